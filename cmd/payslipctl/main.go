@@ -0,0 +1,183 @@
+// Command payslipctl runs administrative tasks against the payslip database from the command line.
+//
+// Usage:
+//
+//	payslipctl import-employees -file employees.csv [-dry-run]
+//	payslipctl import-holidays -file holidays.ics [-format ics|csv]
+//	payslipctl close-attendance [-policy flag|auto_close]
+//	payslipctl import-attlog -file attlog.dat
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"payslip-generator/pkg/config"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/services"
+	"strings"
+	"time"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	// Keep stdout for the report; GORM query logging would drown it out
+	if os.Getenv("LOG_LEVEL") == "" {
+		os.Setenv("LOG_LEVEL", "silent")
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import-employees":
+		err = importEmployees(os.Args[2:])
+	case "import-holidays":
+		err = importHolidays(os.Args[2:])
+	case "close-attendance":
+		err = closeAttendance(os.Args[2:])
+	case "import-attlog":
+		err = importAttlog(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: payslipctl <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  import-employees   Create employees in bulk from a CSV file (username,salary[,password])")
+	fmt.Fprintln(os.Stderr, "  import-holidays    Add or update company holidays from an ICS or CSV (date,name) file")
+	fmt.Fprintln(os.Stderr, "  close-attendance   Apply the missing check-out policy to attendance left open on previous days")
+	fmt.Fprintln(os.Stderr, "  import-attlog      Record attendance from a fingerprint terminal attlog export (PIN, timestamp, verify mode)")
+}
+
+// connect loads configuration and opens the database the same way the server does
+func connect() {
+	config.LoadConfig()
+	database.ConnectDB()
+}
+
+func importEmployees(args []string) error {
+	fs := flag.NewFlagSet("import-employees", flag.ExitOnError)
+	filePath := fs.String("file", "", "Path to the CSV file (required)")
+	dryRun := fs.Bool("dry-run", false, "Validate the file without creating employees")
+	fs.Parse(args)
+
+	if *filePath == "" {
+		fs.Usage()
+		return errors.New("-file is required")
+	}
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	connect()
+	report, importErr := services.NewEmployeeImporter(database.DB).Import(file, services.EmployeeImportOptions{DryRun: *dryRun})
+	if report != nil {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	}
+	return importErr
+}
+
+func importHolidays(args []string) error {
+	fs := flag.NewFlagSet("import-holidays", flag.ExitOnError)
+	filePath := fs.String("file", "", "Path to the ICS or CSV file (required)")
+	format := fs.String("format", "", "ics or csv; inferred from the file extension when omitted")
+	fs.Parse(args)
+
+	if *filePath == "" {
+		fs.Usage()
+		return errors.New("-file is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*filePath), ".")
+	}
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entries, source, err := services.ParseHolidayFile(file, *format)
+	if err != nil {
+		return err
+	}
+
+	connect()
+	result, err := services.NewHolidayService(database.DB).Import(entries, source, nil, "", "")
+	if err != nil {
+		return err
+	}
+	return printJSON(result)
+}
+
+func closeAttendance(args []string) error {
+	fs := flag.NewFlagSet("close-attendance", flag.ExitOnError)
+	policy := fs.String("policy", "", "flag or auto_close; defaults to MISSING_CHECKOUT_POLICY")
+	fs.Parse(args)
+
+	connect()
+	if *policy == "" {
+		*policy = config.AppConfig.MissingCheckOutPolicy
+	}
+	closed, err := services.CloseMissingCheckOuts(database.DB, *policy, time.Now())
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{"policy": *policy, "records": closed})
+}
+
+func importAttlog(args []string) error {
+	fs := flag.NewFlagSet("import-attlog", flag.ExitOnError)
+	filePath := fs.String("file", "", "Path to the tab-separated attlog file (required)")
+	fs.Parse(args)
+
+	if *filePath == "" {
+		fs.Usage()
+		return errors.New("-file is required")
+	}
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	punches, err := services.ParseAttlog(file, time.Local)
+	if err != nil {
+		return err
+	}
+
+	connect()
+	report, err := services.NewAttlogImporter(database.DB).Import(punches, services.AttlogImportOptions{})
+	if err != nil {
+		return err
+	}
+	return printJSON(report)
+}
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
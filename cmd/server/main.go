@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"payslip-generator/pkg/config"
 	"payslip-generator/pkg/database"
 	"payslip-generator/pkg/middleware"
 	"payslip-generator/pkg/routes"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/storage"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 
@@ -40,10 +44,36 @@ func main() {
 	// Seed data - In a real app, you might control this with a flag
 	database.SeedData(database.DB)
 
-	app := fiber.New()
+	// Load the income tax tables and BPJS rates payroll withholds PPh 21 and deducts social security contributions by.
+	// This must happen before the payroll workers start, or runs resumed from a previous process would be paid without them.
+	if err := services.LoadIncomeTaxTables(config.AppConfig.TaxTablesFile); err != nil {
+		log.Fatal(err)
+	}
+	if err := services.LoadSocialSecurityTables(config.AppConfig.SocialSecurityRatesFile); err != nil {
+		log.Fatal(err)
+	}
+
+	// Start background payroll workers; unfinished runs from a previous process are picked up again
+	services.StartPayrollWorkers(context.Background(), database.DB, 2)
+
+	// Apply the missing check-out policy to attendance left open on previous days
+	services.StartAttendanceCloser(context.Background(), database.DB, config.AppConfig.MissingCheckOutPolicy, time.Hour)
+
+	// Bring employees' salaries up to date as scheduled salary changes take effect
+	services.StartSalaryUpdater(context.Background(), database.DB, time.Hour)
+
+	// Connect the receipt store
+	if err := storage.ConnectReceiptStore(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Stream request bodies over the default limit, so the receipt upload routes can take larger ones
+	app := fiber.New(fiber.Config{StreamRequestBody: true, DisablePreParseMultipartForm: true})
 
 	// Register middleware
 	app.Use(middleware.RequestID)       // Add RequestID middleware first
+	// Routes other than the receipt uploads keep the default body limit
+	app.Use(middleware.LimitBody(fiber.DefaultBodyLimit, routes.AcceptsReceiptUpload))
 	app.Use(middleware.Logger)          // Add Logger middleware next
 	app.Use(middleware.DeserializeUser) // DeserializeUser for auth
 
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"payslip-generator/pkg/pdf"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +16,19 @@ type Config struct {
 	DBPassword string
 	DBName    string
 	DBPort    string
+	MissingCheckOutPolicy string // "flag" (default) or "auto_close"
+	ReceiptStorage    string // "local" (default) or "s3"
+	ReceiptStorageDir string // Directory of the local receipt store
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	CompanyName       string // Printed at the top of payslip PDFs
+	CompanyAddress    string
+	PayslipBrandColor string // #RRGGBB color of the payslip PDF header
+	TaxTablesFile     string // JSON file of the income tax (PPh 21) tables
+	SocialSecurityRatesFile string // JSON file of the BPJS contribution rates and wage caps
 }
 
 // AppConfig is the global configuration variable
@@ -45,6 +59,59 @@ func LoadConfig() {
 	AppConfig.DBName = os.Getenv("DB_NAME")
 	AppConfig.DBPort = os.Getenv("DB_PORT")
 
+	AppConfig.MissingCheckOutPolicy = os.Getenv("MISSING_CHECKOUT_POLICY")
+	switch AppConfig.MissingCheckOutPolicy {
+	case "":
+		AppConfig.MissingCheckOutPolicy = "flag"
+	case "flag", "auto_close":
+	default:
+		log.Fatalf("MISSING_CHECKOUT_POLICY must be 'flag' or 'auto_close', got %q", AppConfig.MissingCheckOutPolicy)
+	}
+
+	AppConfig.ReceiptStorage = os.Getenv("RECEIPT_STORAGE")
+	switch AppConfig.ReceiptStorage {
+	case "":
+		AppConfig.ReceiptStorage = "local"
+	case "local", "s3":
+	default:
+		log.Fatalf("RECEIPT_STORAGE must be 'local' or 's3', got %q", AppConfig.ReceiptStorage)
+	}
+	AppConfig.ReceiptStorageDir = os.Getenv("RECEIPT_STORAGE_DIR")
+	if AppConfig.ReceiptStorageDir == "" {
+		AppConfig.ReceiptStorageDir = "storage/receipts"
+	}
+	AppConfig.S3Endpoint = os.Getenv("S3_ENDPOINT")
+	AppConfig.S3Region = os.Getenv("S3_REGION")
+	if AppConfig.S3Region == "" {
+		AppConfig.S3Region = "us-east-1"
+	}
+	AppConfig.S3Bucket = os.Getenv("S3_BUCKET")
+	AppConfig.S3AccessKeyID = os.Getenv("S3_ACCESS_KEY_ID")
+	AppConfig.S3SecretAccessKey = os.Getenv("S3_SECRET_ACCESS_KEY")
+
+	AppConfig.CompanyName = os.Getenv("COMPANY_NAME")
+	if AppConfig.CompanyName == "" {
+		AppConfig.CompanyName = "Payslip Generator"
+	}
+	AppConfig.CompanyAddress = os.Getenv("COMPANY_ADDRESS")
+	AppConfig.PayslipBrandColor = os.Getenv("PAYSLIP_BRAND_COLOR")
+	if AppConfig.PayslipBrandColor == "" {
+		AppConfig.PayslipBrandColor = "#1F4E79"
+	}
+	if _, err := pdf.ParseHexColor(AppConfig.PayslipBrandColor); err != nil {
+		log.Fatalf("PAYSLIP_BRAND_COLOR: %v", err)
+	}
+
+	AppConfig.TaxTablesFile = os.Getenv("TAX_TABLES_FILE")
+	if AppConfig.TaxTablesFile == "" {
+		AppConfig.TaxTablesFile = "config/income_tax_tables.json"
+	}
+
+	AppConfig.SocialSecurityRatesFile = os.Getenv("SOCIAL_SECURITY_RATES_FILE")
+	if AppConfig.SocialSecurityRatesFile == "" {
+		AppConfig.SocialSecurityRatesFile = "config/social_security_rates.json"
+	}
+
 	// Basic check for essential DB config
 	if AppConfig.DBHost == "" || AppConfig.DBUser == "" || AppConfig.DBName == "" || AppConfig.DBPort == "" {
 		log.Println("Warning: One or more database connection environment variables (DB_HOST, DB_USER, DB_NAME, DB_PORT) are not set.")
@@ -2,8 +2,6 @@ package controllers
 
 import (
 	"fmt"
-	"payslip-generator/pkg/config"
-	"encoding/json"
 	"payslip-generator/pkg/constants"
 	"payslip-generator/pkg/database"
 	"payslip-generator/pkg/models"
@@ -13,7 +11,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -101,17 +99,22 @@ type RunPayrollPayload struct {
 
 // RunPayroll godoc
 // @Summary Run Payroll
-// @Description Allows an admin to run payroll for a specified attendance period.
+// @Description Allows an admin to queue a payroll run for a specified attendance period. Payslips are generated by a background worker; poll the returned run for progress.
+// @Description Only approved overtime is paid; the completed run lists overtime still awaiting review under warnings.
+// @Description Employees whose payslip cannot be calculated are counted as failed and listed under warnings while the others are paid; the run then
+// @Description fails and the period stays open, so running payroll again for it pays only the employees still without a payslip.
 // @Tags Admin
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param payroll_run body RunPayrollPayload true "Payroll Run Details"
-// @Success 200 {object} map[string]string `json:"{"status":"success", "message":"Payroll run successfully for period XYZ"}"`
-// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"error_message (e.g., invalid ID, payroll already run, zero working days)"}"`
-// @Failure 401 {object} map[string]string `json:"{"status":"fail", "message":"Admin ID not found in token or invalid."}"`
-// @Failure 404 {object} map[string]string `json:"{"status":"fail", "message":"Attendance period not found."}"`
-// @Failure 500 {object} map[string]string `json:"{"status":"error", "message":"An internal error occurred during payroll processing."}"`
+// @Success 202 {object} object{status=string,message=string,data=models.PayrollRun} "Payroll run queued"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID, payroll already run, or zero working days"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Attendance period not found"
+// @Failure 409 {object} object{status=string,message=string} "A payroll run for this period is already in progress"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Failure 503 {object} object{status=string,message=string} "Payroll worker is not available or queue is full"
 // @Router /admin/payroll [post]
 func RunPayroll(c *fiber.Ctx) error {
 	var payload RunPayrollPayload
@@ -131,10 +134,155 @@ func RunPayroll(c *fiber.Ctx) error {
 	ipAddress := c.IP()
 	requestIDVal := c.Locals(constants.RequestIDKey.String())
 	requestID, _ := requestIDVal.(string)
-	auditService := services.NewAuditService(database.DB) // auditService for transaction
 
+	if services.PayrollQueue == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "error", "message": "Payroll worker is not running."})
+	}
+
+	var attendancePeriod models.AttendancePeriod
+	if err := database.DB.First(&attendancePeriod, "id = ?", periodID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Attendance period not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	if attendancePeriod.PayrollRunAt != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Payroll already run for this period."})
+	}
+
+	// Avoid division by zero later: every day of the period is a holiday, so no schedule has working days.
+	// Employees whose own schedule has no working days in the period are reported as failed on the run; the others are still paid.
+	totalWorkingDays, err := services.NewHolidayService(database.DB).CountWorkingDays(attendancePeriod.StartDate, attendancePeriod.EndDate, utils.EveryDayWorkWeek)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	if totalWorkingDays == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Payroll cannot be run for a period with zero total working days."})
+	}
+
+	var activeRuns int64
+	if err := database.DB.Model(&models.PayrollRun{}).
+		Where("attendance_period_id = ? AND status IN ?", periodID, []string{models.PayrollRunQueued, models.PayrollRunRunning}).
+		Count(&activeRuns).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	if activeRuns > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": "A payroll run for this period is already in progress."})
+	}
+
+	run := models.PayrollRun{
+		AttendancePeriodID: periodID,
+		Status:             models.PayrollRunQueued,
+		RequestID:          requestID,
+	}
+	run.CreatedBy = &adminID
+	run.UpdatedBy = &adminID
+	run.IPAddress = &ipAddress
+
+	if err := database.DB.Create(&run).Error; err != nil {
+		// The partial unique index rejects a second active run that slipped past the check above
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Could not queue payroll run: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "queue_payroll",
+		TargetResource:   "payroll_run",
+		TargetResourceID: run.ID,
+		Changes:          run,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	if err := services.PayrollQueue.Enqueue(run.ID); err != nil {
+		errMessage := err.Error()
+		database.DB.Model(&run).Updates(map[string]interface{}{"status": models.PayrollRunFailed, "error": errMessage})
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "error", "message": "Payroll queue is full, please retry later."})
+	}
+
+	utils.Logger.Info("Payroll run queued", zap.String("period_id", periodID.String()), zap.String("run_id", run.ID.String()), zap.String("request_id", requestID))
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": "success", "message": "Payroll run queued for period " + periodID.String(), "data": run})
+}
+
+// GetPayrollRun godoc
+// @Summary Get Payroll Run
+// @Description Allows an admin to poll the status and progress of a payroll run.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payroll Run ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=models.PayrollRun} "Payroll run status"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 404 {object} object{status=string,message=string} "Payroll run not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/payroll/runs/{id} [get]
+func GetPayrollRun(c *fiber.Ctx) error {
+	runID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid payroll run ID format."})
+	}
+
+	var run models.PayrollRun
+	if err := database.DB.First(&run, "id = ?", runID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Payroll run not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": run})
+}
+
+// VoidPayrollPayload struct for voiding a payroll run
+type VoidPayrollPayload struct {
+	AttendancePeriodID string `json:"attendance_period_id" validate:"required,uuid"`
+	Reason             string `json:"reason" validate:"required"`
+}
+
+// VoidPayroll godoc
+// @Summary Void Payroll
+// @Description Allows an admin to void a completed payroll run, or one that failed for some employees after paying the rest. Payslips for the period are soft-voided and kept for history, reimbursements paid by the run go back to approved, and the period can be run again.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payroll_void body VoidPayrollPayload true "Payroll Void Details"
+// @Success 200 {object} object{status=string,message=string,data=object{payslips_voided=int,reimbursements_reverted=int}} "Payroll voided"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID, missing reason, or payroll not run"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Attendance period not found"
+// @Failure 409 {object} object{status=string,message=string} "Payroll run in progress"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/payroll/void [post]
+func VoidPayroll(c *fiber.Ctx) error {
+	var payload VoidPayrollPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	periodID, err := uuid.Parse(payload.AttendancePeriodID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid AttendancePeriodID format."})
+	}
+	if payload.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Reason is required."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var payslipIDs, reimbursementIDs []uuid.UUID
 	err = database.DB.Transaction(func(tx *gorm.DB) error {
-		// Re-initialize auditService with transactional DB
 		txAuditService := services.NewAuditService(tx)
 
 		var attendancePeriod models.AttendancePeriod
@@ -145,164 +293,268 @@ func RunPayroll(c *fiber.Ctx) error {
 			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
 		}
 
-		if attendancePeriod.PayrollRunAt != nil {
-			return fiber.NewError(fiber.StatusBadRequest, "Payroll already run for this period.")
+		// A run that failed for some employees leaves the period open but has paid the rest, which can be voided too
+		previousRunAt := attendancePeriod.PayrollRunAt
+		if previousRunAt == nil {
+			var activeRuns int64
+			if err := tx.Model(&models.PayrollRun{}).
+				Where("attendance_period_id = ? AND status IN ?", periodID, []string{models.PayrollRunQueued, models.PayrollRunRunning}).
+				Count(&activeRuns).Error; err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+			}
+			if activeRuns > 0 {
+				return fiber.NewError(fiber.StatusConflict, "A payroll run for this period is in progress.")
+			}
 		}
+		now := time.Now()
 
-		var employees []models.Employee
-		if err := tx.Find(&employees).Error; err != nil {
-			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to fetch employees: %v", err))
+		if err := tx.Model(&models.Payslip{}).
+			Where("attendance_period_id = ? AND voided_at IS NULL", periodID).
+			Pluck("id", &payslipIDs).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to fetch payslips: %v", err))
 		}
-
-		totalWorkingDays := utils.CalculateWorkingDays(attendancePeriod.StartDate, attendancePeriod.EndDate)
-		if totalWorkingDays == 0 { // Avoid division by zero later, implies no possible workdays
-			// Update period and log, then return. No payslips generated.
-			now := time.Now()
-			attendancePeriod.PayrollRunAt = &now
-			attendancePeriod.UpdatedBy = &adminID
-			attendancePeriod.IPAddress = &ipAddress
-			if err := tx.Save(&attendancePeriod).Error; err != nil {
-				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to update attendance period: %v", err))
-			}
-			// Log audit for payroll run attempt on zero working day period
-			txAuditService.CreateAuditLog(services.AuditLogEntryParams{
-				UserID:           adminID,
-				UserType:         "admin",
-				Action:           "run_payroll_zero_working_days",
-				TargetResource:   "attendance_period",
-				TargetResourceID: attendancePeriod.ID,
-				Changes:          map[string]interface{}{"message": "Attempted payroll run for period with zero working days."},
-				IPAddress:        ipAddress,
-				RequestID:        requestID,
-				PerformedBy:      adminID,
-			})
-			return fiber.NewError(fiber.StatusBadRequest, "Payroll cannot be run for a period with zero total working days.")
+		if previousRunAt == nil && len(payslipIDs) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Payroll has not been run for this period.")
 		}
-
-		var payslipsGenerated int
-		for _, emp := range employees {
-			var attendanceRecords []models.AttendanceRecord
-			tx.Where("employee_id = ? AND date BETWEEN ? AND ?", emp.ID, attendancePeriod.StartDate, attendancePeriod.EndDate).Find(&attendanceRecords)
-
-			uniqueAttendanceDates := make(map[string]struct{})
-			for _, ar := range attendanceRecords {
-				uniqueAttendanceDates[ar.Date.Format("2006-01-02")] = struct{}{}
+		if len(payslipIDs) > 0 {
+			if err := tx.Model(&models.Payslip{}).Where("id IN ?", payslipIDs).Updates(map[string]interface{}{
+				"voided_at":   now,
+				"voided_by":   adminID,
+				"void_reason": payload.Reason,
+				"updated_by":  adminID,
+				"ip_address":  ipAddress,
+			}).Error; err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to void payslips: %v", err))
 			}
-			attendanceCount := len(uniqueAttendanceDates)
-
-			proratedSalary := decimal.NewFromFloat(0)
-			if totalWorkingDays > 0 {
-				proratedSalary = decimal.NewFromFloat(emp.Salary).Div(decimal.NewFromInt(int64(totalWorkingDays))).Mul(decimal.NewFromInt(int64(attendanceCount)))
-			}
-
-			var overtimeRecords []models.OvertimeRecord
-			tx.Where("employee_id = ? AND date BETWEEN ? AND ?", emp.ID, attendancePeriod.StartDate, attendancePeriod.EndDate).Find(&overtimeRecords)
-
-			overtimeHours := decimal.NewFromFloat(0)
-			overtimePay := decimal.NewFromFloat(0)
-			if totalWorkingDays > 0 { // Avoid division by zero if no working days
-				dailySalary := decimal.NewFromFloat(emp.Salary).Div(decimal.NewFromInt(int64(totalWorkingDays)))
-				hourlySalary := dailySalary.Div(decimal.NewFromInt(8)) // Assuming 8 hour work day
-
-				for _, ot := range overtimeRecords {
-					hoursDecimal := decimal.NewFromInt(int64(ot.Hours))
-					rateMultiplierDecimal := decimal.NewFromFloat(ot.RateMultiplier)
-					currentOvertimePay := hourlySalary.Mul(hoursDecimal).Mul(rateMultiplierDecimal)
-					overtimePay = overtimePay.Add(currentOvertimePay)
-					overtimeHours = overtimeHours.Add(hoursDecimal)
-				}
-			}
-
-			var reimbursementRequests []models.ReimbursementRequest
-			tx.Where("employee_id = ? AND status = ? AND (attendance_period_id IS NULL OR attendance_period_id = ?)", emp.ID, "approved", periodID).Find(&reimbursementRequests)
-
-			reimbursementsTotal := decimal.NewFromFloat(0)
-			for i := range reimbursementRequests { // Use index to modify slice elements
-				rr := &reimbursementRequests[i]
-				reimbursementsTotal = reimbursementsTotal.Add(decimal.NewFromFloat(rr.Amount))
-				rr.AttendancePeriodID = periodID
-				rr.Status = "paid"
-				rr.UpdatedBy = &adminID
-				rr.IPAddress = &ipAddress
-				if err := tx.Save(rr).Error; err != nil {
-					return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to update reimbursement request %s: %v", rr.ID, err))
-				}
-			}
-
-			takeHomePay := proratedSalary.Add(overtimePay).Add(reimbursementsTotal)
-
-			payslip := models.Payslip{
-				EmployeeID:          emp.ID,
-				AttendancePeriodID:  periodID,
-				BaseSalary:          emp.Salary,
-				ProratedSalary:      proratedSalary.InexactFloat64(),
-				AttendanceCount:     attendanceCount,
-				TotalWorkingDays:    totalWorkingDays,
-				OvertimeHours:       overtimeHours.InexactFloat64(),
-				OvertimePay:         overtimePay.InexactFloat64(),
-				ReimbursementsTotal: reimbursementsTotal.InexactFloat64(),
-				TakeHomePay:         takeHomePay.InexactFloat64(),
-			}
-			payslip.CreatedBy = &adminID
-			payslip.UpdatedBy = &adminID
-			payslip.IPAddress = &ipAddress
+		}
 
-			if err := tx.Create(&payslip).Error; err != nil {
-				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to create payslip for employee %s: %v", emp.ID, err))
+		// RunPayroll links every reimbursement it pays to the period, so these are exactly the ones it consumed
+		if err := tx.Model(&models.ReimbursementRequest{}).
+			Where("attendance_period_id = ? AND status = ?", periodID, models.ReimbursementPaid).
+			Pluck("id", &reimbursementIDs).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to fetch reimbursements: %v", err))
+		}
+		if len(reimbursementIDs) > 0 {
+			if err := tx.Model(&models.ReimbursementRequest{}).Where("id IN ?", reimbursementIDs).Updates(map[string]interface{}{
+				"status":     models.ReimbursementApproved,
+				"updated_by": adminID,
+				"ip_address": ipAddress,
+			}).Error; err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to revert reimbursements: %v", err))
 			}
-			payslipsGenerated++
 		}
 
-		now := time.Now()
-		attendancePeriod.PayrollRunAt = &now
-		attendancePeriod.UpdatedBy = &adminID
-		attendancePeriod.IPAddress = &ipAddress
-		if err := tx.Save(&attendancePeriod).Error; err != nil {
+		if err := tx.Model(&attendancePeriod).Updates(map[string]interface{}{
+			"payroll_run_at": nil,
+			"updated_by":     adminID,
+			"ip_address":     ipAddress,
+		}).Error; err != nil {
 			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to update attendance period: %v", err))
 		}
 
-		// Audit Log for successful payroll run
 		txAuditService.CreateAuditLog(services.AuditLogEntryParams{
 			UserID:           adminID,
 			UserType:         "admin",
-			Action:           "run_payroll",
+			Action:           "void_payroll",
 			TargetResource:   "attendance_period",
 			TargetResourceID: attendancePeriod.ID,
-			Changes:          map[string]interface{}{"payslips_generated": payslipsGenerated, "period_id": attendancePeriod.ID},
-			IPAddress:        ipAddress,
-			RequestID:        requestID,
-			PerformedBy:      adminID,
+			Changes: map[string]interface{}{
+				"reason":                  payload.Reason,
+				"previous_payroll_run_at": previousRunAt,
+				"voided_payslip_ids":      payslipIDs,
+				"reverted_reimbursements": reimbursementIDs,
+			},
+			IPAddress:   ipAddress,
+			RequestID:   requestID,
+			PerformedBy: adminID,
 		})
 
-		return nil // Commit transaction
+		return nil
 	})
 
 	if err != nil {
-		// Log the original error before returning a generic one to client
-		utils.Logger.Error("Payroll transaction failed", zap.Error(err), zap.String("request_id", requestID))
+		utils.Logger.Error("Payroll void transaction failed", zap.Error(err), zap.String("request_id", requestID))
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"status": "fail", "message": fe.Message})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred during payroll processing."})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while voiding payroll."})
 	}
-	// Explicitly log success after transaction for clarity
-	utils.Logger.Info("Payroll run completed successfully", zap.String("period_id", periodID.String()), zap.String("request_id", requestID))
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Payroll run successfully for period " + periodID.String()})
+
+	utils.Logger.Info("Payroll voided", zap.String("period_id", periodID.String()), zap.String("request_id", requestID))
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Payroll voided for period " + periodID.String(),
+		"data": fiber.Map{
+			"payslips_voided":         len(payslipIDs),
+			"reimbursements_reverted": len(reimbursementIDs),
+		},
+	})
 }
 
+// PayrollPreviewEntry holds the would-be payslip figures for one employee
+type PayrollPreviewEntry struct {
+	EmployeeID              uuid.UUID                      `json:"employee_id"`
+	Username                string                         `json:"username"`
+	WorkSchedule            string                         `json:"work_schedule"`
+	TotalWorkingDays        int                            `json:"total_working_days"` // Under the employee's own work schedule
+	BaseSalary              models.Money                   `json:"base_salary"`        // In force at the end of the period
+	SalarySegments          []models.SalarySegment         `json:"salary_segments"`
+	AttendanceCount         int                            `json:"attendance_count"` // Includes paid leave days
+	PaidLeaveDays           int                            `json:"paid_leave_days"`
+	UnpaidLeaveDays         int                            `json:"unpaid_leave_days"`
+	UnpaidLeaveDeduction    models.Money                   `json:"unpaid_leave_deduction"`
+	ProratedSalary          models.Money                   `json:"prorated_salary"`
+	OvertimeHours           float64                        `json:"overtime_hours"`
+	OvertimePay             models.Money                   `json:"overtime_pay"`
+	ReimbursementsTotal     models.Money                   `json:"reimbursements_total"`
+	SocialSecurityDeduction models.Money                   `json:"social_security_deduction"` // Employee's BPJS contributions
+	EmployerContributions   models.Money                   `json:"employer_contributions"`    // Employer's BPJS contributions, on top of pay
+	Contributions           []services.PayrollContribution `json:"contributions"`
+	IncomeTax               models.Money                   `json:"income_tax"`
+	TaxCalculation          *models.TaxCalculation         `json:"tax_calculation,omitempty"`
+	TakeHomePay             models.Money                   `json:"take_home_pay"`
+	LineItems               []services.PayrollLineItem     `json:"line_items"`
+}
+
+// PayrollPreviewResponse defines the structure for a payroll dry-run
+type PayrollPreviewResponse struct {
+	PeriodID                     uuid.UUID                 `json:"period_id"`
+	TotalWorkingDays             int                       `json:"total_working_days"` // Under the standard Monday to Friday schedule
+	Employees                    []PayrollPreviewEntry     `json:"employees"`
+	TotalTakeHomePayAllEmployees models.Money              `json:"total_take_home_pay_all_employees"`
+	TotalEmployerContributions   models.Money              `json:"total_employer_contributions"` // Employer's BPJS cost for the period
+	Warnings                     []services.PayrollWarning `json:"warnings"`       // Items that would be left out, e.g. overtime awaiting review
+	PayrollPolicy                models.PayrollPolicy      `json:"payroll_policy"` // In effect on the first day of the period
+}
+
+// PreviewPayroll godoc
+// @Summary Preview Payroll
+// @Description Allows an admin to see what every employee would be paid for an attendance period without running payroll. Nothing is persisted.
+// @Description Only approved overtime is paid; overtime still awaiting review is listed under warnings, as are employees whose payslip could not be calculated.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payroll_run body RunPayrollPayload true "Payroll Run Details"
+// @Success 200 {object} object{status=string,data=PayrollPreviewResponse} "Per-employee payroll figures"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID, payroll already run, or zero working days"
+// @Failure 404 {object} object{status=string,message=string} "Attendance period not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/payroll/preview [post]
+func PreviewPayroll(c *fiber.Ctx) error {
+	var payload RunPayrollPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	periodID, err := uuid.Parse(payload.AttendancePeriodID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid AttendancePeriodID format."})
+	}
+
+	var attendancePeriod models.AttendancePeriod
+	if err := database.DB.First(&attendancePeriod, "id = ?", periodID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Attendance period not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	if attendancePeriod.PayrollRunAt != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Payroll already run for this period."})
+	}
+
+	holidays, err := services.NewHolidayService(database.DB).LoadHolidaySet(attendancePeriod.StartDate, attendancePeriod.EndDate)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	if utils.CalculateWorkingDays(attendancePeriod.StartDate, attendancePeriod.EndDate, utils.EveryDayWorkWeek, holidays) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Payroll cannot be run for a period with zero total working days."})
+	}
+
+	employees, err := services.LoadPayrollEmployees(database.DB, attendancePeriod)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+
+	policy, err := services.NewPayrollPolicyService(database.DB).PolicyFor(attendancePeriod.StartDate)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+
+	// Same calculation as RunPayroll, but reads only: no payslips, no reimbursement status changes, no PayrollRunAt
+	payrollEngine := services.NewPayrollEngine()
+	entries := make([]PayrollPreviewEntry, 0, len(employees))
+	warnings := []services.PayrollWarning{}
+	var totalTakeHomePay, totalEmployerContributions models.Money
+	for _, emp := range employees {
+		// An employee the run could not pay is left out with a warning, as the run does
+		input, err := services.LoadPayrollInput(database.DB, emp, attendancePeriod, holidays)
+		if err != nil {
+			warnings = append(warnings, services.PayslipFailedWarning(emp, err))
+			continue
+		}
+		result, err := payrollEngine.Calculate(input)
+		if err != nil {
+			warnings = append(warnings, services.PayslipFailedWarning(emp, err))
+			continue
+		}
+
+		p := result.Payslip
+		entries = append(entries, PayrollPreviewEntry{
+			EmployeeID:           emp.ID,
+			Username:             emp.Username,
+			WorkSchedule:         input.WorkSchedule.Name,
+			TotalWorkingDays:     p.TotalWorkingDays,
+			BaseSalary:           p.BaseSalary,
+			SalarySegments:       p.SalarySegments,
+			AttendanceCount:      p.AttendanceCount,
+			PaidLeaveDays:        p.PaidLeaveDays,
+			UnpaidLeaveDays:      p.UnpaidLeaveDays,
+			UnpaidLeaveDeduction: p.UnpaidLeaveDeduction,
+			ProratedSalary:       p.ProratedSalary,
+			OvertimeHours:        p.OvertimeHours,
+			OvertimePay:          p.OvertimePay,
+			ReimbursementsTotal:  p.ReimbursementsTotal,
+			SocialSecurityDeduction: p.SocialSecurityDeduction,
+			EmployerContributions:   p.EmployerContributions,
+			Contributions:           result.Contributions,
+			IncomeTax:            p.IncomeTax,
+			TaxCalculation:       p.TaxCalculation,
+			TakeHomePay:          p.TakeHomePay,
+			LineItems:            result.LineItems,
+		})
+		warnings = append(warnings, result.Warnings...)
+		totalTakeHomePay = totalTakeHomePay.Add(p.TakeHomePay)
+		totalEmployerContributions = totalEmployerContributions.Add(p.EmployerContributions)
+	}
+
+	response := PayrollPreviewResponse{
+		PeriodID:                     periodID,
+		TotalWorkingDays:             utils.CalculateWorkingDays(attendancePeriod.StartDate, attendancePeriod.EndDate, utils.StandardWorkWeek, holidays),
+		Employees:                    entries,
+		TotalTakeHomePayAllEmployees: totalTakeHomePay,
+		TotalEmployerContributions:   totalEmployerContributions,
+		Warnings:                     warnings,
+		PayrollPolicy:                policy,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": response})
+}
 
 // GetPayslipsSummaryResponse defines the structure for payslip summary
 type GetPayslipsSummaryResponse struct {
 	PeriodID                      uuid.UUID        `json:"period_id"`
 	Summary                       []PayslipSummary `json:"summary"`
-	TotalTakeHomePayAllEmployees float64          `json:"total_take_home_pay_all_employees"`
+	TotalTakeHomePayAllEmployees models.Money     `json:"total_take_home_pay_all_employees"`
 }
 
 // PayslipSummary holds individual employee payslip info
 type PayslipSummary struct {
 	EmployeeID   uuid.UUID `json:"employee_id"`
 	Username     string    `json:"username"` // Assuming Employee has Username
-	TakeHomePay float64   `json:"take_home_pay"`
+	TakeHomePay models.Money `json:"take_home_pay"`
+	VoidedAt    *time.Time `json:"voided_at,omitempty"` // Only set when include_voided=true returns a voided payslip
 }
 
 // GetPayslipsSummary godoc
@@ -313,6 +565,7 @@ type PayslipSummary struct {
 // @Produce json
 // @Security BearerAuth
 // @Param period_id query string true "Attendance Period ID (UUID)" format(uuid)
+// @Param include_voided query bool false "Also list payslips from voided payroll runs (excluded from the total)"
 // @Success 200 {object} map[string]interface{} `json:"{"status":"success", "data": GetPayslipsSummaryResponse}"`
 // @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"period_id query parameter is required / Invalid period_id format."}"`
 // @Failure 401 {object} map[string]string `json:"{"status":"fail", "message":"Unauthorized"}"` // Implicit via middleware
@@ -330,26 +583,33 @@ func GetPayslipsSummary(c *fiber.Ctx) error {
 
 	var payslips []models.Payslip
 	// Preload Employee to get Username
-	if err := database.DB.Preload("Employee").Where("attendance_period_id = ?", periodID).Find(&payslips).Error; err != nil {
+	query := database.DB.Preload("Employee").Where("attendance_period_id = ?", periodID)
+	if !c.QueryBool("include_voided") {
+		query = query.Where("voided_at IS NULL")
+	}
+	if err := query.Find(&payslips).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch payslips: %v", err)})
 	}
 
 	var summaryList []PayslipSummary
-	totalTakeHomePay := decimal.NewFromFloat(0)
+	var totalTakeHomePay models.Money
 
 	for _, p := range payslips {
 		summaryList = append(summaryList, PayslipSummary{
 			EmployeeID:  p.EmployeeID,
 			Username:    p.Employee.Username, // Accessing preloaded employee's username
 			TakeHomePay: p.TakeHomePay,
+			VoidedAt:    p.VoidedAt,
 		})
-		totalTakeHomePay = totalTakeHomePay.Add(decimal.NewFromFloat(p.TakeHomePay))
+		if p.VoidedAt == nil {
+			totalTakeHomePay = totalTakeHomePay.Add(p.TakeHomePay)
+		}
 	}
 
 	response := GetPayslipsSummaryResponse{
 		PeriodID:                      periodID,
 		Summary:                       summaryList,
-		TotalTakeHomePayAllEmployees: totalTakeHomePay.InexactFloat64(),
+		TotalTakeHomePayAllEmployees: totalTakeHomePay,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": response})
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AttendanceListItem is the admin view of an attendance record
+type AttendanceListItem struct {
+	ID                 uuid.UUID  `json:"id"`
+	EmployeeID         uuid.UUID  `json:"employee_id"`
+	Username           string     `json:"username"`
+	AttendancePeriodID uuid.UUID  `json:"attendance_period_id"`
+	Date               string     `json:"date"`
+	CheckInTime        time.Time  `json:"check_in_time"`
+	CheckOutTime       *time.Time `json:"check_out_time,omitempty"`
+	CheckOutStatus     string     `json:"check_out_status"`
+	WorkedMinutes      *int       `json:"worked_minutes,omitempty"`
+}
+
+// ListAttendanceRecords godoc
+// @Summary List Attendance Records
+// @Description Allows an admin to list attendance records, those flagged for a missing check-out by default.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param check_out_status query string false "Check-out status filter (open, checked_out, auto_closed, flagged); defaults to flagged"
+// @Param employee_id query string false "Employee ID (UUID)" format(uuid)
+// @Param period_id query string false "Attendance Period ID (UUID)" format(uuid)
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]AttendanceListItem,pagination=utils.Pagination} "Attendance records"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/attendance [get]
+func ListAttendanceRecords(c *fiber.Ctx) error {
+	status := c.Query("check_out_status", models.CheckOutFlagged)
+	switch status {
+	case models.CheckOutOpen, models.CheckOutRecorded, models.CheckOutAutoClosed, models.CheckOutFlagged:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid check_out_status filter."})
+	}
+
+	query := database.DB.Model(&models.AttendanceRecord{}).Preload("Employee").Where("check_out_status = ?", status)
+
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid employee_id format."})
+		}
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	if periodIDStr := c.Query("period_id"); periodIDStr != "" {
+		periodID, err := uuid.Parse(periodIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+		}
+		query = query.Where("attendance_period_id = ?", periodID)
+	}
+
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count attendance records: %v", err)})
+	}
+
+	var records []models.AttendanceRecord
+	if err := pageQuery.Order("date, check_in_time").Find(&records).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch attendance records: %v", err)})
+	}
+
+	items := make([]AttendanceListItem, 0, len(records))
+	for _, record := range records {
+		items = append(items, AttendanceListItem{
+			ID:                 record.ID,
+			EmployeeID:         record.EmployeeID,
+			Username:           record.Employee.Username,
+			AttendancePeriodID: record.AttendancePeriodID,
+			Date:               record.Date.Format("2006-01-02"),
+			CheckInTime:        record.CheckInTime,
+			CheckOutTime:       record.CheckOutTime,
+			CheckOutStatus:     record.CheckOutStatus,
+			WorkedMinutes:      record.WorkedMinutes,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// ResolveCheckOutPayload struct for setting the check-out of a flagged attendance record
+type ResolveCheckOutPayload struct {
+	CheckOutTime time.Time `json:"check_out_time" validate:"required"` // RFC 3339, e.g. 2024-01-15T17:30:00+07:00
+}
+
+// ResolveCheckOut godoc
+// @Summary Resolve Missing Check-Out
+// @Description Allows an admin to record the check-out time of an attendance record that is open or flagged for review.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance Record ID (UUID)" format(uuid)
+// @Param check_out body ResolveCheckOutPayload true "Check-out time"
+// @Success 200 {object} object{status=string,data=models.AttendanceRecord} "Updated attendance record"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID or check-out time"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Attendance record not found"
+// @Failure 409 {object} object{status=string,message=string} "Record already checked out or payroll has been run"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/attendance/{id}/check-out [post]
+func ResolveCheckOut(c *fiber.Ctx) error {
+	recordID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid attendance record ID format."})
+	}
+
+	var payload ResolveCheckOutPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	if payload.CheckOutTime.IsZero() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "check_out_time is required."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var record models.AttendanceRecord
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("AttendancePeriod").First(&record, "id = ?", recordID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusNotFound, "Attendance record not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+
+		if record.CheckOutStatus != models.CheckOutOpen && record.CheckOutStatus != models.CheckOutFlagged {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Attendance record is already %s.", record.CheckOutStatus))
+		}
+		if record.AttendancePeriod.PayrollRunAt != nil {
+			return fiber.NewError(fiber.StatusConflict, "Payroll has already been run for this attendance period.")
+		}
+		if !payload.CheckOutTime.After(record.CheckInTime) {
+			return fiber.NewError(fiber.StatusBadRequest, "check_out_time must be after the check-in time.")
+		}
+		if payload.CheckOutTime.After(time.Now()) {
+			return fiber.NewError(fiber.StatusBadRequest, "check_out_time cannot be in the future.")
+		}
+
+		previousStatus := record.CheckOutStatus
+		services.ApplyCheckOut(&record, payload.CheckOutTime, models.CheckOutRecorded)
+		if err := tx.Model(&record).Updates(map[string]interface{}{
+			"check_out_time":   record.CheckOutTime,
+			"check_out_status": record.CheckOutStatus,
+			"worked_minutes":   record.WorkedMinutes,
+			"updated_by":       adminID,
+			"ip_address":       ipAddress,
+		}).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not update attendance record: %v", err))
+		}
+		record.UpdatedBy = &adminID
+		record.IPAddress = &ipAddress
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           adminID,
+			UserType:         "admin",
+			Action:           "resolve_check_out",
+			TargetResource:   "attendance_record",
+			TargetResourceID: record.ID,
+			Changes: map[string]interface{}{
+				"employee_id":     record.EmployeeID,
+				"previous_status": previousStatus,
+				"check_out_time":  record.CheckOutTime,
+				"worked_minutes":  record.WorkedMinutes,
+			},
+			IPAddress:   ipAddress,
+			RequestID:   requestID,
+			PerformedBy: adminID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while resolving the check-out."})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": record})
+}
@@ -0,0 +1,490 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SubmitAttendanceCorrectionPayload struct for requesting attendance on a missed day
+type SubmitAttendanceCorrectionPayload struct {
+	Date         string `json:"date" validate:"required" example:"2024-01-15"`     // YYYY-MM-DD
+	CheckInTime  string `json:"check_in_time" validate:"required" example:"09:00"` // HH:MM, local time
+	CheckOutTime string `json:"check_out_time" example:"17:30"`                    // Optional HH:MM; without it the missing check-out policy applies
+	Reason       string `json:"reason" validate:"required"`
+}
+
+// AttendanceCorrectionListItem is the list view of an attendance correction request
+type AttendanceCorrectionListItem struct {
+	ID                 uuid.UUID  `json:"id"`
+	EmployeeID         uuid.UUID  `json:"employee_id"`
+	Username           string     `json:"username"`
+	AttendancePeriodID uuid.UUID  `json:"attendance_period_id"`
+	Date               string     `json:"date"`
+	CheckInTime        time.Time  `json:"check_in_time"`
+	CheckOutTime       *time.Time `json:"check_out_time,omitempty"`
+	Reason             string     `json:"reason"`
+	Status             string     `json:"status"`
+	SubmittedAt        time.Time  `json:"submitted_at"`
+	ReviewedAt         *time.Time `json:"reviewed_at,omitempty"`
+	ReviewReason       *string    `json:"review_reason,omitempty"`
+	AttendanceRecordID *uuid.UUID `json:"attendance_record_id,omitempty"`
+}
+
+func newAttendanceCorrectionListItem(ac models.AttendanceCorrection) AttendanceCorrectionListItem {
+	return AttendanceCorrectionListItem{
+		ID:                 ac.ID,
+		EmployeeID:         ac.EmployeeID,
+		Username:           ac.Employee.Username,
+		AttendancePeriodID: ac.AttendancePeriodID,
+		Date:               ac.Date.Format("2006-01-02"),
+		CheckInTime:        ac.CheckInTime,
+		CheckOutTime:       ac.CheckOutTime,
+		Reason:             ac.Reason,
+		Status:             ac.Status,
+		SubmittedAt:        ac.CreatedAt,
+		ReviewedAt:         ac.ReviewedAt,
+		ReviewReason:       ac.ReviewReason,
+		AttendanceRecordID: ac.AttendanceRecordID,
+	}
+}
+
+// SubmitAttendanceCorrection godoc
+// @Summary Submit Attendance Correction
+// @Description Allows an authenticated employee to request attendance for a past working day they did not record,
+// @Description within an attendance period whose payroll has not been run. An admin must approve the request.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param correction body SubmitAttendanceCorrectionPayload true "Attendance correction"
+// @Success 201 {object} object{status=string,data=AttendanceCorrectionListItem} "Submitted correction request"
+// @Failure 400 {object} object{status=string,message=string} "Validation error, non-working day, or no open period"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 409 {object} object{status=string,message=string} "Attendance or a pending correction already exists for the date"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/attendance-corrections [post]
+func SubmitAttendanceCorrection(c *fiber.Ctx) error {
+	var payload SubmitAttendanceCorrectionPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	payload.Reason = strings.TrimSpace(payload.Reason)
+	if payload.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Reason is required."})
+	}
+
+	now := time.Now()
+	date, err := time.ParseInLocation("2006-01-02", payload.Date, now.Location())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid date format. Use YYYY-MM-DD."})
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !date.Before(today) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Corrections are only for past dates. Use the attendance endpoint for today."})
+	}
+	checkIn, err := time.ParseInLocation("2006-01-02 15:04", payload.Date+" "+payload.CheckInTime, now.Location())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid check_in_time format. Use HH:MM."})
+	}
+	var checkOut *time.Time
+	if payload.CheckOutTime != "" {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", payload.Date+" "+payload.CheckOutTime, now.Location())
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid check_out_time format. Use HH:MM."})
+		}
+		if !parsed.After(checkIn) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "check_out_time must be after check_in_time."})
+		}
+		checkOut = &parsed
+	}
+
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var correction models.AttendanceCorrection
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the employee so two corrections for the same day cannot both be filed
+		var employee models.Employee
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&employee, "id = ?", employeeID).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Database error loading employee.")
+		}
+		period, fe := checkAttendanceCorrectionDate(tx, employee, date)
+		if fe != nil {
+			return fe
+		}
+
+		var pending int64
+		if err := tx.Model(&models.AttendanceCorrection{}).
+			Where("employee_id = ? AND date = ? AND status = ?", employeeID, date, models.CorrectionPending).
+			Count(&pending).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Database error checking existing corrections.")
+		}
+		if pending > 0 {
+			return fiber.NewError(fiber.StatusConflict, "A correction request for this date is already pending.")
+		}
+
+		correction = models.AttendanceCorrection{
+			EmployeeID:         employeeID,
+			AttendancePeriodID: period.ID,
+			Date:               date,
+			CheckInTime:        checkIn,
+			CheckOutTime:       checkOut,
+			Reason:             payload.Reason,
+			Status:             models.CorrectionPending,
+			Employee:           employee,
+		}
+		correction.CreatedBy = &employeeID
+		correction.UpdatedBy = &employeeID
+		correction.IPAddress = &ipAddress
+		if err := tx.Omit(clause.Associations).Create(&correction).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not submit correction request: %v", err))
+		}
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employeeID,
+			UserType:         "employee",
+			Action:           "submit_attendance_correction",
+			TargetResource:   "attendance_correction",
+			TargetResourceID: correction.ID,
+			Changes:          newAttendanceCorrectionListItem(correction),
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      employeeID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while submitting the correction request."})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": newAttendanceCorrectionListItem(correction)})
+}
+
+// ListAttendanceCorrections godoc
+// @Summary List Attendance Corrections
+// @Description Allows an admin to list attendance correction requests, pending ones by default, with optional filters.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected); defaults to pending"
+// @Param employee_id query string false "Employee ID (UUID)" format(uuid)
+// @Param period_id query string false "Attendance Period ID (UUID)" format(uuid)
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]AttendanceCorrectionListItem,pagination=utils.Pagination} "Correction requests"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/attendance-corrections [get]
+func ListAttendanceCorrections(c *fiber.Ctx) error {
+	status := c.Query("status", models.CorrectionPending)
+	switch status {
+	case models.CorrectionPending, models.CorrectionApproved, models.CorrectionRejected:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter."})
+	}
+
+	query := database.DB.Model(&models.AttendanceCorrection{}).Preload("Employee").Where("status = ?", status)
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid employee_id format."})
+		}
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	if periodIDStr := c.Query("period_id"); periodIDStr != "" {
+		periodID, err := uuid.Parse(periodIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+		}
+		query = query.Where("attendance_period_id = ?", periodID)
+	}
+
+	return listAttendanceCorrections(c, query)
+}
+
+// ListMyAttendanceCorrections godoc
+// @Summary List My Attendance Corrections
+// @Description Allows an authenticated employee to list their own attendance correction requests, most recent date first.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]AttendanceCorrectionListItem,pagination=utils.Pagination} "Correction requests"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/attendance-corrections [get]
+func ListMyAttendanceCorrections(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	query := database.DB.Model(&models.AttendanceCorrection{}).Preload("Employee").Where("employee_id = ?", employeeID)
+	return listAttendanceCorrections(c, query)
+}
+
+// listAttendanceCorrections paginates a correction query and writes the list response
+func listAttendanceCorrections(c *fiber.Ctx, query *gorm.DB) error {
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count correction requests: %v", err)})
+	}
+
+	var corrections []models.AttendanceCorrection
+	if err := pageQuery.Order("date DESC, created_at").Find(&corrections).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch correction requests: %v", err)})
+	}
+
+	items := make([]AttendanceCorrectionListItem, 0, len(corrections))
+	for _, ac := range corrections {
+		items = append(items, newAttendanceCorrectionListItem(ac))
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// ReviewAttendanceCorrectionPayload struct for approving or rejecting an attendance correction
+type ReviewAttendanceCorrectionPayload struct {
+	Reason string `json:"reason"` // Required when rejecting
+}
+
+// ApproveAttendanceCorrection godoc
+// @Summary Approve Attendance Correction
+// @Description Allows an admin to approve a pending correction request, which creates the attendance record on the employee's behalf.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance Correction ID (UUID)" format(uuid)
+// @Param review body ReviewAttendanceCorrectionPayload false "Optional approval note"
+// @Success 200 {object} object{status=string,data=AttendanceCorrectionListItem} "Approved correction request"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID, or the date is no longer eligible"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Correction request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending, or attendance already exists for the date"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/attendance-corrections/{id}/approve [post]
+func ApproveAttendanceCorrection(c *fiber.Ctx) error {
+	return reviewAttendanceCorrection(c, models.CorrectionApproved)
+}
+
+// RejectAttendanceCorrection godoc
+// @Summary Reject Attendance Correction
+// @Description Allows an admin to reject a pending correction request with a reason.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance Correction ID (UUID)" format(uuid)
+// @Param review body ReviewAttendanceCorrectionPayload true "Rejection reason"
+// @Success 200 {object} object{status=string,data=AttendanceCorrectionListItem} "Rejected correction request"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID or missing reason"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Correction request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/attendance-corrections/{id}/reject [post]
+func RejectAttendanceCorrection(c *fiber.Ctx) error {
+	return reviewAttendanceCorrection(c, models.CorrectionRejected)
+}
+
+// reviewAttendanceCorrection moves a pending correction to the given status, creating the attendance
+// record on approval, and audits the decision
+func reviewAttendanceCorrection(c *fiber.Ctx, newStatus string) error {
+	correctionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid correction request ID format."})
+	}
+
+	var payload ReviewAttendanceCorrectionPayload
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+	}
+	if newStatus == models.CorrectionRejected && payload.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Reason is required when rejecting a correction request."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var correction models.AttendanceCorrection
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the row so two admins cannot decide the same request at once
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Employee").First(&correction, "id = ?", correctionID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusNotFound, "Correction request not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		if correction.Status != models.CorrectionPending {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Correction request is already %s.", correction.Status))
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{
+			"status":      newStatus,
+			"reviewed_at": now,
+			"updated_by":  adminID,
+			"ip_address":  ipAddress,
+		}
+		if payload.Reason != "" {
+			updates["review_reason"] = payload.Reason
+		}
+
+		if newStatus == models.CorrectionApproved {
+			// The period may have closed or the day been recorded since the request was filed
+			period, fe := checkAttendanceCorrectionDate(tx, correction.Employee, correction.Date)
+			if fe != nil {
+				return fe
+			}
+
+			record := models.AttendanceRecord{
+				EmployeeID:         correction.EmployeeID,
+				AttendancePeriodID: period.ID,
+				Date:               correction.Date,
+				CheckInTime:        correction.CheckInTime,
+				CheckOutStatus:     models.CheckOutOpen,
+			}
+			if correction.CheckOutTime != nil {
+				services.ApplyCheckOut(&record, *correction.CheckOutTime, models.CheckOutRecorded)
+			}
+			record.CreatedBy = &adminID
+			record.UpdatedBy = &adminID
+			record.IPAddress = &ipAddress
+			if err := tx.Omit(clause.Associations).Create(&record).Error; err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not create attendance record: %v", err))
+			}
+			updates["attendance_record_id"] = record.ID
+			correction.AttendanceRecordID = &record.ID
+
+			services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+				UserID:           correction.EmployeeID,
+				UserType:         "employee",
+				Action:           "create_attendance_from_correction",
+				TargetResource:   "attendance_record",
+				TargetResourceID: record.ID,
+				Changes:          map[string]interface{}{"attendance_correction_id": correction.ID, "record": record},
+				IPAddress:        ipAddress,
+				RequestID:        requestID,
+				PerformedBy:      adminID,
+			})
+		}
+
+		if err := tx.Model(&correction).Omit(clause.Associations).Updates(updates).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not update correction request: %v", err))
+		}
+		previousStatus := correction.Status
+		correction.Status = newStatus
+		correction.ReviewedAt = &now
+		correction.UpdatedBy = &adminID
+		correction.IPAddress = &ipAddress
+		if payload.Reason != "" {
+			correction.ReviewReason = &payload.Reason
+		}
+
+		action := "approve_attendance_correction"
+		if newStatus == models.CorrectionRejected {
+			action = "reject_attendance_correction"
+		}
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           adminID,
+			UserType:         "admin",
+			Action:           action,
+			TargetResource:   "attendance_correction",
+			TargetResourceID: correction.ID,
+			Changes: map[string]interface{}{
+				"employee_id":          correction.EmployeeID,
+				"date":                 correction.Date.Format("2006-01-02"),
+				"previous_status":      previousStatus,
+				"new_status":           newStatus,
+				"reason":               payload.Reason,
+				"attendance_record_id": correction.AttendanceRecordID,
+			},
+			IPAddress:   ipAddress,
+			RequestID:   requestID,
+			PerformedBy: adminID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while reviewing the correction request."})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newAttendanceCorrectionListItem(correction)})
+}
+
+// checkAttendanceCorrectionDate returns the open attendance period covering the date, after checking that
+// the date is a working day of the employee's schedule, not a holiday, and has no attendance yet
+func checkAttendanceCorrectionDate(tx *gorm.DB, employee models.Employee, date time.Time) (*models.AttendancePeriod, *fiber.Error) {
+	var period models.AttendancePeriod
+	if err := tx.Where("start_date <= ? AND end_date >= ? AND payroll_run_at IS NULL", date, date).First(&period).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "No open attendance period for this date, or payroll has been run.")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Database error finding attendance period.")
+	}
+	paid, err := services.HasActivePayslip(tx, employee.ID, date, date)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Database error checking payslips.")
+	}
+	if paid {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "The employee has already been paid for this date; void the payroll first.")
+	}
+
+	schedule, err := services.ResolveWorkSchedule(tx, employee)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Database error loading work schedule.")
+	}
+	if !schedule.WorkingDays.Includes(date.Weekday()) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s is not a working day of the work schedule (%s).", date.Weekday(), schedule.Name))
+	}
+	holiday, err := services.NewHolidayService(tx).FindHoliday(date)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Database error checking holidays.")
+	}
+	if holiday != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s is a holiday (%s).", date.Format("2006-01-02"), holiday.Name))
+	}
+
+	var existing int64
+	if err := tx.Model(&models.AttendanceRecord{}).Where("employee_id = ? AND date = ?", employee.ID, date).Count(&existing).Error; err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Database error checking existing attendance.")
+	}
+	if existing > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, "Attendance is already recorded for this date.")
+	}
+	return &period, nil
+}
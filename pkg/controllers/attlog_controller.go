@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxAttlogImportFileSize caps the size of an uploaded attlog export
+const MaxAttlogImportFileSize = 10 * 1024 * 1024
+
+// CreateDevicePINPayload struct for mapping a fingerprint terminal PIN to an employee
+type CreateDevicePINPayload struct {
+	PIN        string    `json:"pin" validate:"required"`
+	EmployeeID uuid.UUID `json:"employee_id" validate:"required"`
+}
+
+// DevicePINListItem is the list view of a device PIN mapping
+type DevicePINListItem struct {
+	ID         uuid.UUID `json:"id"`
+	PIN        string    `json:"pin"`
+	EmployeeID uuid.UUID `json:"employee_id"`
+	Username   string    `json:"username"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newDevicePINListItem(p models.DevicePIN) DevicePINListItem {
+	return DevicePINListItem{ID: p.ID, PIN: p.PIN, EmployeeID: p.EmployeeID, Username: p.Employee.Username, CreatedAt: p.CreatedAt}
+}
+
+// CreateDevicePIN godoc
+// @Summary Create Device PIN
+// @Description Allows an admin to map a user PIN enrolled on the fingerprint terminals to an employee, so attlog imports can record their attendance.
+// @Description An employee may have several PINs; a PIN belongs to one employee.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param mapping body CreateDevicePINPayload true "Device PIN mapping"
+// @Success 201 {object} object{status=string,data=DevicePINListItem} "Created mapping"
+// @Failure 400 {object} object{status=string,message=string} "Validation error or unknown employee"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 409 {object} object{status=string,message=string} "PIN is already mapped"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/device-pins [post]
+func CreateDevicePIN(c *fiber.Ctx) error {
+	var payload CreateDevicePINPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	pin := strings.TrimSpace(payload.PIN)
+	if pin == "" || len(pin) > 32 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "PIN is required and must be at most 32 characters."})
+	}
+	if payload.EmployeeID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "employee_id is required."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var employee models.Employee
+	if err := database.DB.First(&employee, "id = ?", payload.EmployeeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Employee not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	var existing models.DevicePIN
+	err = database.DB.Preload("Employee").Where("pin = ?", pin).First(&existing).Error
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("PIN %s is already mapped to %s.", pin, existing.Employee.Username)})
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	mapping := models.DevicePIN{PIN: pin, EmployeeID: employee.ID}
+	mapping.CreatedBy = &adminID
+	mapping.UpdatedBy = &adminID
+	mapping.IPAddress = &ipAddress
+	if err := database.DB.Create(&mapping).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not create device PIN: %v", err)})
+	}
+	mapping.Employee = employee
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "create_device_pin",
+		TargetResource:   "device_pin",
+		TargetResourceID: mapping.ID,
+		Changes:          map[string]interface{}{"pin": pin, "employee_id": employee.ID},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": newDevicePINListItem(mapping)})
+}
+
+// ListDevicePINs godoc
+// @Summary List Device PINs
+// @Description Allows an admin to list the fingerprint terminal PIN mappings, ordered by PIN.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param employee_id query string false "Employee ID (UUID)" format(uuid)
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]DevicePINListItem,pagination=utils.Pagination} "Device PIN mappings"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/device-pins [get]
+func ListDevicePINs(c *fiber.Ctx) error {
+	query := database.DB.Model(&models.DevicePIN{}).Preload("Employee")
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid employee_id format."})
+		}
+		query = query.Where("employee_id = ?", employeeID)
+	}
+
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count device PINs: %v", err)})
+	}
+	var mappings []models.DevicePIN
+	if err := pageQuery.Order("pin").Find(&mappings).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch device PINs: %v", err)})
+	}
+
+	items := make([]DevicePINListItem, 0, len(mappings))
+	for _, m := range mappings {
+		items = append(items, newDevicePINListItem(m))
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// DeleteDevicePIN godoc
+// @Summary Delete Device PIN
+// @Description Allows an admin to remove a fingerprint terminal PIN mapping. Attendance already imported is kept.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Device PIN ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=DevicePINListItem} "Deleted mapping"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Device PIN not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/device-pins/{id} [delete]
+func DeleteDevicePIN(c *fiber.Ctx) error {
+	mappingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid device PIN ID format."})
+	}
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var mapping models.DevicePIN
+	if err := database.DB.Preload("Employee").First(&mapping, "id = ?", mappingID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Device PIN not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	if err := database.DB.Delete(&mapping).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not delete device PIN: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "delete_device_pin",
+		TargetResource:   "device_pin",
+		TargetResourceID: mapping.ID,
+		Changes:          map[string]interface{}{"pin": mapping.PIN, "employee_id": mapping.EmployeeID},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newDevicePINListItem(mapping)})
+}
+
+// ImportAttlog godoc
+// @Summary Import Attendance from Fingerprint Terminals
+// @Description Allows an admin to import a tab-separated attlog export (user PIN, timestamp, verify mode) from the fingerprint terminals.
+// @Description The first punch of an employee's day is the check-in and the last, if later, the check-out.
+// @Description Days already recorded are reported as duplicates, except that an open record is given the imported check-out,
+// @Description so the same file can be imported again safely. PINs without a mapping are reported and skipped.
+// @Tags Admin
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Attlog file"
+// @Success 200 {object} object{status=string,data=services.AttlogImportReport} "Import report"
+// @Failure 400 {object} object{status=string,message=string} "Missing, oversized or invalid file"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/attendance/import [post]
+func ImportAttlog(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "An attlog file is required in the 'file' field."})
+	}
+	if fileHeader.Size > MaxAttlogImportFileSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("File is too large. The maximum size is %d bytes.", MaxAttlogImportFileSize)})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Could not read the uploaded file."})
+	}
+	defer file.Close()
+
+	punches, err := services.ParseAttlog(file, time.Local)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	report, err := services.NewAttlogImporter(database.DB).Import(punches, services.AttlogImportOptions{
+		PerformedBy: &adminID,
+		IPAddress:   c.IP(),
+		RequestID:   requestID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not import attendance: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": report})
+}
@@ -108,6 +108,10 @@ func EmployeeLogin(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Invalid credentials."})
 	}
 
+	if employee.DeactivatedAt != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "fail", "message": "Account is deactivated."})
+	}
+
 	token, err := utils.GenerateJWT(employee.ID, "employee", config.AppConfig.JWTSecret)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Could not generate token."})
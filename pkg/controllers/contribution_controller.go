@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetContributionReport godoc
+// @Summary Get Social Security Contribution Report
+// @Description Allows an admin to retrieve the BPJS contributions of a period's payslips for filing: each employee's wage, employee and employer
+// @Description share per program, and each program's totals. Payslips of voided payroll runs are left out. Use format=csv to download it as a spreadsheet.
+// @Tags Admin
+// @Accept json
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param period_id query string true "Attendance Period ID (UUID)" format(uuid)
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} object{status=string,data=services.ContributionReport} "Contribution report"
+// @Failure 400 {object} object{status=string,message=string} "Missing or invalid period_id or format, or payroll not run for the period"
+// @Failure 404 {object} object{status=string,message=string} "Attendance period not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/contributions-report [get]
+func GetContributionReport(c *fiber.Ctx) error {
+	periodIDStr := c.Query("period_id")
+	if periodIDStr == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "period_id query parameter is required."})
+	}
+	periodID, err := uuid.Parse(periodIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+	}
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "format must be json or csv."})
+	}
+
+	var period models.AttendancePeriod
+	if err := database.DB.First(&period, "id = ?", periodID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Attendance period not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	if period.PayrollRunAt == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Payroll has not been run for this period."})
+	}
+
+	report, err := services.LoadContributionReport(database.DB, period)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	if format == "json" {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": report})
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	fileName := fmt.Sprintf("bpjs-contributions-%s-%s.csv", report.PeriodStartDate, report.PeriodEndDate)
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// ListSocialSecurityRates godoc
+// @Summary List Social Security Rates
+// @Description Allows an admin to see the BPJS contribution rates and wage caps loaded from SOCIAL_SECURITY_RATES_FILE, oldest first. A payslip's
+// @Description contributions use the latest rates in effect on the last day of its period.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=[]services.SocialSecurityTable} "Social security rates"
+// @Router /admin/social-security-rates [get]
+func ListSocialSecurityRates(c *fiber.Ctx) error {
+	tables := []services.SocialSecurityTable{}
+	if services.SocialSecurityTables != nil {
+		tables = services.SocialSecurityTables.Tables
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": tables})
+}
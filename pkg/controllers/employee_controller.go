@@ -1,18 +1,20 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"payslip-generator/pkg/constants"
 	"payslip-generator/pkg/database"
 	"payslip-generator/pkg/models"
 	"payslip-generator/pkg/services" // Added
+	"payslip-generator/pkg/storage"
 	"payslip-generator/pkg/utils"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // SubmitAttendance godoc
@@ -23,7 +25,7 @@ import (
 // @Produce json
 // @Security BearerAuth
 // @Success 201 {object} map[string]interface{} `json:"{"status":"success", "data": models.AttendanceRecord}"`
-// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"error_message (e.g., weekend, no active period, payroll run)"}"`
+// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"error_message (e.g., non-working day, holiday, no active period, payroll run)"}"`
 // @Failure 401 {object} map[string]string `json:"{"status":"fail", "message":"User not authenticated."}"`
 // @Failure 409 {object} map[string]string `json:"{"status":"fail", "message":"Attendance already submitted for today."}"`
 // @Failure 500 {object} map[string]string `json:"{"status":"error", "message":"Database error / Could not submit attendance"}"`
@@ -39,10 +41,26 @@ func SubmitAttendance(c *fiber.Ctx) error {
 	auditService := services.NewAuditService(database.DB)
 	today := time.Now() // Consider standardizing timezone, e.g., today = time.Now().UTC()
 
-	// Check if today is a weekend
-	weekday := today.Weekday()
-	if weekday == time.Saturday || weekday == time.Sunday {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Attendance submission not allowed on weekends."})
+	// Check if today is a working day of the employee's schedule
+	var employee models.Employee
+	if err := database.DB.First(&employee, "id = ?", employeeID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error loading employee."})
+	}
+	schedule, err := services.ResolveWorkSchedule(database.DB, employee)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error loading work schedule."})
+	}
+	if !schedule.WorkingDays.Includes(today.Weekday()) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Attendance submission not allowed on %s; your work schedule (%s) covers %s.", today.Weekday(), schedule.Name, schedule.WorkingDays)})
+	}
+
+	// Check if today is a company holiday
+	holiday, err := services.NewHolidayService(database.DB).FindHoliday(today)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error checking holidays."})
+	}
+	if holiday != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Attendance submission not allowed on holidays (%s).", holiday.Name)})
 	}
 
 	// Find active attendance period
@@ -76,6 +94,7 @@ func SubmitAttendance(c *fiber.Ctx) error {
 		AttendancePeriodID: activePeriod.ID,
 		Date:               dateOnlyToday,
 		CheckInTime:        today, // Full timestamp for check-in
+		CheckOutStatus:     models.CheckOutOpen,
 	}
 	attendanceRecord.CreatedBy = &employeeID // Pointer
 	attendanceRecord.UpdatedBy = &employeeID // Pointer
@@ -101,23 +120,130 @@ func SubmitAttendance(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": attendanceRecord})
 }
 
+// CheckOut godoc
+// @Summary Record Employee Check-Out
+// @Description Records the check-out time for today's attendance and the hours worked since check-in.
+// @Tags Employee
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} `json:"{"status":"success", "data": models.AttendanceRecord}"`
+// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"No check-in recorded for today."}"`
+// @Failure 401 {object} map[string]string `json:"{"status":"fail", "message":"User not authenticated."}"`
+// @Failure 409 {object} map[string]string `json:"{"status":"fail", "message":"Already checked out today."}"`
+// @Failure 500 {object} map[string]string `json:"{"status":"error", "message":"Database error / Could not record check-out"}"`
+// @Router /employee/attendance/check-out [post]
+func CheckOut(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+	now := time.Now()
+	dateOnlyToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var attendanceRecord models.AttendanceRecord
+	err = database.DB.Where("employee_id = ? AND date = ?", employeeID, dateOnlyToday).First(&attendanceRecord).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "No check-in recorded for today."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error finding today's attendance."})
+	}
+	if attendanceRecord.CheckOutStatus != models.CheckOutOpen {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": "Already checked out today."})
+	}
+
+	services.ApplyCheckOut(&attendanceRecord, now, models.CheckOutRecorded)
+	attendanceRecord.UpdatedBy = &employeeID
+	attendanceRecord.IPAddress = &ipAddress
+
+	if err := database.DB.Save(&attendanceRecord).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not record check-out: %v", err)})
+	}
+
+	services.NewAuditService(database.DB).CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           employeeID,
+		UserType:         "employee",
+		Action:           "check_out",
+		TargetResource:   "attendance_record",
+		TargetResourceID: attendanceRecord.ID,
+		Changes:          map[string]interface{}{"check_out_time": attendanceRecord.CheckOutTime, "worked_minutes": attendanceRecord.WorkedMinutes},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      employeeID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": attendanceRecord})
+}
+
 // SubmitOvertimePayload struct for submitting overtime
+// Give the duration as exactly one of hours, minutes, or start_time and end_time.
 type SubmitOvertimePayload struct {
-	Date  string `json:"date" validate:"required,datetime=2006-01-02"`
-	Hours int    `json:"hours" validate:"required,min=1,max=3"` // Example: Using struct tags for validation info in docs
+	Date      string `json:"date" validate:"required,datetime=2006-01-02"`
+	Hours     int    `json:"hours" validate:"omitempty,min=1"`   // Whole hours
+	Minutes   int    `json:"minutes" validate:"omitempty,min=1"` // Capped per day and week, and rounded, by the overtime policy
+	StartTime string `json:"start_time" example:"17:00"`         // HH:MM on the overtime date
+	EndTime   string `json:"end_time" example:"18:45"`           // HH:MM; at or before start_time means the next day
+}
+
+// overtimeDuration returns the overtime minutes of a payload and, when given, its start and end times
+func overtimeDuration(payload SubmitOvertimePayload, now time.Time) (int, *time.Time, *time.Time, *fiber.Error) {
+	given := 0
+	for _, set := range []bool{payload.Hours != 0, payload.Minutes != 0, payload.StartTime != "" || payload.EndTime != ""} {
+		if set {
+			given++
+		}
+	}
+	if given != 1 {
+		return 0, nil, nil, fiber.NewError(fiber.StatusBadRequest, "Give the overtime as hours, minutes, or start_time and end_time.")
+	}
+	switch {
+	case payload.Hours != 0:
+		if payload.Hours < 0 {
+			return 0, nil, nil, fiber.NewError(fiber.StatusBadRequest, "Overtime hours must be at least 1.")
+		}
+		return payload.Hours * 60, nil, nil, nil
+	case payload.Minutes != 0:
+		if payload.Minutes < 0 {
+			return 0, nil, nil, fiber.NewError(fiber.StatusBadRequest, "Overtime minutes must be at least 1.")
+		}
+		return payload.Minutes, nil, nil, nil
+	}
+	start, err := time.ParseInLocation("2006-01-02 15:04", payload.Date+" "+payload.StartTime, now.Location())
+	if err != nil {
+		return 0, nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid start_time format. Use HH:MM.")
+	}
+	end, err := time.ParseInLocation("2006-01-02 15:04", payload.Date+" "+payload.EndTime, now.Location())
+	if err != nil {
+		return 0, nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid end_time format. Use HH:MM.")
+	}
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1) // Overtime past midnight
+	}
+	if end.After(now) {
+		return 0, nil, nil, fiber.NewError(fiber.StatusBadRequest, "Overtime cannot end in the future.")
+	}
+	return int(end.Sub(start) / time.Minute), &start, &end, nil
 }
 
 // SubmitOvertime godoc
 // @Summary Submit Employee Overtime
-// @Description Allows an authenticated employee to submit an overtime record.
+// @Description Allows an authenticated employee to submit an overtime record, at most one per day. It is priced by the overtime policy
+// @Description in effect on the date: the day type (workday, rest day or holiday) selects the hourly tiers and the daily cap, and a weekly cap applies.
+// @Description The duration is given as whole hours, minutes, or start and end times, and rounded by the policy.
+// @Description Workday overtime must be covered by the recorded hours, and start and end times must be within check-in and check-out.
+// @Description It is paid once an admin approves it.
 // @Tags Employee
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param overtime_details body SubmitOvertimePayload true "Overtime Details"
 // @Success 201 {object} map[string]interface{} `json:"{"status":"success", "data": models.OvertimeRecord}"`
-// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"error_message (e.g., invalid hours, invalid date, submission timing, no attendance, payroll run)"}"`
+// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"error_message (e.g., invalid hours, invalid date, submission timing, no attendance, no check-out, hours not worked, cap exceeded, payroll run)"}"`
 // @Failure 401 {object} map[string]string `json:"{"status":"fail", "message":"User not authenticated."}"`
+// @Failure 409 {object} map[string]string `json:"{"status":"fail", "message":"Overtime already submitted for this date."}"`
 // @Failure 500 {object} map[string]string `json:"{"status":"error", "message":"Database error / Could not submit overtime"}"`
 // @Router /employee/overtime [post]
 func SubmitOvertime(c *fiber.Ctx) error {
@@ -127,14 +253,14 @@ func SubmitOvertime(c *fiber.Ctx) error {
 	}
 	// TODO: Add proper validation using a library like go-playground/validator
 
-	if payload.Hours <= 0 || payload.Hours > 3 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Overtime hours must be between 1 and 3."})
-	}
-
 	overtimeDate, err := time.Parse("2006-01-02", payload.Date)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid date format. Use YYYY-MM-DD."})
 	}
+	minutes, startTime, endTime, fe := overtimeDuration(payload, time.Now())
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
 
 	employeeID, err := utils.GetUserIDFromContext(c)
 	if err != nil {
@@ -143,7 +269,6 @@ func SubmitOvertime(c *fiber.Ctx) error {
 	ipAddress := c.IP()
 	requestIDVal := c.Locals(constants.RequestIDKey.String())
 	requestID, _ := requestIDVal.(string)
-	auditService := services.NewAuditService(database.DB)
 	now := time.Now()
 
 	// Validation: If Date is today, current time must be after 5 PM.
@@ -157,14 +282,42 @@ func SubmitOvertime(c *fiber.Ctx) error {
     }
 
 
-	// Check if an AttendanceRecord exists for this employee on the given Date
-	var attendanceRecord models.AttendanceRecord
-	err = database.DB.Where("employee_id = ? AND date = ?", employeeID, overtimeDate).First(&attendanceRecord).Error
+	var employee models.Employee
+	if err := database.DB.First(&employee, "id = ?", employeeID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error loading employee."})
+	}
+	schedule, err := services.ResolveWorkSchedule(database.DB, employee)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Cannot submit overtime for a day you did not attend."})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error loading work schedule."})
+	}
+	policyService := services.NewOvertimePolicyService(database.DB)
+	dayType, err := policyService.DayType(schedule, overtimeDate)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error checking the overtime date."})
+	}
+
+	// Workday overtime must be covered by the hours recorded between check-in and check-out.
+	// Attendance is not recorded on rest days and holidays, so that overtime relies on approval.
+	if dayType == models.OvertimeDayWorkday {
+		var attendanceRecord models.AttendanceRecord
+		err = database.DB.Where("employee_id = ? AND date = ?", employeeID, overtimeDate).First(&attendanceRecord).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Cannot submit overtime for a day you did not attend."})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error checking attendance for overtime."})
+		}
+		if err := services.ValidateOvertimeAgainstAttendance(attendanceRecord, schedule, minutes); err != nil {
+			if errors.Is(err, services.ErrCheckOutMissing) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Cannot submit overtime for a day without a recorded check-out."})
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Overtime exceeds recorded hours: %v", err)})
+		}
+		if startTime != nil {
+			if err := services.ValidateOvertimeWindow(attendanceRecord, *startTime, *endTime); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Overtime must be within the recorded attendance: %v", err)})
+			}
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error checking attendance for overtime."})
 	}
 
 	// Find the AttendancePeriod for the overtime Date. If payroll for that period is already run, disallow submission.
@@ -177,34 +330,64 @@ func SubmitOvertime(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error finding attendance period for overtime."})
 	}
 
-
 	overtimeRecord := models.OvertimeRecord{
-		EmployeeID:     employeeID,
-		Date:           overtimeDate,
-		Hours:          payload.Hours,
-		SubmittedAt:    now,
-		RateMultiplier: 2.0, // Default, can be made configurable if needed
+		EmployeeID:  employeeID,
+		Date:        overtimeDate,
+		Minutes:     minutes,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		SubmittedAt: now,
+		Status:      models.OvertimePending,
 	}
 	overtimeRecord.CreatedBy = &employeeID // Pointer
 	overtimeRecord.UpdatedBy = &employeeID // Pointer
 	overtimeRecord.IPAddress = &ipAddress  // Pointer
 
-	if err := database.DB.Create(&overtimeRecord).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not submit overtime: %v", err)})
-	}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the employee so concurrent submissions cannot both fit under the weekly cap
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Employee{}, "id = ?", employeeID).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Database error loading employee.")
+		}
+		// Tiers start from the first overtime hour of the day, so a day has a single overtime record
+		var existing int64
+		if err := tx.Model(&models.OvertimeRecord{}).
+			Where("employee_id = ? AND date = ? AND status <> ?", employeeID, overtimeDate, models.OvertimeRejected).
+			Count(&existing).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Database error checking existing overtime.")
+		}
+		if existing > 0 {
+			return fiber.NewError(fiber.StatusConflict, "Overtime already submitted for this date.")
+		}
 
-	// Audit Log
-	auditService.CreateAuditLog(services.AuditLogEntryParams{
-		UserID:           employeeID,
-		UserType:         "employee",
-		Action:           "submit_overtime",
-		TargetResource:   "overtime_record",
-		TargetResourceID: overtimeRecord.ID,
-		Changes:          overtimeRecord,
-		IPAddress:        ipAddress,
-		RequestID:        requestID,
-		PerformedBy:      employeeID,
+		if err := services.NewOvertimePolicyService(tx).PriceOvertime(&overtimeRecord, schedule); err != nil {
+			if errors.Is(err, services.ErrOvertimeCapExceeded) || errors.Is(err, services.ErrOvertimeTooShort) {
+				return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Overtime exceeds the overtime policy: %v", err))
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not price overtime: %v", err))
+		}
+		if err := tx.Omit(clause.Associations).Create(&overtimeRecord).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not submit overtime: %v", err))
+		}
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employeeID,
+			UserType:         "employee",
+			Action:           "submit_overtime",
+			TargetResource:   "overtime_record",
+			TargetResourceID: overtimeRecord.ID,
+			Changes:          overtimeRecord,
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      employeeID,
+		})
+		return nil
 	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while submitting overtime."})
+	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": overtimeRecord})
 }
@@ -212,21 +395,27 @@ func SubmitOvertime(c *fiber.Ctx) error {
 
 // SubmitReimbursementPayload for reimbursement requests
 type SubmitReimbursementPayload struct {
-	Amount      float64 `json:"amount" validate:"required,gt=0"`
-	Description string  `json:"description" validate:"required"`
+	CategoryID  string       `json:"category_id" form:"category_id" validate:"required" format:"uuid"`
+	Amount      models.Money `json:"amount" form:"amount" validate:"required,gt=0"`
+	Description string       `json:"description" form:"description" validate:"required"`
 }
 
 // SubmitReimbursement godoc
 // @Summary Submit Employee Reimbursement Request
-// @Description Allows an authenticated employee to submit a reimbursement request.
+// @Description Allows an authenticated employee to submit a reimbursement request in a category. Sent as multipart/form-data,
+// @Description the request can carry receipt files in the 'receipts' field, which are attached as by the receipt upload endpoint.
+// @Description The claim must keep to the category's per-claim maximum and its cap for the period or year, counting the employee's
+// @Description pending, approved and paid claims, and must include a receipt if the category requires one. Otherwise it is refused
+// @Description with a 422 listing every violation.
 // @Tags Employee
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security BearerAuth
 // @Param reimbursement_details body SubmitReimbursementPayload true "Reimbursement Details"
 // @Success 201 {object} map[string]interface{} `json:"{"status":"success", "data": models.ReimbursementRequest}"`
-// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"error_message (e.g., invalid amount, missing description)"}"`
+// @Failure 400 {object} map[string]string `json:"{"status":"fail", "message":"error_message (e.g., invalid amount, missing description, invalid receipt)"}"`
 // @Failure 401 {object} map[string]string `json:"{"status":"fail", "message":"User not authenticated."}"`
+// @Failure 422 {object} object{status=string,message=string,data=[]services.ReimbursementViolation} "Category limits broken"
 // @Failure 500 {object} map[string]string `json:"{"status":"error", "message":"Could not submit reimbursement request"}"`
 // @Router /employee/reimbursements [post]
 func SubmitReimbursement(c *fiber.Ctx) error {
@@ -236,12 +425,36 @@ func SubmitReimbursement(c *fiber.Ctx) error {
 	}
 	// TODO: Add proper validation using a library
 
-	if payload.Amount <= 0 {
+	if !payload.Amount.IsPositive() {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Amount must be greater than zero."})
 	}
+	if payload.Amount.GreaterThan(services.MaxReimbursementAmount.Decimal) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Amount must be at most %s.", services.MaxReimbursementAmount)})
+	}
+	if !payload.Amount.HasWholeCents() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Amount must have at most 2 decimal places."})
+	}
 	if payload.Description == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Description is required."})
 	}
+	if payload.CategoryID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "category_id is required."})
+	}
+	categoryID, err := uuid.Parse(payload.CategoryID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid category_id format."})
+	}
+	var category models.ReimbursementCategory
+	if err := database.DB.First(&category, "id = ?", categoryID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Reimbursement category not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	receiptFiles, err := receiptFilesFromForm(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
 
 	employeeID, err := utils.GetUserIDFromContext(c)
 	if err != nil {
@@ -250,13 +463,13 @@ func SubmitReimbursement(c *fiber.Ctx) error {
 	ipAddress := c.IP()
 	requestIDVal := c.Locals(constants.RequestIDKey.String())
 	requestID, _ := requestIDVal.(string)
-	auditService := services.NewAuditService(database.DB)
 
 	reimbursementRequest := models.ReimbursementRequest{
 		EmployeeID:  employeeID, // This is the FK, not part of BaseModel's CreatedBy
+		CategoryID:  &category.ID,
 		Amount:      payload.Amount,
 		Description: payload.Description,
-		Status:      "pending", // Default status
+		Status:      models.ReimbursementPending, // Default status
 	}
 	reimbursementRequest.CreatedBy = &employeeID // Pointer for BaseModel field
 	reimbursementRequest.UpdatedBy = &employeeID // Pointer for BaseModel field
@@ -273,23 +486,65 @@ func SubmitReimbursement(c *fiber.Ctx) error {
 		reimbursementRequest.AttendancePeriodID = activePeriod.ID
 	}
 
+	// The request and its receipts are created together, so a bad receipt leaves no request behind
+	var violations []services.ReimbursementViolation
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the employee so concurrent claims are checked against each other's amounts
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Employee{}, "id = ?", employeeID).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		violations, err = services.NewReimbursementPolicyService(tx).Check(category, services.ReimbursementClaim{
+			EmployeeID:         employeeID,
+			AttendancePeriodID: reimbursementRequest.AttendancePeriodID,
+			Amount:             payload.Amount,
+			Receipts:           len(receiptFiles),
+			SubmittedAt:        time.Now(),
+		})
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if len(violations) > 0 {
+			return fiber.NewError(fiber.StatusUnprocessableEntity, fmt.Sprintf("The claim breaks the limits of the %s category.", category.Name))
+		}
 
-	if err := database.DB.Create(&reimbursementRequest).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not submit reimbursement request: %v", err)})
-	}
+		if err := tx.Omit(clause.Associations).Create(&reimbursementRequest).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not submit reimbursement request: %v", err))
+		}
+		if len(receiptFiles) > 0 {
+			receipts, err := services.NewReceiptService(tx, storage.Receipts).Attach(c.Context(), reimbursementRequest, receiptFiles, employeeID, ipAddress)
+			if err != nil {
+				if errors.Is(err, services.ErrInvalidReceipt) {
+					return fiber.NewError(fiber.StatusBadRequest, err.Error())
+				}
+				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+			reimbursementRequest.Receipts = receipts
+		}
 
-	// Audit Log
-	auditService.CreateAuditLog(services.AuditLogEntryParams{
-		UserID:           employeeID,
-		UserType:         "employee",
-		Action:           "submit_reimbursement",
-		TargetResource:   "reimbursement_request",
-		TargetResourceID: reimbursementRequest.ID,
-		Changes:          reimbursementRequest,
-		IPAddress:        ipAddress,
-		RequestID:        requestID,
-		PerformedBy:      employeeID,
+		// Audit Log
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employeeID,
+			UserType:         "employee",
+			Action:           "submit_reimbursement",
+			TargetResource:   "reimbursement_request",
+			TargetResourceID: reimbursementRequest.ID,
+			Changes:          reimbursementRequest,
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      employeeID,
+		})
+		return nil
 	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			if len(violations) > 0 {
+				return c.Status(fe.Code).JSON(fiber.Map{"status": "fail", "message": fe.Message, "data": violations})
+			}
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Could not submit reimbursement request."})
+	}
+	reimbursementRequest.Category = &category
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": reimbursementRequest})
 }
@@ -299,15 +554,26 @@ type PayslipDetailResponse struct {
 	PayslipID                    uuid.UUID `json:"payslip_id"`
 	PeriodStartDate              string    `json:"period_start_date"`
 	PeriodEndDate                string    `json:"period_end_date"`
-	BaseSalary                   float64   `json:"base_salary"`
-	ProratedSalary               float64   `json:"prorated_salary"`
+	BaseSalary                   models.Money `json:"base_salary"`
+	ProratedSalary               models.Money `json:"prorated_salary"`
 	AttendanceCount              int       `json:"attendance_count"`
 	TotalWorkingDaysInPeriod     int       `json:"total_working_days_in_period"`
+	PaidLeaveDays                int       `json:"paid_leave_days"` // Included in AttendanceCount
+	UnpaidLeaveDays              int       `json:"unpaid_leave_days"`
+	UnpaidLeaveDeduction         models.Money `json:"unpaid_leave_deduction"`
 	OvertimeHours                float64   `json:"overtime_hours"`
-	OvertimePay                  float64   `json:"overtime_pay"`
+	OvertimePay                  models.Money `json:"overtime_pay"`
 	Reimbursements               []models.ReimbursementRequest `json:"reimbursements"` // List of actual RRs
-	TotalReimbursements          float64   `json:"total_reimbursements"` // This should be sum of Reimbursements array amounts
-	TakeHomePay                  float64   `json:"take_home_pay"`
+	TotalReimbursements          models.Money `json:"total_reimbursements"` // This should be sum of Reimbursements array amounts
+	SocialSecurityDeduction      models.Money `json:"social_security_deduction"` // Employee's BPJS contributions
+	Contributions                []models.PayslipContribution `json:"contributions"` // Employee and employer share per BPJS program
+	TaxableIncome                models.Money `json:"taxable_income"` // Prorated salary, overtime and taxable employer contributions
+	IncomeTax                    models.Money `json:"income_tax"`     // PPh 21 withheld; negative when the year-end true-up refunds
+	TaxCalculation               *models.TaxCalculation `json:"tax_calculation,omitempty"`
+	TakeHomePay                  models.Money `json:"take_home_pay"`
+	PayrollPolicyVersion         int          `json:"payroll_policy_version"` // 0 is the built-in default policy
+	PayrollPolicy                *models.PayrollPolicyRules `json:"payroll_policy,omitempty"` // Proration and rounding applied; absent on payslips from before payroll policies
+	SalarySegments               []models.SalarySegment     `json:"salary_segments,omitempty"` // Parts of the period paid at each salary in force
 }
 
 // GetMyPayslip godoc
@@ -341,7 +607,7 @@ func GetMyPayslip(c *fiber.Ctx) error {
 
 	var payslip models.Payslip
 	err = database.DB.Preload("AttendancePeriod").
-		Where("employee_id = ? AND attendance_period_id = ?", employeeID, periodID).
+		Where("employee_id = ? AND attendance_period_id = ? AND voided_at IS NULL", employeeID, periodID).
 		First(&payslip).Error
 
 	if err != nil {
@@ -352,18 +618,23 @@ func GetMyPayslip(c *fiber.Ctx) error {
 	}
 
 	var paidReimbursements []models.ReimbursementRequest
-	err = database.DB.Where("employee_id = ? AND attendance_period_id = ? AND status = ?", employeeID, periodID, "paid").Find(&paidReimbursements).Error
+	err = database.DB.Where("employee_id = ? AND attendance_period_id = ? AND status = ?", employeeID, periodID, models.ReimbursementPaid).Find(&paidReimbursements).Error
 	if err != nil {
 		// Log this error but don't fail the request, as payslip itself was found
 		fmt.Printf("Error fetching paid reimbursements for payslip %s: %v\n", payslip.ID, err)
 	}
 
     // Recalculate total reimbursements from the fetched list for accuracy, though payslip.ReimbursementsTotal should be correct.
-    actualReimbursementsTotal := decimal.NewFromFloat(0)
+    var actualReimbursementsTotal models.Money
     for _, rr := range paidReimbursements {
-        actualReimbursementsTotal = actualReimbursementsTotal.Add(decimal.NewFromFloat(rr.Amount))
+        actualReimbursementsTotal = actualReimbursementsTotal.Add(rr.Amount)
     }
 
+	contributions := []models.PayslipContribution{}
+	if err := database.DB.Where("payslip_id = ?", payslip.ID).Order("position").Find(&contributions).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch contributions: %v", err)})
+	}
+
 
 	response := PayslipDetailResponse{
 		PayslipID:                    payslip.ID,
@@ -373,11 +644,22 @@ func GetMyPayslip(c *fiber.Ctx) error {
 		ProratedSalary:               payslip.ProratedSalary,
 		AttendanceCount:              payslip.AttendanceCount,
 		TotalWorkingDaysInPeriod:     payslip.TotalWorkingDays,
+		PaidLeaveDays:                payslip.PaidLeaveDays,
+		UnpaidLeaveDays:              payslip.UnpaidLeaveDays,
+		UnpaidLeaveDeduction:         payslip.UnpaidLeaveDeduction,
 		OvertimeHours:                payslip.OvertimeHours,
 		OvertimePay:                  payslip.OvertimePay,
 		Reimbursements:               paidReimbursements,
-		TotalReimbursements:          actualReimbursementsTotal.InexactFloat64(), // Use sum from actual RRs
+		TotalReimbursements:          actualReimbursementsTotal, // Use sum from actual RRs
+		SocialSecurityDeduction:      payslip.SocialSecurityDeduction,
+		Contributions:                contributions,
+		TaxableIncome:                payslip.TaxableIncome,
+		IncomeTax:                    payslip.IncomeTax,
+		TaxCalculation:               payslip.TaxCalculation,
 		TakeHomePay:                  payslip.TakeHomePay,
+		PayrollPolicyVersion:         payslip.PayrollPolicyVersion,
+		PayrollPolicy:                payslip.PayrollPolicy,
+		SalarySegments:               payslip.SalarySegments,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": response})
@@ -0,0 +1,431 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PayslipListItem is the employee's summary of one payslip in their payslip history
+type PayslipListItem struct {
+	PayslipID            uuid.UUID    `json:"payslip_id"`
+	PeriodID             uuid.UUID    `json:"period_id"`
+	PeriodStartDate      string       `json:"period_start_date"`
+	PeriodEndDate        string       `json:"period_end_date"`
+	BaseSalary           models.Money `json:"base_salary"`
+	ProratedSalary       models.Money `json:"prorated_salary"`
+	UnpaidLeaveDeduction models.Money `json:"unpaid_leave_deduction"`
+	OvertimePay          models.Money `json:"overtime_pay"`
+	TotalReimbursements  models.Money `json:"total_reimbursements"`
+	SocialSecurity       models.Money `json:"social_security_deduction"`
+	IncomeTax            models.Money `json:"income_tax"`
+	TakeHomePay          models.Money `json:"take_home_pay"`
+	GeneratedAt          time.Time    `json:"generated_at"`
+	VoidedAt             *time.Time   `json:"voided_at,omitempty"` // Set on payslips of a voided payroll run
+}
+
+// dateRangeQuery parses the optional from and to query parameters as YYYY-MM-DD dates
+func dateRangeQuery(c *fiber.Ctx) (from, to *time.Time, fe *fiber.Error) {
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid from date format. Use YYYY-MM-DD.")
+		}
+		from = &parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid to date format. Use YYYY-MM-DD.")
+		}
+		to = &parsed
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "to must not be before from.")
+	}
+	return from, to, nil
+}
+
+// yearQuery parses the optional year query parameter; zero means no year filter
+func yearQuery(c *fiber.Ctx) (int, *fiber.Error) {
+	yearStr := c.Query("year")
+	if yearStr == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || year < 1 || year > 9999 {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "Invalid year.")
+	}
+	return year, nil
+}
+
+// filterPeriods limits an attendance period query to the periods that overlap the year and from/to range of the request
+func filterPeriods(c *fiber.Ctx, query *gorm.DB, column func(string) string) (*gorm.DB, *fiber.Error) {
+	year, fe := yearQuery(c)
+	if fe != nil {
+		return nil, fe
+	}
+	from, to, fe := dateRangeQuery(c)
+	if fe != nil {
+		return nil, fe
+	}
+	if year != 0 {
+		query = query.Where(column("start_date")+" <= ? AND "+column("end_date")+" >= ?",
+			time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC), time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))
+	}
+	if from != nil {
+		query = query.Where(column("end_date")+" >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where(column("start_date")+" <= ?", *to)
+	}
+	return query, nil
+}
+
+// ListMyPayslips godoc
+// @Summary List My Payslips
+// @Description Allows an authenticated employee to list their payslips across attendance periods, most recent period first.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Only periods overlapping this year"
+// @Param from query string false "Only periods ending on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Only periods starting on or before this date (YYYY-MM-DD)"
+// @Param include_voided query bool false "Include payslips of voided payroll runs (default false)"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]PayslipListItem,pagination=utils.Pagination} "Payslips"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/payslips [get]
+func ListMyPayslips(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+
+	query := database.DB.Model(&models.Payslip{}).
+		Joins("JOIN attendance_periods ON attendance_periods.id = payslips.attendance_period_id").
+		Where("payslips.employee_id = ?", employeeID)
+	query, fe := filterPeriods(c, query, func(column string) string { return "attendance_periods." + column })
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	if !c.QueryBool("include_voided", false) {
+		query = query.Where("payslips.voided_at IS NULL")
+	}
+
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count payslips: %v", err)})
+	}
+
+	var payslips []models.Payslip
+	if err := pageQuery.Preload("AttendancePeriod").Order("attendance_periods.start_date DESC, payslips.created_at DESC").Find(&payslips).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch payslips: %v", err)})
+	}
+
+	items := make([]PayslipListItem, 0, len(payslips))
+	for _, p := range payslips {
+		items = append(items, PayslipListItem{
+			PayslipID:            p.ID,
+			PeriodID:             p.AttendancePeriodID,
+			PeriodStartDate:      p.AttendancePeriod.StartDate.Format("2006-01-02"),
+			PeriodEndDate:        p.AttendancePeriod.EndDate.Format("2006-01-02"),
+			BaseSalary:           p.BaseSalary,
+			ProratedSalary:       p.ProratedSalary,
+			UnpaidLeaveDeduction: p.UnpaidLeaveDeduction,
+			OvertimePay:          p.OvertimePay,
+			TotalReimbursements:  p.ReimbursementsTotal,
+			SocialSecurity:       p.SocialSecurityDeduction,
+			IncomeTax:            p.IncomeTax,
+			TakeHomePay:          p.TakeHomePay,
+			GeneratedAt:          p.CreatedAt,
+			VoidedAt:             p.VoidedAt,
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// GetMyAttendanceCalendar godoc
+// @Summary Get My Attendance Calendar
+// @Description Allows an authenticated employee to view their attendance as a calendar, one page entry per attendance period, most recent first. Each day is present, leave, holiday, rest_day, absent or upcoming.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period_id query string false "Attendance Period ID (UUID)" format(uuid)
+// @Param year query int false "Only periods overlapping this year"
+// @Param from query string false "Only periods ending on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Only periods starting on or before this date (YYYY-MM-DD)"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]services.AttendanceCalendar,pagination=utils.Pagination} "Attendance calendars"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/attendance [get]
+func GetMyAttendanceCalendar(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	var employee models.Employee
+	if err := database.DB.First(&employee, "id = ?", employeeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	query := database.DB.Model(&models.AttendancePeriod{})
+	if periodIDStr := c.Query("period_id"); periodIDStr != "" {
+		periodID, err := uuid.Parse(periodIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+		}
+		query = query.Where("id = ?", periodID)
+	}
+	query, fe := filterPeriods(c, query, func(column string) string { return column })
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count attendance periods: %v", err)})
+	}
+
+	var periods []models.AttendancePeriod
+	if err := pageQuery.Order("start_date DESC").Find(&periods).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch attendance periods: %v", err)})
+	}
+
+	today := time.Now()
+	calendars := make([]services.AttendanceCalendar, 0, len(periods))
+	for _, period := range periods {
+		calendar, err := services.LoadAttendanceCalendar(database.DB, employee, period, today)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to build attendance calendar: %v", err)})
+		}
+		calendars = append(calendars, calendar)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": calendars, "pagination": pagination})
+}
+
+// ListMyOvertime godoc
+// @Summary List My Overtime
+// @Description Allows an authenticated employee to list their own overtime submissions, most recent first.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected)"
+// @Param period_id query string false "Attendance Period ID (UUID); overtime dated within the period" format(uuid)
+// @Param from query string false "Overtime dated on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Overtime dated on or before this date (YYYY-MM-DD)"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]OvertimeListItem,pagination=utils.Pagination} "Overtime records"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 404 {object} object{status=string,message=string} "Attendance period not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/overtime [get]
+func ListMyOvertime(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+
+	query := database.DB.Model(&models.OvertimeRecord{}).Preload("Employee").Where("employee_id = ?", employeeID)
+	if status := c.Query("status"); status != "" {
+		switch status {
+		case models.OvertimePending, models.OvertimeApproved, models.OvertimeRejected:
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter."})
+		}
+		query = query.Where("status = ?", status)
+	}
+	if periodIDStr := c.Query("period_id"); periodIDStr != "" {
+		periodID, err := uuid.Parse(periodIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+		}
+		var period models.AttendancePeriod
+		if err := database.DB.First(&period, "id = ?", periodID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Attendance period not found."})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+		}
+		query = query.Where("date BETWEEN ? AND ?", period.StartDate, period.EndDate)
+	}
+	from, to, fe := dateRangeQuery(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("date <= ?", *to)
+	}
+
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count overtime records: %v", err)})
+	}
+
+	var records []models.OvertimeRecord
+	if err := pageQuery.Order("date DESC, submitted_at DESC").Find(&records).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch overtime records: %v", err)})
+	}
+
+	items := make([]OvertimeListItem, 0, len(records))
+	for _, ot := range records {
+		items = append(items, newOvertimeListItem(ot))
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// ListMyReimbursements godoc
+// @Summary List My Reimbursement Requests
+// @Description Allows an authenticated employee to list their own reimbursement requests with their status, most recent first.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected, paid, cancelled)"
+// @Param period_id query string false "Attendance Period ID (UUID)" format(uuid)
+// @Param category_id query string false "Reimbursement Category ID (UUID)" format(uuid)
+// @Param from query string false "Submitted on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Submitted on or before this date (YYYY-MM-DD)"
+// @Param min_amount query number false "Minimum amount"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]ReimbursementListItem,pagination=utils.Pagination} "Reimbursement requests"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/reimbursements [get]
+func ListMyReimbursements(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+
+	query := database.DB.Model(&models.ReimbursementRequest{}).Preload("Employee").Preload("Category").Preload("Receipts").Where("employee_id = ?", employeeID)
+	if status := c.Query("status"); status != "" {
+		switch status {
+		case models.ReimbursementPending, models.ReimbursementApproved, models.ReimbursementRejected, models.ReimbursementPaid, models.ReimbursementCancelled:
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter."})
+		}
+		query = query.Where("status = ?", status)
+	}
+	query, fe := filterReimbursements(c, query)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	return listReimbursements(c, query, "created_at DESC")
+}
+
+// CancelMyReimbursement godoc
+// @Summary Cancel My Reimbursement Request
+// @Description Allows an authenticated employee to cancel one of their own reimbursement requests while it is still pending. Cancelled requests no longer count towards category caps.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=models.ReimbursementRequest} "Cancelled reimbursement request"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 404 {object} object{status=string,message=string} "Reimbursement request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/reimbursements/{id}/cancel [post]
+func CancelMyReimbursement(c *fiber.Ctx) error {
+	reimbursementID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid reimbursement request ID format."})
+	}
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var reimbursementRequest models.ReimbursementRequest
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the row so an admin cannot approve the request while it is being cancelled
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&reimbursementRequest, "id = ? AND employee_id = ?", reimbursementID, employeeID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusNotFound, "Reimbursement request not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+
+		if reimbursementRequest.Status != models.ReimbursementPending {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Reimbursement request is already %s.", reimbursementRequest.Status))
+		}
+
+		updates := map[string]interface{}{
+			"status":     models.ReimbursementCancelled,
+			"updated_by": employeeID,
+			"ip_address": ipAddress,
+		}
+		if err := tx.Model(&reimbursementRequest).Updates(updates).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not cancel reimbursement request: %v", err))
+		}
+		reimbursementRequest.Status = models.ReimbursementCancelled
+		reimbursementRequest.UpdatedBy = &employeeID
+		reimbursementRequest.IPAddress = &ipAddress
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employeeID,
+			UserType:         "employee",
+			Action:           "cancel_reimbursement",
+			TargetResource:   "reimbursement_request",
+			TargetResourceID: reimbursementRequest.ID,
+			Changes: map[string]interface{}{
+				"previous_status": models.ReimbursementPending,
+				"new_status":      models.ReimbursementCancelled,
+				"amount":          reimbursementRequest.Amount,
+			},
+			IPAddress:   ipAddress,
+			RequestID:   requestID,
+			PerformedBy: employeeID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while cancelling the reimbursement request."})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": reimbursementRequest})
+}
@@ -0,0 +1,551 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmployeeResponse is the admin view of an employee, without the password hash
+type EmployeeResponse struct {
+	ID             uuid.UUID    `json:"id"`
+	Username       string       `json:"username"`
+	Salary         models.Money `json:"salary"`
+	IsActive       bool         `json:"is_active"`
+	DeactivatedAt  *time.Time   `json:"deactivated_at,omitempty"`
+	WorkScheduleID *uuid.UUID   `json:"work_schedule_id"` // null means the standard Monday to Friday, 8 hours schedule
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+func newEmployeeResponse(e models.Employee) EmployeeResponse {
+	return EmployeeResponse{
+		ID:             e.ID,
+		Username:       e.Username,
+		Salary:         e.Salary,
+		IsActive:       e.DeactivatedAt == nil,
+		DeactivatedAt:  e.DeactivatedAt,
+		WorkScheduleID: e.WorkScheduleID,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+// CreateEmployeePayload struct for creating an employee
+type CreateEmployeePayload struct {
+	Username string       `json:"username" validate:"required"`
+	Password string       `json:"password" validate:"required,min=8"`
+	Salary   models.Money `json:"salary" validate:"required,gt=0"`
+	// Optional; omit for the standard Monday to Friday, 8 hours schedule
+	WorkScheduleID string `json:"work_schedule_id" format:"uuid"`
+}
+
+// CreateEmployee godoc
+// @Summary Create Employee
+// @Description Allows an admin to onboard a new employee. The password is stored as a bcrypt hash.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param employee body CreateEmployeePayload true "Employee Details"
+// @Success 201 {object} object{status=string,data=EmployeeResponse} "Created employee"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 409 {object} object{status=string,message=string} "Username already exists"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees [post]
+func CreateEmployee(c *fiber.Ctx) error {
+	var payload CreateEmployeePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	username := services.NormalizeUsername(payload.Username)
+	if err := services.ValidateUsername(username); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	if err := services.ValidatePassword(payload.Password); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	if err := services.ValidateSalary(payload.Salary); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var existingCount int64
+	if err := database.DB.Model(&models.Employee{}).Where("username = ?", username).Count(&existingCount).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error checking username."})
+	}
+	if existingCount > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": "Username already exists."})
+	}
+
+	workScheduleID, fe := parseWorkScheduleID(payload.WorkScheduleID)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	hashedPassword, err := utils.HashPassword(payload.Password)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Could not hash password."})
+	}
+
+	employee := models.Employee{
+		Username:       username,
+		Password:       hashedPassword,
+		Salary:         payload.Salary,
+		WorkScheduleID: workScheduleID,
+	}
+	employee.CreatedBy = &adminID
+	employee.UpdatedBy = &adminID
+	employee.IPAddress = &ipAddress
+
+	if err := database.DB.Create(&employee).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not create employee: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           employee.ID,
+		UserType:         "employee",
+		Action:           "create_employee",
+		TargetResource:   "employee",
+		TargetResourceID: employee.ID,
+		Changes:          newEmployeeResponse(employee),
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": newEmployeeResponse(employee)})
+}
+
+// ListEmployees godoc
+// @Summary List Employees
+// @Description Allows an admin to list employees with optional username search and status filter.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Case-insensitive username search"
+// @Param status query string false "active (default), inactive, or all"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]EmployeeResponse,pagination=utils.Pagination} "Employees"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees [get]
+func ListEmployees(c *fiber.Ctx) error {
+	query := database.DB.Model(&models.Employee{})
+
+	switch c.Query("status", "active") {
+	case "active":
+		query = query.Where("deactivated_at IS NULL")
+	case "inactive":
+		query = query.Where("deactivated_at IS NOT NULL")
+	case "all":
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter. Use active, inactive or all."})
+	}
+	if search := c.Query("search"); search != "" {
+		query = query.Where("username ILIKE ?", "%"+search+"%")
+	}
+
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count employees: %v", err)})
+	}
+
+	var employees []models.Employee
+	if err := pageQuery.Order("username").Find(&employees).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch employees: %v", err)})
+	}
+
+	items := make([]EmployeeResponse, 0, len(employees))
+	for _, e := range employees {
+		items = append(items, newEmployeeResponse(e))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// GetEmployee godoc
+// @Summary Get Employee
+// @Description Allows an admin to retrieve a single employee.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=EmployeeResponse} "Employee"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id} [get]
+func GetEmployee(c *fiber.Ctx) error {
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newEmployeeResponse(*employee)})
+}
+
+// UpdateEmployeePayload struct for updating an employee. Omitted fields are left unchanged.
+type UpdateEmployeePayload struct {
+	Username *string       `json:"username"`
+	Password *string       `json:"password"`
+	Salary   *models.Money `json:"salary"`
+	// When the salary takes effect (YYYY-MM-DD); defaults to today. Earlier parts of a period are paid at the previous salary.
+	SalaryEffectiveFrom *string `json:"salary_effective_from" example:"2025-01-15"`
+	SalaryChangeReason  *string `json:"salary_change_reason"`
+	// Empty string assigns the standard Monday to Friday, 8 hours schedule
+	WorkScheduleID *string `json:"work_schedule_id" format:"uuid"`
+}
+
+// UpdateEmployee godoc
+// @Summary Update Employee
+// @Description Allows an admin to change an employee's username, password, salary or work schedule. A salary change is added to the employee's
+// @Description salary history, effective today or from salary_effective_from, and audited with the old and new values. It cannot take effect in
+// @Description a period the employee already has a payslip for.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Param employee body UpdateEmployeePayload true "Fields to update"
+// @Success 200 {object} object{status=string,data=EmployeeResponse} "Updated employee"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 409 {object} object{status=string,message=string} "Username already exists, or the salary change falls in a paid period"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id} [put]
+func UpdateEmployee(c *fiber.Ctx) error {
+	var payload UpdateEmployeePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	updates := map[string]interface{}{}
+	changes := map[string]interface{}{}
+
+	if payload.Username != nil {
+		username := services.NormalizeUsername(*payload.Username)
+		if err := services.ValidateUsername(username); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+		if username != employee.Username {
+			var existingCount int64
+			if err := database.DB.Model(&models.Employee{}).Where("username = ? AND id <> ?", username, employee.ID).Count(&existingCount).Error; err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error checking username."})
+			}
+			if existingCount > 0 {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": "Username already exists."})
+			}
+			updates["username"] = username
+			changes["username"] = fiber.Map{"old": employee.Username, "new": username}
+		}
+	}
+	if payload.Password != nil {
+		if err := services.ValidatePassword(*payload.Password); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+		hashedPassword, err := utils.HashPassword(*payload.Password)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Could not hash password."})
+		}
+		updates["password"] = hashedPassword
+		changes["password"] = "changed" // Never log the hash
+	}
+	var salaryChange *services.SalaryChange
+	if payload.Salary != nil {
+		if err := services.ValidateSalary(*payload.Salary); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+		effectiveFrom := time.Now()
+		if payload.SalaryEffectiveFrom != nil {
+			parsed, err := time.Parse("2006-01-02", *payload.SalaryEffectiveFrom)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid salary_effective_from format. Use YYYY-MM-DD."})
+			}
+			effectiveFrom = parsed
+		}
+		if !payload.Salary.Equal(employee.Salary.Decimal) || payload.SalaryEffectiveFrom != nil {
+			salaryChange = &services.SalaryChange{Salary: *payload.Salary, EffectiveFrom: effectiveFrom, PerformedBy: &adminID, IPAddress: ipAddress}
+			if payload.SalaryChangeReason != nil {
+				salaryChange.Reason = *payload.SalaryChangeReason
+			}
+			changes["salary"] = fiber.Map{"old": employee.Salary, "new": *payload.Salary, "effective_from": effectiveFrom.Format("2006-01-02")}
+		}
+	}
+
+	if payload.WorkScheduleID != nil {
+		workScheduleID, fe := parseWorkScheduleID(*payload.WorkScheduleID)
+		if fe != nil {
+			return respondWithError(c, fe)
+		}
+		if !sameUUID(workScheduleID, employee.WorkScheduleID) {
+			if workScheduleID == nil {
+				updates["work_schedule_id"] = nil
+			} else {
+				updates["work_schedule_id"] = *workScheduleID
+			}
+			changes["work_schedule_id"] = fiber.Map{"old": employee.WorkScheduleID, "new": workScheduleID}
+		}
+	}
+
+	if len(updates) == 0 && salaryChange == nil {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newEmployeeResponse(*employee)})
+	}
+	updates["updated_by"] = adminID
+	updates["ip_address"] = ipAddress
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(employee).Updates(updates).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not update employee: %v", err))
+		}
+		if salaryChange != nil {
+			if _, err := services.NewSalaryHistoryService(tx).RecordSalaryChange(employee, *salaryChange); err != nil {
+				return salaryChangeError(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while updating the employee."})
+	}
+	if err := database.DB.First(employee, "id = ?", employee.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	action := "update_employee"
+	if _, ok := changes["salary"]; ok {
+		action = "update_employee_salary"
+	}
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           employee.ID,
+		UserType:         "employee",
+		Action:           action,
+		TargetResource:   "employee",
+		TargetResourceID: employee.ID,
+		Changes:          changes,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newEmployeeResponse(*employee)})
+}
+
+// DeactivateEmployee godoc
+// @Summary Deactivate Employee
+// @Description Allows an admin to deactivate an employee. Deactivated employees cannot log in and are not included in payroll runs for periods starting after deactivation. Records are kept.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=EmployeeResponse} "Deactivated employee"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 409 {object} object{status=string,message=string} "Employee already deactivated"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id}/deactivate [post]
+func DeactivateEmployee(c *fiber.Ctx) error {
+	return setEmployeeActive(c, false)
+}
+
+// ReactivateEmployee godoc
+// @Summary Reactivate Employee
+// @Description Allows an admin to reactivate a previously deactivated employee.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=EmployeeResponse} "Reactivated employee"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 409 {object} object{status=string,message=string} "Employee is already active"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id}/reactivate [post]
+func ReactivateEmployee(c *fiber.Ctx) error {
+	return setEmployeeActive(c, true)
+}
+
+func setEmployeeActive(c *fiber.Ctx, active bool) error {
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	action := "deactivate_employee"
+	var deactivatedAt *time.Time
+	if active {
+		if employee.DeactivatedAt == nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": "Employee is already active."})
+		}
+		action = "reactivate_employee"
+	} else {
+		if employee.DeactivatedAt != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": "Employee is already deactivated."})
+		}
+		now := time.Now()
+		deactivatedAt = &now
+	}
+
+	if err := database.DB.Model(employee).Updates(map[string]interface{}{
+		"deactivated_at": deactivatedAt,
+		"updated_by":     adminID,
+		"ip_address":     ipAddress,
+	}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not update employee: %v", err)})
+	}
+	employee.DeactivatedAt = deactivatedAt
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           employee.ID,
+		UserType:         "employee",
+		Action:           action,
+		TargetResource:   "employee",
+		TargetResourceID: employee.ID,
+		Changes:          map[string]interface{}{"deactivated_at": deactivatedAt},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newEmployeeResponse(*employee)})
+}
+
+// MaxEmployeeImportFileSize caps the size of an uploaded employee CSV
+const MaxEmployeeImportFileSize = 5 * 1024 * 1024
+
+// ImportEmployees godoc
+// @Summary Import Employees from CSV
+// @Description Allows an admin to create employees in bulk from a CSV file with the columns username, salary and optionally password.
+// @Description Every row is validated first and employees are created in a single transaction only if all rows are valid.
+// @Description Rows without a password get a temporary password that is returned once in the report.
+// @Tags Admin
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file"
+// @Param dry_run query bool false "Validate only, do not create employees"
+// @Success 200 {object} object{status=string,data=services.EmployeeImportReport} "Dry-run report"
+// @Success 201 {object} object{status=string,data=services.EmployeeImportReport} "Import report"
+// @Failure 400 {object} object{status=string,message=string} "Missing or oversized file"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 422 {object} object{status=string,message=string,data=services.EmployeeImportReport} "Validation report with the invalid rows"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/import [post]
+func ImportEmployees(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "A CSV file is required in the 'file' field."})
+	}
+	if fileHeader.Size > MaxEmployeeImportFileSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("File is too large. The maximum size is %d bytes.", MaxEmployeeImportFileSize)})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Could not read the uploaded file."})
+	}
+	defer file.Close()
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	dryRun := c.QueryBool("dry_run", false)
+	report, err := services.NewEmployeeImporter(database.DB).Import(file, services.EmployeeImportOptions{
+		DryRun:      dryRun,
+		PerformedBy: &adminID,
+		IPAddress:   c.IP(),
+		RequestID:   requestID,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEmployeeImport) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"status": "fail", "message": err.Error(), "data": report})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not import employees: %v", err)})
+	}
+
+	if dryRun {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": report})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": report})
+}
+
+// sameUUID reports whether two optional IDs are both nil or equal
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// findEmployeeByParam loads the employee named by the :id route parameter
+func findEmployeeByParam(c *fiber.Ctx) (*models.Employee, *fiber.Error) {
+	employeeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid employee ID format.")
+	}
+
+	var employee models.Employee
+	if err := database.DB.First(&employee, "id = ?", employeeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Employee not found.")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+	}
+	return &employee, nil
+}
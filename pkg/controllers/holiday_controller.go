@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"fmt"
+	"path/filepath"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxHolidayImportFileSize caps the size of an uploaded holiday calendar
+const MaxHolidayImportFileSize = 1024 * 1024
+
+// CreateHolidayPayload struct for adding a holiday
+type CreateHolidayPayload struct {
+	Date string `json:"date" validate:"required"` // YYYY-MM-DD
+	Name string `json:"name" validate:"required"`
+}
+
+// CreateHoliday godoc
+// @Summary Create Holiday
+// @Description Allows an admin to add a company holiday. Holidays are excluded from working days and block attendance submission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param holiday body CreateHolidayPayload true "Holiday"
+// @Success 201 {object} object{status=string,data=models.Holiday} "Created holiday"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 409 {object} object{status=string,message=string} "A holiday already exists on this date"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/holidays [post]
+func CreateHoliday(c *fiber.Ctx) error {
+	var payload CreateHolidayPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	date, err := time.Parse("2006-01-02", payload.Date)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid date format. Use YYYY-MM-DD."})
+	}
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Name is required."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	existing, err := services.NewHolidayService(database.DB).FindHoliday(date)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	if existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("A holiday already exists on %s (%s).", payload.Date, existing.Name)})
+	}
+
+	holiday := models.Holiday{Date: date, Name: name, Source: models.HolidaySourceManual}
+	holiday.CreatedBy = &adminID
+	holiday.UpdatedBy = &adminID
+	holiday.IPAddress = &ipAddress
+	if err := database.DB.Create(&holiday).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not create holiday: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "create_holiday",
+		TargetResource:   "holiday",
+		TargetResourceID: holiday.ID,
+		Changes:          map[string]interface{}{"date": payload.Date, "name": name},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": holiday})
+}
+
+// ListHolidays godoc
+// @Summary List Holidays
+// @Description Allows an admin to list company holidays for a year, ordered by date.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Calendar year (defaults to the current year)"
+// @Success 200 {object} object{status=string,data=[]models.Holiday} "Holidays"
+// @Failure 400 {object} object{status=string,message=string} "Invalid year"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/holidays [get]
+func ListHolidays(c *fiber.Ctx) error {
+	year := c.QueryInt("year", time.Now().Year())
+	if year < 1900 || year > 9999 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid year."})
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	var holidays []models.Holiday
+	if err := database.DB.Where("date BETWEEN ? AND ?", start, end).Order("date").Find(&holidays).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch holidays: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": holidays})
+}
+
+// DeleteHoliday godoc
+// @Summary Delete Holiday
+// @Description Allows an admin to remove a company holiday. Payslips already generated keep the working days they were calculated with.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Holiday ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=models.Holiday} "Deleted holiday"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Holiday not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/holidays/{id} [delete]
+func DeleteHoliday(c *fiber.Ctx) error {
+	holidayID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid holiday ID format."})
+	}
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var holiday models.Holiday
+	if err := database.DB.First(&holiday, "id = ?", holidayID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Holiday not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	if err := database.DB.Delete(&holiday).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not delete holiday: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "delete_holiday",
+		TargetResource:   "holiday",
+		TargetResourceID: holiday.ID,
+		Changes:          map[string]interface{}{"date": holiday.Date.Format("2006-01-02"), "name": holiday.Name},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": holiday})
+}
+
+// ImportHolidays godoc
+// @Summary Import Holidays
+// @Description Allows an admin to import holidays from an iCalendar (.ics) file or a CSV file with date (YYYY-MM-DD) and name columns.
+// @Description Dates that already exist are updated, so the same calendar can be imported again safely.
+// @Tags Admin
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "ICS or CSV file"
+// @Param format query string false "ics or csv; inferred from the file extension when omitted"
+// @Success 200 {object} object{status=string,data=services.HolidayImportResult} "Import result"
+// @Failure 400 {object} object{status=string,message=string} "Missing, oversized or invalid file"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/holidays/import [post]
+func ImportHolidays(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "An ICS or CSV file is required in the 'file' field."})
+	}
+	if fileHeader.Size > MaxHolidayImportFileSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("File is too large. The maximum size is %d bytes.", MaxHolidayImportFileSize)})
+	}
+	format := strings.ToLower(c.Query("format", strings.TrimPrefix(filepath.Ext(fileHeader.Filename), ".")))
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Could not read the uploaded file."})
+	}
+	defer file.Close()
+
+	entries, source, err := services.ParseHolidayFile(file, format)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	result, err := services.NewHolidayService(database.DB).Import(entries, source, &adminID, c.IP(), requestID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not import holidays: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": result})
+}
@@ -0,0 +1,753 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaveTypePayload struct for creating or replacing a leave type
+type LeaveTypePayload struct {
+	Name              string  `json:"name" validate:"required"`
+	Paid              bool    `json:"paid"`
+	YearlyEntitlement float64 `json:"yearly_entitlement" validate:"gte=0"`               // Days per calendar year; ignored for unpaid leave
+	Accrual           string  `json:"accrual" example:"upfront" enums:"upfront,monthly"` // Defaults to upfront
+}
+
+func (p LeaveTypePayload) toModel() models.LeaveType {
+	accrual := p.Accrual
+	if accrual == "" {
+		accrual = models.LeaveAccrualUpfront
+	}
+	return models.LeaveType{Name: strings.TrimSpace(p.Name), Paid: p.Paid, YearlyEntitlement: p.YearlyEntitlement, Accrual: accrual}
+}
+
+// CreateLeaveType godoc
+// @Summary Create Leave Type
+// @Description Allows an admin to define a leave type. Paid leave counts as attended in payroll and is limited by the yearly entitlement;
+// @Description unpaid leave is deducted from salary and not limited.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param leave_type body LeaveTypePayload true "Leave type"
+// @Success 201 {object} object{status=string,data=models.LeaveType} "Created leave type"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 409 {object} object{status=string,message=string} "Name already exists"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/leave-types [post]
+func CreateLeaveType(c *fiber.Ctx) error {
+	var payload LeaveTypePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	leaveType := payload.toModel()
+	if err := services.ValidateLeaveType(leaveType); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	if fe := checkLeaveTypeNameAvailable(leaveType.Name, uuid.Nil); fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	leaveType.CreatedBy = &adminID
+	leaveType.UpdatedBy = &adminID
+	leaveType.IPAddress = &ipAddress
+	if err := database.DB.Create(&leaveType).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not create leave type: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "create_leave_type",
+		TargetResource:   "leave_type",
+		TargetResourceID: leaveType.ID,
+		Changes:          leaveType,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": leaveType})
+}
+
+// ListLeaveTypes godoc
+// @Summary List Leave Types
+// @Description Allows an admin to list all leave types, ordered by name.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=[]models.LeaveType} "Leave types"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/leave-types [get]
+func ListLeaveTypes(c *fiber.Ctx) error {
+	var leaveTypes []models.LeaveType
+	if err := database.DB.Order("name").Find(&leaveTypes).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch leave types: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": leaveTypes})
+}
+
+// UpdateLeaveType godoc
+// @Summary Update Leave Type
+// @Description Allows an admin to change a leave type. Balances are recalculated from the new entitlement; existing payslips are unchanged.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave Type ID (UUID)" format(uuid)
+// @Param leave_type body LeaveTypePayload true "Leave type"
+// @Success 200 {object} object{status=string,data=models.LeaveType} "Updated leave type"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Leave type not found"
+// @Failure 409 {object} object{status=string,message=string} "Name already exists"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/leave-types/{id} [put]
+func UpdateLeaveType(c *fiber.Ctx) error {
+	var payload LeaveTypePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	updated := payload.toModel()
+	if err := services.ValidateLeaveType(updated); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	leaveType, fe := findLeaveTypeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	if fe := checkLeaveTypeNameAvailable(updated.Name, leaveType.ID); fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	previous := *leaveType
+	leaveType.Name = updated.Name
+	leaveType.Paid = updated.Paid
+	leaveType.YearlyEntitlement = updated.YearlyEntitlement
+	leaveType.Accrual = updated.Accrual
+	leaveType.UpdatedBy = &adminID
+	leaveType.IPAddress = &ipAddress
+	if err := database.DB.Save(leaveType).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not update leave type: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "update_leave_type",
+		TargetResource:   "leave_type",
+		TargetResourceID: leaveType.ID,
+		Changes:          map[string]interface{}{"old": previous, "new": leaveType},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": leaveType})
+}
+
+// DeleteLeaveType godoc
+// @Summary Delete Leave Type
+// @Description Allows an admin to delete a leave type that has never been requested.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave Type ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=models.LeaveType} "Deleted leave type"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Leave type not found"
+// @Failure 409 {object} object{status=string,message=string} "Leave type has requests"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/leave-types/{id} [delete]
+func DeleteLeaveType(c *fiber.Ctx) error {
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	leaveType, fe := findLeaveTypeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	var requested int64
+	if err := database.DB.Model(&models.LeaveRequest{}).Where("leave_type_id = ?", leaveType.ID).Count(&requested).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	if requested > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Leave type has %d request(s) and cannot be deleted.", requested)})
+	}
+	if err := database.DB.Delete(leaveType).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not delete leave type: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "delete_leave_type",
+		TargetResource:   "leave_type",
+		TargetResourceID: leaveType.ID,
+		Changes:          leaveType,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": leaveType})
+}
+
+// LeaveRequestListItem is the list view of a leave request
+type LeaveRequestListItem struct {
+	ID           uuid.UUID  `json:"id"`
+	EmployeeID   uuid.UUID  `json:"employee_id"`
+	Username     string     `json:"username"`
+	LeaveTypeID  uuid.UUID  `json:"leave_type_id"`
+	LeaveType    string     `json:"leave_type"`
+	Paid         bool       `json:"paid"`
+	StartDate    string     `json:"start_date"`
+	EndDate      string     `json:"end_date"`
+	Days         float64    `json:"days"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	SubmittedAt  time.Time  `json:"submitted_at"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	ReviewReason *string    `json:"review_reason,omitempty"`
+}
+
+func newLeaveRequestListItem(lr models.LeaveRequest) LeaveRequestListItem {
+	return LeaveRequestListItem{
+		ID:           lr.ID,
+		EmployeeID:   lr.EmployeeID,
+		Username:     lr.Employee.Username,
+		LeaveTypeID:  lr.LeaveTypeID,
+		LeaveType:    lr.LeaveType.Name,
+		Paid:         lr.LeaveType.Paid,
+		StartDate:    lr.StartDate.Format("2006-01-02"),
+		EndDate:      lr.EndDate.Format("2006-01-02"),
+		Days:         lr.Days,
+		Reason:       lr.Reason,
+		Status:       lr.Status,
+		SubmittedAt:  lr.CreatedAt,
+		ReviewedAt:   lr.ReviewedAt,
+		ReviewReason: lr.ReviewReason,
+	}
+}
+
+// ListLeaveRequests godoc
+// @Summary List Leave Requests
+// @Description Allows an admin to list leave requests, pending ones by default, with optional filters.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected); defaults to pending"
+// @Param employee_id query string false "Employee ID (UUID)" format(uuid)
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]LeaveRequestListItem,pagination=utils.Pagination} "Leave requests"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/leave-requests [get]
+func ListLeaveRequests(c *fiber.Ctx) error {
+	status := c.Query("status", models.LeavePending)
+	switch status {
+	case models.LeavePending, models.LeaveApproved, models.LeaveRejected:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter."})
+	}
+
+	query := database.DB.Model(&models.LeaveRequest{}).Preload("Employee").Preload("LeaveType").Where("status = ?", status)
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid employee_id format."})
+		}
+		query = query.Where("employee_id = ?", employeeID)
+	}
+
+	return listLeaveRequests(c, query)
+}
+
+// ListMyLeaveRequests godoc
+// @Summary List My Leave Requests
+// @Description Allows an authenticated employee to list their own leave requests, most recent first.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected)"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]LeaveRequestListItem,pagination=utils.Pagination} "Leave requests"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/leave-requests [get]
+func ListMyLeaveRequests(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+
+	query := database.DB.Model(&models.LeaveRequest{}).Preload("Employee").Preload("LeaveType").Where("employee_id = ?", employeeID)
+	if status := c.Query("status"); status != "" {
+		switch status {
+		case models.LeavePending, models.LeaveApproved, models.LeaveRejected:
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter."})
+		}
+		query = query.Where("status = ?", status)
+	}
+
+	return listLeaveRequests(c, query)
+}
+
+// listLeaveRequests paginates a leave request query and writes the list response
+func listLeaveRequests(c *fiber.Ctx, query *gorm.DB) error {
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count leave requests: %v", err)})
+	}
+
+	var requests []models.LeaveRequest
+	if err := pageQuery.Order("start_date DESC, created_at").Find(&requests).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch leave requests: %v", err)})
+	}
+
+	items := make([]LeaveRequestListItem, 0, len(requests))
+	for _, lr := range requests {
+		items = append(items, newLeaveRequestListItem(lr))
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// SubmitLeaveRequestPayload struct for requesting leave
+type SubmitLeaveRequestPayload struct {
+	LeaveTypeID string `json:"leave_type_id" validate:"required,uuid"`
+	StartDate   string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate     string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	Reason      string `json:"reason"`
+}
+
+// SubmitLeaveRequest godoc
+// @Summary Submit Leave Request
+// @Description Allows an authenticated employee to request leave for a range of dates within one calendar year.
+// @Description Only working days of the employee's schedule that are not holidays are counted. Paid leave must fit in the accrued balance.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param leave_request body SubmitLeaveRequestPayload true "Leave request"
+// @Success 201 {object} object{status=string,data=LeaveRequestListItem} "Submitted leave request"
+// @Failure 400 {object} object{status=string,message=string} "Validation error, insufficient balance, or payroll already run"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 409 {object} object{status=string,message=string} "Overlaps an existing leave request"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/leave-requests [post]
+func SubmitLeaveRequest(c *fiber.Ctx) error {
+	var payload SubmitLeaveRequestPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	leaveTypeID, err := uuid.Parse(payload.LeaveTypeID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid leave_type_id format."})
+	}
+	startDate, err := time.Parse("2006-01-02", payload.StartDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid start_date format. Use YYYY-MM-DD."})
+	}
+	endDate, err := time.Parse("2006-01-02", payload.EndDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid end_date format. Use YYYY-MM-DD."})
+	}
+	if endDate.Before(startDate) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "end_date must be on or after start_date."})
+	}
+
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var leaveRequest models.LeaveRequest
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the employee so concurrent requests cannot both spend the same balance
+		var employee models.Employee
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&employee, "id = ?", employeeID).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Database error loading employee.")
+		}
+		var leaveType models.LeaveType
+		if err := tx.First(&leaveType, "id = ?", leaveTypeID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusBadRequest, "Leave type not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+
+		leaveService := services.NewLeaveService(tx)
+		days, err := leaveService.CountLeaveDays(employee, startDate, endDate)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if days == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "The requested dates contain no working days.")
+		}
+
+		leaveRequest = models.LeaveRequest{
+			EmployeeID:  employeeID,
+			LeaveTypeID: leaveType.ID,
+			StartDate:   startDate,
+			EndDate:     endDate,
+			Days:        float64(days),
+			Reason:      strings.TrimSpace(payload.Reason),
+			Status:      models.LeavePending,
+			Employee:    employee,
+			LeaveType:   leaveType,
+		}
+		if fe := leaveRequestError(leaveService.CheckLeaveRequest(employee, leaveType, leaveRequest)); fe != nil {
+			return fe
+		}
+
+		leaveRequest.CreatedBy = &employeeID
+		leaveRequest.UpdatedBy = &employeeID
+		leaveRequest.IPAddress = &ipAddress
+		if err := tx.Omit(clause.Associations).Create(&leaveRequest).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not submit leave request: %v", err))
+		}
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employeeID,
+			UserType:         "employee",
+			Action:           "submit_leave_request",
+			TargetResource:   "leave_request",
+			TargetResourceID: leaveRequest.ID,
+			Changes:          newLeaveRequestListItem(leaveRequest),
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      employeeID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while submitting the leave request."})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": newLeaveRequestListItem(leaveRequest)})
+}
+
+// ReviewLeaveRequestPayload struct for approving or rejecting a leave request
+type ReviewLeaveRequestPayload struct {
+	Reason string `json:"reason"` // Required when rejecting
+}
+
+// ApproveLeaveRequest godoc
+// @Summary Approve Leave Request
+// @Description Allows an admin to approve a pending leave request. The days are recounted and paid leave is checked against the balance again.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave Request ID (UUID)" format(uuid)
+// @Param review body ReviewLeaveRequestPayload false "Optional approval note"
+// @Success 200 {object} object{status=string,data=LeaveRequestListItem} "Approved leave request"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID, insufficient balance, or payroll already run"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Leave request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending or overlaps another request"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/leave-requests/{id}/approve [post]
+func ApproveLeaveRequest(c *fiber.Ctx) error {
+	return reviewLeaveRequest(c, models.LeaveApproved)
+}
+
+// RejectLeaveRequest godoc
+// @Summary Reject Leave Request
+// @Description Allows an admin to reject a pending leave request with a reason.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave Request ID (UUID)" format(uuid)
+// @Param review body ReviewLeaveRequestPayload true "Rejection reason"
+// @Success 200 {object} object{status=string,data=LeaveRequestListItem} "Rejected leave request"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID or missing reason"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Leave request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/leave-requests/{id}/reject [post]
+func RejectLeaveRequest(c *fiber.Ctx) error {
+	return reviewLeaveRequest(c, models.LeaveRejected)
+}
+
+// reviewLeaveRequest moves a pending leave request to the given status and audits the decision
+func reviewLeaveRequest(c *fiber.Ctx, newStatus string) error {
+	leaveRequestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid leave request ID format."})
+	}
+
+	var payload ReviewLeaveRequestPayload
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+	}
+	if newStatus == models.LeaveRejected && payload.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Reason is required when rejecting a leave request."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var leaveRequest models.LeaveRequest
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the row so two admins cannot decide the same request at once
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&leaveRequest, "id = ?", leaveRequestID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusNotFound, "Leave request not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		if leaveRequest.Status != models.LeavePending {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Leave request is already %s.", leaveRequest.Status))
+		}
+		if err := tx.First(&leaveRequest.Employee, "id = ?", leaveRequest.EmployeeID).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		if err := tx.First(&leaveRequest.LeaveType, "id = ?", leaveRequest.LeaveTypeID).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+
+		updates := map[string]interface{}{
+			"status":      newStatus,
+			"reviewed_at": time.Now(),
+			"updated_by":  adminID,
+			"ip_address":  ipAddress,
+		}
+		if newStatus == models.LeaveApproved {
+			// Holidays or the employee's schedule may have changed since the request was made
+			leaveService := services.NewLeaveService(tx)
+			days, err := leaveService.CountLeaveDays(leaveRequest.Employee, leaveRequest.StartDate, leaveRequest.EndDate)
+			if err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+			leaveRequest.Days = float64(days)
+			if fe := leaveRequestError(leaveService.CheckLeaveRequest(leaveRequest.Employee, leaveRequest.LeaveType, leaveRequest)); fe != nil {
+				return fe
+			}
+			updates["days"] = leaveRequest.Days
+		}
+		if payload.Reason != "" {
+			updates["review_reason"] = payload.Reason
+		}
+
+		previousStatus := leaveRequest.Status
+		if err := tx.Model(&leaveRequest).Omit(clause.Associations).Updates(updates).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not update leave request: %v", err))
+		}
+		reviewedAt := updates["reviewed_at"].(time.Time)
+		leaveRequest.Status = newStatus
+		leaveRequest.ReviewedAt = &reviewedAt
+		leaveRequest.UpdatedBy = &adminID
+		leaveRequest.IPAddress = &ipAddress
+		if payload.Reason != "" {
+			leaveRequest.ReviewReason = &payload.Reason
+		}
+
+		action := "approve_leave_request"
+		if newStatus == models.LeaveRejected {
+			action = "reject_leave_request"
+		}
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           adminID,
+			UserType:         "admin",
+			Action:           action,
+			TargetResource:   "leave_request",
+			TargetResourceID: leaveRequest.ID,
+			Changes: map[string]interface{}{
+				"employee_id":     leaveRequest.EmployeeID,
+				"leave_type":      leaveRequest.LeaveType.Name,
+				"previous_status": previousStatus,
+				"new_status":      newStatus,
+				"reason":          payload.Reason,
+				"days":            leaveRequest.Days,
+			},
+			IPAddress:   ipAddress,
+			RequestID:   requestID,
+			PerformedBy: adminID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while reviewing the leave request."})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newLeaveRequestListItem(leaveRequest)})
+}
+
+// GetMyLeaveBalances godoc
+// @Summary Get My Leave Balances
+// @Description Allows an authenticated employee to see their balance for every leave type in a year.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Calendar year (defaults to the current year)"
+// @Success 200 {object} object{status=string,data=[]services.LeaveBalance} "Leave balances"
+// @Failure 400 {object} object{status=string,message=string} "Invalid year"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/leave-balances [get]
+func GetMyLeaveBalances(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	var employee models.Employee
+	if err := database.DB.First(&employee, "id = ?", employeeID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Database error loading employee."})
+	}
+	return respondWithLeaveBalances(c, employee)
+}
+
+// GetEmployeeLeaveBalances godoc
+// @Summary Get Employee Leave Balances
+// @Description Allows an admin to see an employee's balance for every leave type in a year.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Param year query int false "Calendar year (defaults to the current year)"
+// @Success 200 {object} object{status=string,data=[]services.LeaveBalance} "Leave balances"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID or year"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id}/leave-balances [get]
+func GetEmployeeLeaveBalances(c *fiber.Ctx) error {
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	return respondWithLeaveBalances(c, *employee)
+}
+
+// respondWithLeaveBalances writes the employee's leave balances for the year in the ?year= query parameter
+func respondWithLeaveBalances(c *fiber.Ctx, employee models.Employee) error {
+	now := time.Now()
+	year := c.QueryInt("year", now.Year())
+	if year < 1900 || year > 9999 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid year."})
+	}
+	balances, err := services.NewLeaveService(database.DB).Balances(employee, year, now)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": balances})
+}
+
+// leaveRequestError maps a LeaveService.CheckLeaveRequest error to a response error
+func leaveRequestError(err error) *fiber.Error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, services.ErrLeaveOverlap):
+		return fiber.NewError(fiber.StatusConflict, "The dates overlap another pending or approved leave request.")
+	case errors.Is(err, services.ErrLeaveSpansYears):
+		return fiber.NewError(fiber.StatusBadRequest, "A leave request cannot span two calendar years. Submit one request per year.")
+	case errors.Is(err, services.ErrLeavePayrollClosed):
+		return fiber.NewError(fiber.StatusBadRequest, "Payroll has already been run for a period covering these dates.")
+	case errors.Is(err, services.ErrInsufficientLeaveBalance):
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Insufficient leave balance: %v", err))
+	default:
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+}
+
+// findLeaveTypeByParam loads the leave type named by the :id route parameter
+func findLeaveTypeByParam(c *fiber.Ctx) (*models.LeaveType, *fiber.Error) {
+	leaveTypeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid leave type ID format.")
+	}
+
+	var leaveType models.LeaveType
+	if err := database.DB.First(&leaveType, "id = ?", leaveTypeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Leave type not found.")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+	}
+	return &leaveType, nil
+}
+
+// checkLeaveTypeNameAvailable returns a 409 error if another leave type already uses the name
+func checkLeaveTypeNameAvailable(name string, excludeID uuid.UUID) *fiber.Error {
+	var existingCount int64
+	if err := database.DB.Model(&models.LeaveType{}).Where("name = ? AND id <> ?", name, excludeID).Count(&existingCount).Error; err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Database error checking leave type name.")
+	}
+	if existingCount > 0 {
+		return fiber.NewError(fiber.StatusConflict, "A leave type with this name already exists.")
+	}
+	return nil
+}
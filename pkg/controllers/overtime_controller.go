@@ -0,0 +1,283 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OvertimeListItem is the admin view of an overtime record
+type OvertimeListItem struct {
+	ID             uuid.UUID                    `json:"id"`
+	EmployeeID     uuid.UUID                    `json:"employee_id"`
+	Username       string                       `json:"username"`
+	Date           string                       `json:"date"`
+	Minutes        int                          `json:"minutes"`
+	Hours          float64                      `json:"hours"` // Minutes as decimal hours
+	StartTime      *time.Time                   `json:"start_time,omitempty"`
+	EndTime        *time.Time                   `json:"end_time,omitempty"`
+	RateMultiplier float64                      `json:"rate_multiplier"`
+	DayType        string                       `json:"day_type,omitempty"`
+	PolicyVersion  *int                         `json:"policy_version,omitempty"`
+	Breakdown      []models.OvertimeTierMinutes `json:"breakdown,omitempty"`
+	Status         string                       `json:"status"`
+	SubmittedAt    time.Time                    `json:"submitted_at"`
+	ReviewedAt     *time.Time                   `json:"reviewed_at,omitempty"`
+	ReviewReason   *string                      `json:"review_reason,omitempty"`
+}
+
+func newOvertimeListItem(ot models.OvertimeRecord) OvertimeListItem {
+	return OvertimeListItem{
+		ID:             ot.ID,
+		EmployeeID:     ot.EmployeeID,
+		Username:       ot.Employee.Username,
+		Date:           ot.Date.Format("2006-01-02"),
+		Minutes:        ot.Minutes,
+		Hours:          ot.HoursWorked(),
+		StartTime:      ot.StartTime,
+		EndTime:        ot.EndTime,
+		RateMultiplier: ot.RateMultiplier,
+		DayType:        ot.DayType,
+		PolicyVersion:  ot.PolicyVersion,
+		Breakdown:      ot.Breakdown,
+		Status:         ot.Status,
+		SubmittedAt:    ot.SubmittedAt,
+		ReviewedAt:     ot.ReviewedAt,
+		ReviewReason:   ot.ReviewReason,
+	}
+}
+
+// ListOvertimeRecords godoc
+// @Summary List Overtime Records
+// @Description Allows an admin to list overtime records, pending ones by default, with optional filters.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected); defaults to pending"
+// @Param employee_id query string false "Employee ID (UUID)" format(uuid)
+// @Param period_id query string false "Attendance Period ID (UUID); overtime dated within the period"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]OvertimeListItem,pagination=utils.Pagination} "Overtime records"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 404 {object} object{status=string,message=string} "Attendance period not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/overtime [get]
+func ListOvertimeRecords(c *fiber.Ctx) error {
+	status := c.Query("status", models.OvertimePending)
+	switch status {
+	case models.OvertimePending, models.OvertimeApproved, models.OvertimeRejected:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter."})
+	}
+
+	query := database.DB.Model(&models.OvertimeRecord{}).Preload("Employee").Where("status = ?", status)
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid employee_id format."})
+		}
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	if periodIDStr := c.Query("period_id"); periodIDStr != "" {
+		periodID, err := uuid.Parse(periodIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+		}
+		var period models.AttendancePeriod
+		if err := database.DB.First(&period, "id = ?", periodID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Attendance period not found."})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+		}
+		query = query.Where("date BETWEEN ? AND ?", period.StartDate, period.EndDate)
+	}
+
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count overtime records: %v", err)})
+	}
+
+	var records []models.OvertimeRecord
+	if err := pageQuery.Order("date, submitted_at").Find(&records).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch overtime records: %v", err)})
+	}
+
+	items := make([]OvertimeListItem, 0, len(records))
+	for _, ot := range records {
+		items = append(items, newOvertimeListItem(ot))
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// ReviewOvertimePayload struct for approving or rejecting an overtime record
+type ReviewOvertimePayload struct {
+	Reason string `json:"reason"` // Required when rejecting
+}
+
+// ApproveOvertime godoc
+// @Summary Approve Overtime
+// @Description Allows an admin to approve pending overtime so it is paid when payroll is run for its period.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Overtime Record ID (UUID)" format(uuid)
+// @Param review body ReviewOvertimePayload false "Optional approval note"
+// @Success 200 {object} object{status=string,data=OvertimeListItem} "Approved overtime"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Overtime record not found"
+// @Failure 409 {object} object{status=string,message=string} "Overtime is not pending, or payroll has been run for its period"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/overtime/{id}/approve [post]
+func ApproveOvertime(c *fiber.Ctx) error {
+	return reviewOvertime(c, models.OvertimeApproved)
+}
+
+// RejectOvertime godoc
+// @Summary Reject Overtime
+// @Description Allows an admin to reject pending overtime with a reason. Rejected overtime is never paid.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Overtime Record ID (UUID)" format(uuid)
+// @Param review body ReviewOvertimePayload true "Rejection reason"
+// @Success 200 {object} object{status=string,data=OvertimeListItem} "Rejected overtime"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID or missing reason"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Overtime record not found"
+// @Failure 409 {object} object{status=string,message=string} "Overtime is not pending"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/overtime/{id}/reject [post]
+func RejectOvertime(c *fiber.Ctx) error {
+	return reviewOvertime(c, models.OvertimeRejected)
+}
+
+// reviewOvertime moves a pending overtime record to the given status and audits the decision
+func reviewOvertime(c *fiber.Ctx, newStatus string) error {
+	overtimeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid overtime record ID format."})
+	}
+
+	var payload ReviewOvertimePayload
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+	}
+	if newStatus == models.OvertimeRejected && payload.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Reason is required when rejecting overtime."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var overtimeRecord models.OvertimeRecord
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the row so two admins cannot decide the same overtime at once
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Employee").First(&overtimeRecord, "id = ?", overtimeID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusNotFound, "Overtime record not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		if overtimeRecord.Status != models.OvertimePending {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Overtime is already %s.", overtimeRecord.Status))
+		}
+
+		// Approval after payroll has run would never be paid; void the payroll first
+		if newStatus == models.OvertimeApproved {
+			var closed int64
+			if err := tx.Model(&models.AttendancePeriod{}).
+				Where("start_date <= ? AND end_date >= ? AND payroll_run_at IS NOT NULL", overtimeRecord.Date, overtimeRecord.Date).
+				Count(&closed).Error; err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+			}
+			if closed > 0 {
+				return fiber.NewError(fiber.StatusConflict, "Payroll has already been run for the period containing this overtime.")
+			}
+			paid, err := services.HasActivePayslip(tx, overtimeRecord.EmployeeID, overtimeRecord.Date, overtimeRecord.Date)
+			if err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+			}
+			if paid {
+				return fiber.NewError(fiber.StatusConflict, "The employee has already been paid for the period containing this overtime; void the payroll first.")
+			}
+		}
+
+		previousStatus := overtimeRecord.Status
+		now := time.Now()
+		updates := map[string]interface{}{
+			"status":      newStatus,
+			"reviewed_at": now,
+			"updated_by":  adminID,
+			"ip_address":  ipAddress,
+		}
+		if payload.Reason != "" {
+			updates["review_reason"] = payload.Reason
+		}
+		if err := tx.Model(&overtimeRecord).Omit(clause.Associations).Updates(updates).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not update overtime record: %v", err))
+		}
+		overtimeRecord.Status = newStatus
+		overtimeRecord.ReviewedAt = &now
+		overtimeRecord.UpdatedBy = &adminID
+		overtimeRecord.IPAddress = &ipAddress
+		if payload.Reason != "" {
+			overtimeRecord.ReviewReason = &payload.Reason
+		}
+
+		action := "approve_overtime"
+		if newStatus == models.OvertimeRejected {
+			action = "reject_overtime"
+		}
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           adminID,
+			UserType:         "admin",
+			Action:           action,
+			TargetResource:   "overtime_record",
+			TargetResourceID: overtimeRecord.ID,
+			Changes: map[string]interface{}{
+				"employee_id":     overtimeRecord.EmployeeID,
+				"date":            overtimeRecord.Date.Format("2006-01-02"),
+				"minutes":         overtimeRecord.Minutes,
+				"previous_status": previousStatus,
+				"new_status":      newStatus,
+				"reason":          payload.Reason,
+			},
+			IPAddress:   ipAddress,
+			RequestID:   requestID,
+			PerformedBy: adminID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while reviewing the overtime."})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newOvertimeListItem(overtimeRecord)})
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OvertimePolicyPayload struct for creating an overtime policy version
+type OvertimePolicyPayload struct {
+	Name                 string                `json:"name" validate:"required"`
+	EffectiveFrom        string                `json:"effective_from" validate:"required" example:"2025-01-01"` // YYYY-MM-DD
+	WorkdayTiers         []models.OvertimeTier `json:"workday_tiers" validate:"required"`                       // Hours 0 on the last tier covers the remaining hours
+	RestDayTiers         []models.OvertimeTier `json:"rest_day_tiers" validate:"required"`
+	HolidayTiers         []models.OvertimeTier `json:"holiday_tiers"` // Defaults to the rest day tiers
+	DailyCapHours        int                   `json:"daily_cap_hours" validate:"required,min=1,max=24"`
+	RestDayDailyCapHours int                   `json:"rest_day_daily_cap_hours" validate:"required,min=1,max=24"` // Also applies to holidays
+	WeeklyCapHours       int                   `json:"weekly_cap_hours" validate:"gte=0"`                         // 0 means no weekly cap
+	RoundingMinutes      int                   `json:"rounding_minutes" example:"15"`                             // Defaults to 1, exact minutes
+	RoundingMode         string                `json:"rounding_mode" example:"nearest" enums:"nearest,down,up"`   // Defaults to nearest
+}
+
+// CreateOvertimePolicy godoc
+// @Summary Create Overtime Policy Version
+// @Description Allows an admin to add a version of the overtime pay rules, effective from a date. Overtime is priced when it is submitted,
+// @Description by the latest version in effect on the overtime date, after rounding its duration; overtime already submitted keeps its pricing.
+// @Description Versions cannot be changed.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param policy body OvertimePolicyPayload true "Overtime policy"
+// @Success 201 {object} object{status=string,data=models.OvertimePolicy} "Created overtime policy"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/overtime-policies [post]
+func CreateOvertimePolicy(c *fiber.Ctx) error {
+	var payload OvertimePolicyPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	effectiveFrom, err := time.Parse("2006-01-02", payload.EffectiveFrom)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid effective_from format. Use YYYY-MM-DD."})
+	}
+	policy := models.OvertimePolicy{
+		Name:                 strings.TrimSpace(payload.Name),
+		EffectiveFrom:        effectiveFrom,
+		WorkdayTiers:         payload.WorkdayTiers,
+		RestDayTiers:         payload.RestDayTiers,
+		HolidayTiers:         payload.HolidayTiers,
+		DailyCapHours:        payload.DailyCapHours,
+		RestDayDailyCapHours: payload.RestDayDailyCapHours,
+		WeeklyCapHours:       payload.WeeklyCapHours,
+		RoundingMinutes:      payload.RoundingMinutes,
+		RoundingMode:         payload.RoundingMode,
+	}
+	if len(policy.HolidayTiers) == 0 {
+		policy.HolidayTiers = policy.RestDayTiers
+	}
+	if policy.RoundingMinutes == 0 {
+		policy.RoundingMinutes = 1
+	}
+	if policy.RoundingMode == "" {
+		policy.RoundingMode = models.OvertimeRoundNearest
+	}
+	if err := services.ValidateOvertimePolicy(policy); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	policy.CreatedBy = &adminID
+	policy.UpdatedBy = &adminID
+	policy.IPAddress = &ipAddress
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Serialise creations so each gets the next version number
+		if err := tx.Exec("LOCK TABLE overtime_policies IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		var latest int
+		if err := tx.Model(&models.OvertimePolicy{}).Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		policy.Version = latest + 1
+		if err := tx.Omit(clause.Associations).Create(&policy).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not create overtime policy: %v", err))
+		}
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           adminID,
+			UserType:         "admin",
+			Action:           "create_overtime_policy",
+			TargetResource:   "overtime_policy",
+			TargetResourceID: policy.ID,
+			Changes:          policy,
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      adminID,
+		})
+		return nil
+	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while creating the overtime policy."})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": policy})
+}
+
+// ListOvertimePolicies godoc
+// @Summary List Overtime Policy Versions
+// @Description Allows an admin to list the stored overtime policy versions, newest first. Without any, the built-in statutory policy applies.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=[]models.OvertimePolicy} "Overtime policies"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/overtime-policies [get]
+func ListOvertimePolicies(c *fiber.Ctx) error {
+	var policies []models.OvertimePolicy
+	if err := database.DB.Order("version DESC").Find(&policies).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch overtime policies: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": policies})
+}
+
+// GetEffectiveOvertimePolicy godoc
+// @Summary Get Effective Overtime Policy
+// @Description Allows an admin to see the overtime policy that prices overtime on a date: the latest version in effect,
+// @Description or the built-in statutory policy (version 0) if none is.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} object{status=string,data=models.OvertimePolicy} "Overtime policy in effect"
+// @Failure 400 {object} object{status=string,message=string} "Invalid date"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/overtime-policies/effective [get]
+func GetEffectiveOvertimePolicy(c *fiber.Ctx) error {
+	date := time.Now()
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid date format. Use YYYY-MM-DD."})
+		}
+		date = parsed
+	}
+	policy, err := services.NewOvertimePolicyService(database.DB).PolicyFor(date)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": policy})
+}
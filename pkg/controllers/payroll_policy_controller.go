@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PayrollPolicyPayload struct for creating a payroll policy version
+type PayrollPolicyPayload struct {
+	Name              string `json:"name" validate:"required"`
+	EffectiveFrom     string `json:"effective_from" validate:"required" example:"2025-01-01"`                                                  // YYYY-MM-DD
+	ProrationMethod   string `json:"proration_method" example:"working_days" enums:"working_days,calendar_days,divisor_21_75,divisor_22,none"` // Defaults to working_days
+	RoundingMode      string `json:"rounding_mode" example:"half_up" enums:"half_up,half_even,down,up"`                                        // Defaults to half_up
+	RoundingPrecision *int   `json:"rounding_precision" example:"2"`                                                                           // Decimal places from -3 to 2; defaults to 2
+}
+
+// CreatePayrollPolicy godoc
+// @Summary Create Payroll Policy Version
+// @Description Allows an admin to add a version of the payroll rules, effective from a date: how salary is prorated and how each payslip line is rounded.
+// @Description A payroll run uses the latest version in effect on the first day of its attendance period, and each payslip records the rules it was calculated with.
+// @Description Versions cannot be changed.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param policy body PayrollPolicyPayload true "Payroll policy"
+// @Success 201 {object} object{status=string,data=models.PayrollPolicy} "Created payroll policy"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/payroll-policies [post]
+func CreatePayrollPolicy(c *fiber.Ctx) error {
+	var payload PayrollPolicyPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	effectiveFrom, err := time.Parse("2006-01-02", payload.EffectiveFrom)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid effective_from format. Use YYYY-MM-DD."})
+	}
+	defaults := services.DefaultPayrollPolicy()
+	policy := models.PayrollPolicy{
+		Name:               strings.TrimSpace(payload.Name),
+		EffectiveFrom:      effectiveFrom,
+		PayrollPolicyRules: defaults.PayrollPolicyRules,
+	}
+	if payload.ProrationMethod != "" {
+		policy.ProrationMethod = payload.ProrationMethod
+	}
+	if payload.RoundingMode != "" {
+		policy.RoundingMode = payload.RoundingMode
+	}
+	if payload.RoundingPrecision != nil {
+		policy.RoundingPrecision = *payload.RoundingPrecision
+	}
+	if err := services.ValidatePayrollPolicy(policy); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	policy.CreatedBy = &adminID
+	policy.UpdatedBy = &adminID
+	policy.IPAddress = &ipAddress
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Serialise creations so each gets the next version number
+		if err := tx.Exec("LOCK TABLE payroll_policies IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		var latest int
+		if err := tx.Model(&models.PayrollPolicy{}).Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		policy.Version = latest + 1
+		if err := tx.Omit(clause.Associations).Create(&policy).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not create payroll policy: %v", err))
+		}
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           adminID,
+			UserType:         "admin",
+			Action:           "create_payroll_policy",
+			TargetResource:   "payroll_policy",
+			TargetResourceID: policy.ID,
+			Changes:          policy,
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      adminID,
+		})
+		return nil
+	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while creating the payroll policy."})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": policy})
+}
+
+// ListPayrollPolicies godoc
+// @Summary List Payroll Policy Versions
+// @Description Allows an admin to list the stored payroll policy versions, newest first. Without any, the built-in policy applies:
+// @Description proration by working days and rounding half up to the cent.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=[]models.PayrollPolicy} "Payroll policies"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/payroll-policies [get]
+func ListPayrollPolicies(c *fiber.Ctx) error {
+	var policies []models.PayrollPolicy
+	if err := database.DB.Order("version DESC").Find(&policies).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch payroll policies: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": policies})
+}
+
+// GetEffectivePayrollPolicy godoc
+// @Summary Get Effective Payroll Policy
+// @Description Allows an admin to see the payroll policy for a period starting on a date: the latest version in effect,
+// @Description or the built-in policy (version 0) if none is.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} object{status=string,data=models.PayrollPolicy} "Payroll policy in effect"
+// @Failure 400 {object} object{status=string,message=string} "Invalid date"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/payroll-policies/effective [get]
+func GetEffectivePayrollPolicy(c *fiber.Ctx) error {
+	date := time.Now()
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid date format. Use YYYY-MM-DD."})
+		}
+		date = parsed
+	}
+	policy, err := services.NewPayrollPolicyService(database.DB).PolicyFor(date)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": policy})
+}
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"fmt"
+	"mime"
+	"payslip-generator/pkg/config"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/pdf"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// payslipBranding returns the company identity configured for payslip PDFs
+func payslipBranding() services.PayslipBranding {
+	color, err := pdf.ParseHexColor(config.AppConfig.PayslipBrandColor)
+	if err != nil {
+		color = pdf.Color{R: 31, G: 78, B: 121}
+	}
+	return services.PayslipBranding{
+		CompanyName:    config.AppConfig.CompanyName,
+		CompanyAddress: config.AppConfig.CompanyAddress,
+		BrandColor:     color,
+	}
+}
+
+// sendPayslipPDF renders the payslips, in order, and sends them as one PDF attachment
+func sendPayslipPDF(c *fiber.Ctx, payslips []models.Payslip, fileName string) error {
+	statements := make([]services.PayslipStatement, 0, len(payslips))
+	for _, payslip := range payslips {
+		statement, err := services.LoadPayslipStatement(database.DB, payslip)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+		statements = append(statements, statement)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	return c.Status(fiber.StatusOK).Send(services.RenderPayslipPDF(payslipBranding(), statements))
+}
+
+// GetMyPayslipPDF godoc
+// @Summary Download Employee Payslip PDF
+// @Description Allows an authenticated employee to download their payslip for a period as a PDF with the company header, period, earnings, overtime breakdown, reimbursements, deductions and take-home pay.
+// @Tags Employee
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param period_id query string true "Attendance Period ID (UUID) for the payslip" format(uuid)
+// @Success 200 {file} file "Payslip PDF"
+// @Failure 400 {object} object{status=string,message=string} "Missing or invalid period_id"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 404 {object} object{status=string,message=string} "Payslip not found for this period"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/payslip.pdf [get]
+func GetMyPayslipPDF(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+
+	periodIDStr := c.Query("period_id")
+	if periodIDStr == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "period_id query parameter is required."})
+	}
+	periodID, err := uuid.Parse(periodIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+	}
+
+	var payslip models.Payslip
+	if err := database.DB.Preload("Employee").Preload("AttendancePeriod").
+		Where("employee_id = ? AND attendance_period_id = ? AND voided_at IS NULL", employeeID, periodID).
+		First(&payslip).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Payslip not found for this period."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	fileName := fmt.Sprintf("payslip-%s-%s.pdf", payslip.Employee.Username, payslip.AttendancePeriod.StartDate.Format("2006-01-02"))
+	return sendPayslipPDF(c, []models.Payslip{payslip}, fileName)
+}
+
+// GetPayslipsPDF godoc
+// @Summary Download Payslip PDFs for a Period
+// @Description Allows an admin to download the payslips of an attendance period as one PDF, one payslip per page, ordered by employee username.
+// @Tags Admin
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param period_id query string true "Attendance Period ID (UUID)" format(uuid)
+// @Param employee_id query string false "Only this employee's payslip" format(uuid)
+// @Param include_voided query bool false "Include payslips of voided payroll runs (default false)"
+// @Success 200 {file} file "Payslips PDF"
+// @Failure 400 {object} object{status=string,message=string} "Missing or invalid filter"
+// @Failure 404 {object} object{status=string,message=string} "No payslips found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/payslips.pdf [get]
+func GetPayslipsPDF(c *fiber.Ctx) error {
+	periodIDStr := c.Query("period_id")
+	if periodIDStr == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "period_id query parameter is required."})
+	}
+	periodID, err := uuid.Parse(periodIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid period_id format."})
+	}
+
+	query := database.DB.Preload("Employee").Preload("AttendancePeriod").
+		Joins("JOIN employees ON employees.id = payslips.employee_id").
+		Where("payslips.attendance_period_id = ?", periodID)
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid employee_id format."})
+		}
+		query = query.Where("payslips.employee_id = ?", employeeID)
+	}
+	if !c.QueryBool("include_voided", false) {
+		query = query.Where("payslips.voided_at IS NULL")
+	}
+
+	var payslips []models.Payslip
+	if err := query.Order("employees.username, payslips.created_at").Find(&payslips).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch payslips: %v", err)})
+	}
+	if len(payslips) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "No payslips found for this period."})
+	}
+
+	period := payslips[0].AttendancePeriod
+	fileName := fmt.Sprintf("payslips-%s-to-%s.pdf", period.StartDate.Format("2006-01-02"), period.EndDate.Format("2006-01-02"))
+	return sendPayslipPDF(c, payslips, fileName)
+}
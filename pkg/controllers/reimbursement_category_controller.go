@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReimbursementCategoryPayload struct for creating or replacing a reimbursement category
+type ReimbursementCategoryPayload struct {
+	Name            string       `json:"name" validate:"required"`
+	MaxPerClaim     models.Money `json:"max_per_claim" validate:"gte=0"`                     // 0 means no maximum
+	CapAmount       models.Money `json:"cap_amount" validate:"gte=0"`                        // 0 means no cap
+	CapPeriod       string       `json:"cap_period" example:"year" enums:"none,period,year"` // Defaults to none; required with a cap amount
+	ReceiptRequired bool         `json:"receipt_required"`
+}
+
+func (p ReimbursementCategoryPayload) toModel() models.ReimbursementCategory {
+	capPeriod := p.CapPeriod
+	if capPeriod == "" {
+		capPeriod = models.ReimbursementCapNone
+	}
+	return models.ReimbursementCategory{
+		Name:            strings.TrimSpace(p.Name),
+		MaxPerClaim:     p.MaxPerClaim,
+		CapAmount:       p.CapAmount,
+		CapPeriod:       capPeriod,
+		ReceiptRequired: p.ReceiptRequired,
+	}
+}
+
+// CreateReimbursementCategory godoc
+// @Summary Create Reimbursement Category
+// @Description Allows an admin to define a reimbursement category with a per-claim maximum, a cap per attendance period or calendar year,
+// @Description and whether claims need a receipt. Employees choose a category for every reimbursement request.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category body ReimbursementCategoryPayload true "Reimbursement category"
+// @Success 201 {object} object{status=string,data=models.ReimbursementCategory} "Created category"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 409 {object} object{status=string,message=string} "Name already exists"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursement-categories [post]
+func CreateReimbursementCategory(c *fiber.Ctx) error {
+	var payload ReimbursementCategoryPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	category := payload.toModel()
+	if err := services.ValidateReimbursementCategory(category); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	if fe := checkReimbursementCategoryNameAvailable(category.Name, uuid.Nil); fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	category.CreatedBy = &adminID
+	category.UpdatedBy = &adminID
+	category.IPAddress = &ipAddress
+	if err := database.DB.Create(&category).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not create reimbursement category: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "create_reimbursement_category",
+		TargetResource:   "reimbursement_category",
+		TargetResourceID: category.ID,
+		Changes:          category,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": category})
+}
+
+// ListReimbursementCategories godoc
+// @Summary List Reimbursement Categories
+// @Description Lists all reimbursement categories and their limits, ordered by name. Available to admins and employees.
+// @Tags Admin,Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=[]models.ReimbursementCategory} "Reimbursement categories"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursement-categories [get]
+// @Router /employee/reimbursement-categories [get]
+func ListReimbursementCategories(c *fiber.Ctx) error {
+	var categories []models.ReimbursementCategory
+	if err := database.DB.Order("name").Find(&categories).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch reimbursement categories: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": categories})
+}
+
+// UpdateReimbursementCategory godoc
+// @Summary Update Reimbursement Category
+// @Description Allows an admin to change a reimbursement category. New limits apply to claims submitted from now on; existing requests are unchanged.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Category ID (UUID)" format(uuid)
+// @Param category body ReimbursementCategoryPayload true "Reimbursement category"
+// @Success 200 {object} object{status=string,data=models.ReimbursementCategory} "Updated category"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Category not found"
+// @Failure 409 {object} object{status=string,message=string} "Name already exists"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursement-categories/{id} [put]
+func UpdateReimbursementCategory(c *fiber.Ctx) error {
+	var payload ReimbursementCategoryPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	updated := payload.toModel()
+	if err := services.ValidateReimbursementCategory(updated); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	category, fe := findReimbursementCategoryByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	if fe := checkReimbursementCategoryNameAvailable(updated.Name, category.ID); fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	previous := *category
+	category.Name = updated.Name
+	category.MaxPerClaim = updated.MaxPerClaim
+	category.CapAmount = updated.CapAmount
+	category.CapPeriod = updated.CapPeriod
+	category.ReceiptRequired = updated.ReceiptRequired
+	category.UpdatedBy = &adminID
+	category.IPAddress = &ipAddress
+	if err := database.DB.Save(category).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not update reimbursement category: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "update_reimbursement_category",
+		TargetResource:   "reimbursement_category",
+		TargetResourceID: category.ID,
+		Changes:          map[string]interface{}{"old": previous, "new": category},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": category})
+}
+
+// DeleteReimbursementCategory godoc
+// @Summary Delete Reimbursement Category
+// @Description Allows an admin to delete a reimbursement category that no request uses.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Category ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=models.ReimbursementCategory} "Deleted category"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Category not found"
+// @Failure 409 {object} object{status=string,message=string} "Category has requests"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursement-categories/{id} [delete]
+func DeleteReimbursementCategory(c *fiber.Ctx) error {
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	category, fe := findReimbursementCategoryByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	var requested int64
+	if err := database.DB.Model(&models.ReimbursementRequest{}).Where("category_id = ?", category.ID).Count(&requested).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	if requested > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Reimbursement category has %d request(s) and cannot be deleted.", requested)})
+	}
+	if err := database.DB.Delete(category).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not delete reimbursement category: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "delete_reimbursement_category",
+		TargetResource:   "reimbursement_category",
+		TargetResourceID: category.ID,
+		Changes:          category,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": category})
+}
+
+func findReimbursementCategoryByParam(c *fiber.Ctx) (*models.ReimbursementCategory, *fiber.Error) {
+	categoryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid reimbursement category ID format.")
+	}
+
+	var category models.ReimbursementCategory
+	if err := database.DB.First(&category, "id = ?", categoryID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Reimbursement category not found.")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+	}
+	return &category, nil
+}
+
+// checkReimbursementCategoryNameAvailable returns a 409 error if another category already uses the name
+func checkReimbursementCategoryNameAvailable(name string, excludeID uuid.UUID) *fiber.Error {
+	var existingCount int64
+	if err := database.DB.Model(&models.ReimbursementCategory{}).Where("name = ? AND id <> ?", name, excludeID).Count(&existingCount).Error; err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Database error checking reimbursement category name.")
+	}
+	if existingCount > 0 {
+		return fiber.NewError(fiber.StatusConflict, "A reimbursement category with this name already exists.")
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package controllers
+
+import (
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReimbursementListItem is the list view of a reimbursement request, for admins and the requesting employee
+type ReimbursementListItem struct {
+	ID                 uuid.UUID    `json:"id"`
+	EmployeeID         uuid.UUID    `json:"employee_id"`
+	Username           string       `json:"username"`
+	AttendancePeriodID uuid.UUID    `json:"attendance_period_id"`
+	CategoryID         *uuid.UUID   `json:"category_id,omitempty"`
+	Category           string       `json:"category,omitempty"`
+	Description        string       `json:"description"`
+	Amount             models.Money `json:"amount"`
+	Status             string       `json:"status"`
+	SubmittedAt        time.Time    `json:"submitted_at"`
+	ReviewedAt         *time.Time   `json:"reviewed_at,omitempty"`
+	ReviewReason       *string      `json:"review_reason,omitempty"`
+	ReceiptCount       int          `json:"receipt_count"` // Download them from /admin/reimbursements/{id}/receipts
+}
+
+// ListReimbursements godoc
+// @Summary List Reimbursement Requests
+// @Description Allows an admin to list reimbursement requests, pending ones by default, with optional filters.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected, paid, cancelled); defaults to pending"
+// @Param employee_id query string false "Employee ID (UUID)" format(uuid)
+// @Param period_id query string false "Attendance Period ID (UUID)" format(uuid)
+// @Param category_id query string false "Reimbursement Category ID (UUID)" format(uuid)
+// @Param from query string false "Submitted on or after (YYYY-MM-DD)"
+// @Param to query string false "Submitted on or before (YYYY-MM-DD)"
+// @Param min_amount query number false "Minimum amount"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} object{status=string,data=[]ReimbursementListItem,pagination=utils.Pagination} "Reimbursement requests"
+// @Failure 400 {object} object{status=string,message=string} "Invalid filter"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursements [get]
+func ListReimbursements(c *fiber.Ctx) error {
+	status := c.Query("status", models.ReimbursementPending)
+	switch status {
+	case models.ReimbursementPending, models.ReimbursementApproved, models.ReimbursementRejected, models.ReimbursementPaid, models.ReimbursementCancelled:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid status filter."})
+	}
+
+	query := database.DB.Model(&models.ReimbursementRequest{}).Preload("Employee").Preload("Category").Preload("Receipts").Where("status = ?", status)
+
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid employee_id format."})
+		}
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	query, fe := filterReimbursements(c, query)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	return listReimbursements(c, query, "created_at")
+}
+
+// filterReimbursements applies the period, category, submission date and amount filters shared by the reimbursement lists
+func filterReimbursements(c *fiber.Ctx, query *gorm.DB) (*gorm.DB, *fiber.Error) {
+	if periodIDStr := c.Query("period_id"); periodIDStr != "" {
+		periodID, err := uuid.Parse(periodIDStr)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid period_id format.")
+		}
+		query = query.Where("attendance_period_id = ?", periodID)
+	}
+	if categoryIDStr := c.Query("category_id"); categoryIDStr != "" {
+		categoryID, err := uuid.Parse(categoryIDStr)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid category_id format.")
+		}
+		query = query.Where("category_id = ?", categoryID)
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid from date format. Use YYYY-MM-DD.")
+		}
+		query = query.Where("created_at >= ?", from)
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid to date format. Use YYYY-MM-DD.")
+		}
+		query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+	if minAmountStr := c.Query("min_amount"); minAmountStr != "" {
+		minAmount, err := models.ParseMoney(minAmountStr)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid min_amount.")
+		}
+		query = query.Where("amount >= ?", minAmount)
+	}
+	return query, nil
+}
+
+func newReimbursementListItem(rr models.ReimbursementRequest) ReimbursementListItem {
+	category := ""
+	if rr.Category != nil {
+		category = rr.Category.Name
+	}
+	return ReimbursementListItem{
+		ID:                 rr.ID,
+		EmployeeID:         rr.EmployeeID,
+		Username:           rr.Employee.Username,
+		AttendancePeriodID: rr.AttendancePeriodID,
+		CategoryID:         rr.CategoryID,
+		Category:           category,
+		Description:        rr.Description,
+		Amount:             rr.Amount,
+		Status:             rr.Status,
+		SubmittedAt:        rr.CreatedAt,
+		ReviewedAt:         rr.ReviewedAt,
+		ReviewReason:       rr.ReviewReason,
+		ReceiptCount:       len(rr.Receipts),
+	}
+}
+
+// listReimbursements paginates a reimbursement request query in the given order and writes the list response
+func listReimbursements(c *fiber.Ctx, query *gorm.DB, order string) error {
+	pagination := utils.GetPagination(c)
+	pageQuery, err := pagination.Paginate(query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to count reimbursements: %v", err)})
+	}
+
+	var requests []models.ReimbursementRequest
+	if err := pageQuery.Order(order).Find(&requests).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch reimbursements: %v", err)})
+	}
+
+	items := make([]ReimbursementListItem, 0, len(requests))
+	for _, rr := range requests {
+		items = append(items, newReimbursementListItem(rr))
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items, "pagination": pagination})
+}
+
+// ReviewReimbursementPayload struct for approving or rejecting a reimbursement request
+type ReviewReimbursementPayload struct {
+	Reason string `json:"reason"` // Required when rejecting
+}
+
+// ApproveReimbursement godoc
+// @Summary Approve Reimbursement Request
+// @Description Allows an admin to approve a pending reimbursement request so it is paid in the next payroll run.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Param review body ReviewReimbursementPayload false "Optional approval note"
+// @Success 200 {object} object{status=string,data=models.ReimbursementRequest} "Approved reimbursement request"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Reimbursement request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursements/{id}/approve [post]
+func ApproveReimbursement(c *fiber.Ctx) error {
+	return reviewReimbursement(c, models.ReimbursementApproved)
+}
+
+// RejectReimbursement godoc
+// @Summary Reject Reimbursement Request
+// @Description Allows an admin to reject a pending reimbursement request with a reason.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Param review body ReviewReimbursementPayload true "Rejection reason"
+// @Success 200 {object} object{status=string,data=models.ReimbursementRequest} "Rejected reimbursement request"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID or missing reason"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Reimbursement request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursements/{id}/reject [post]
+func RejectReimbursement(c *fiber.Ctx) error {
+	return reviewReimbursement(c, models.ReimbursementRejected)
+}
+
+// reviewReimbursement moves a pending reimbursement request to the given status and audits the decision
+func reviewReimbursement(c *fiber.Ctx, newStatus string) error {
+	reimbursementID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid reimbursement request ID format."})
+	}
+
+	var payload ReviewReimbursementPayload
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+	}
+	if newStatus == models.ReimbursementRejected && payload.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Reason is required when rejecting a reimbursement request."})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var reimbursementRequest models.ReimbursementRequest
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the row so two admins cannot decide the same request at once
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reimbursementRequest, "id = ?", reimbursementID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusNotFound, "Reimbursement request not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+
+		if reimbursementRequest.Status != models.ReimbursementPending {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Reimbursement request is already %s.", reimbursementRequest.Status))
+		}
+
+		previousStatus := reimbursementRequest.Status
+		now := time.Now()
+		updates := map[string]interface{}{
+			"status":      newStatus,
+			"reviewed_at": now,
+			"updated_by":  adminID,
+			"ip_address":  ipAddress,
+		}
+		if payload.Reason != "" {
+			updates["review_reason"] = payload.Reason
+		}
+		if err := tx.Model(&reimbursementRequest).Updates(updates).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not update reimbursement request: %v", err))
+		}
+		reimbursementRequest.Status = newStatus
+		reimbursementRequest.ReviewedAt = &now
+		reimbursementRequest.UpdatedBy = &adminID
+		reimbursementRequest.IPAddress = &ipAddress
+		if payload.Reason != "" {
+			reimbursementRequest.ReviewReason = &payload.Reason
+		}
+
+		action := "approve_reimbursement"
+		if newStatus == models.ReimbursementRejected {
+			action = "reject_reimbursement"
+		}
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           adminID,
+			UserType:         "admin",
+			Action:           action,
+			TargetResource:   "reimbursement_request",
+			TargetResourceID: reimbursementRequest.ID,
+			Changes: map[string]interface{}{
+				"employee_id":     reimbursementRequest.EmployeeID,
+				"previous_status": previousStatus,
+				"new_status":      newStatus,
+				"reason":          payload.Reason,
+				"amount":          reimbursementRequest.Amount,
+			},
+			IPAddress:   ipAddress,
+			RequestID:   requestID,
+			PerformedBy: adminID,
+		})
+		return nil
+	})
+
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while reviewing the reimbursement request."})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": reimbursementRequest})
+}
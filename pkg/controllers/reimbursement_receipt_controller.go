@@ -0,0 +1,314 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/storage"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReceiptListItem is the view of a receipt attached to a reimbursement request
+type ReceiptListItem struct {
+	ID          uuid.UUID `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"` // Hex SHA-256 of the file
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+func newReceiptListItems(receipts []models.ReimbursementReceipt) []ReceiptListItem {
+	items := make([]ReceiptListItem, 0, len(receipts))
+	for _, r := range receipts {
+		items = append(items, ReceiptListItem{ID: r.ID, FileName: r.FileName, ContentType: r.ContentType, Size: r.Size, Checksum: r.Checksum, UploadedAt: r.CreatedAt})
+	}
+	return items
+}
+
+// receiptFilesFromForm reads the files of the "receipts" field of a multipart form. Non-multipart requests have none.
+func receiptFilesFromForm(c *fiber.Ctx) ([]services.ReceiptFile, error) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return nil, nil
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, fmt.Errorf("could not read multipart form: %w", err)
+	}
+	headers := form.File["receipts"]
+	if len(headers) > services.MaxReceiptsPerRequest {
+		return nil, fmt.Errorf("at most %d receipts can be uploaded", services.MaxReceiptsPerRequest)
+	}
+	files := make([]services.ReceiptFile, 0, len(headers))
+	for _, header := range headers {
+		if header.Size > services.MaxReceiptFileSize {
+			return nil, fmt.Errorf("%s is larger than %d MB", header.Filename, services.MaxReceiptFileSize/(1024*1024))
+		}
+		f, err := header.Open()
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s: %w", header.Filename, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(f, services.MaxReceiptFileSize+1))
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", header.Filename, err)
+		}
+		files = append(files, services.ReceiptFile{FileName: header.Filename, Data: data})
+	}
+	return files, nil
+}
+
+// receiptAuditChanges summarises attached receipts for the audit log
+func receiptAuditChanges(receipts []models.ReimbursementReceipt) []map[string]interface{} {
+	changes := make([]map[string]interface{}, 0, len(receipts))
+	for _, r := range receipts {
+		changes = append(changes, map[string]interface{}{
+			"receipt_id":   r.ID,
+			"file_name":    r.FileName,
+			"content_type": r.ContentType,
+			"size":         r.Size,
+			"checksum":     r.Checksum,
+			"backend":      r.Backend,
+		})
+	}
+	return changes
+}
+
+// UploadReimbursementReceipts godoc
+// @Summary Upload Reimbursement Receipts
+// @Description Allows an authenticated employee to attach receipt files to their own pending reimbursement request.
+// @Description Receipts must be JPEG, PNG or WebP images or PDFs of at most 5 MB each, and a request can have at most 5.
+// @Description The type is detected from the file content and a SHA-256 checksum is recorded for each file.
+// @Tags Employee
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Param receipts formData file true "Receipt files; repeat the field for several"
+// @Success 201 {object} object{status=string,data=[]ReceiptListItem} "Attached receipts"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID or receipt"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 404 {object} object{status=string,message=string} "Reimbursement request not found"
+// @Failure 409 {object} object{status=string,message=string} "Request is not pending"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/reimbursements/{id}/receipts [post]
+func UploadReimbursementReceipts(c *fiber.Ctx) error {
+	reimbursementID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid reimbursement request ID format."})
+	}
+	files, err := receiptFilesFromForm(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	if len(files) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "At least one file is required in the 'receipts' field."})
+	}
+
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	var receipts []models.ReimbursementReceipt
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the request so it cannot be reviewed while receipts are being attached
+		var reimbursementRequest models.ReimbursementRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reimbursementRequest, "id = ? AND employee_id = ?", reimbursementID, employeeID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusNotFound, "Reimbursement request not found.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		if reimbursementRequest.Status != models.ReimbursementPending {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Receipts can only be added to pending requests; this one is %s.", reimbursementRequest.Status))
+		}
+
+		attached, err := services.NewReceiptService(tx, storage.Receipts).Attach(c.Context(), reimbursementRequest, files, employeeID, ipAddress)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidReceipt) {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		receipts = attached
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employeeID,
+			UserType:         "employee",
+			Action:           "upload_reimbursement_receipt",
+			TargetResource:   "reimbursement_request",
+			TargetResourceID: reimbursementRequest.ID,
+			Changes:          map[string]interface{}{"receipts": receiptAuditChanges(receipts)},
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      employeeID,
+		})
+		return nil
+	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while uploading receipts."})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": newReceiptListItems(receipts)})
+}
+
+// ListMyReimbursementReceipts godoc
+// @Summary List Reimbursement Receipts
+// @Description Allows an authenticated employee to list the receipts attached to one of their reimbursement requests.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=[]ReceiptListItem} "Receipts"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 404 {object} object{status=string,message=string} "Reimbursement request not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/reimbursements/{id}/receipts [get]
+func ListMyReimbursementReceipts(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	return listReimbursementReceipts(c, &employeeID)
+}
+
+// DownloadMyReimbursementReceipt godoc
+// @Summary Download Reimbursement Receipt
+// @Description Allows an authenticated employee to download a receipt attached to one of their reimbursement requests.
+// @Description The file is checked against its upload checksum, which is returned in the X-Checksum-SHA256 header.
+// @Tags Employee
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Param receiptId path string true "Receipt ID (UUID)" format(uuid)
+// @Success 200 {file} file "Receipt file"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 404 {object} object{status=string,message=string} "Receipt not found"
+// @Failure 500 {object} object{status=string,message=string} "Receipt could not be read or failed its checksum"
+// @Router /employee/reimbursements/{id}/receipts/{receiptId} [get]
+func DownloadMyReimbursementReceipt(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	return downloadReimbursementReceipt(c, &employeeID)
+}
+
+// ListReimbursementReceipts godoc
+// @Summary List Reimbursement Receipts (Admin)
+// @Description Allows an admin to list the receipts attached to any reimbursement request.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=[]ReceiptListItem} "Receipts"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID"
+// @Failure 404 {object} object{status=string,message=string} "Reimbursement request not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/reimbursements/{id}/receipts [get]
+func ListReimbursementReceipts(c *fiber.Ctx) error {
+	return listReimbursementReceipts(c, nil)
+}
+
+// DownloadReimbursementReceipt godoc
+// @Summary Download Reimbursement Receipt (Admin)
+// @Description Allows an admin to download a receipt attached to any reimbursement request.
+// @Description The file is checked against its upload checksum, which is returned in the X-Checksum-SHA256 header.
+// @Tags Admin
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path string true "Reimbursement Request ID (UUID)" format(uuid)
+// @Param receiptId path string true "Receipt ID (UUID)" format(uuid)
+// @Success 200 {file} file "Receipt file"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID"
+// @Failure 404 {object} object{status=string,message=string} "Receipt not found"
+// @Failure 500 {object} object{status=string,message=string} "Receipt could not be read or failed its checksum"
+// @Router /admin/reimbursements/{id}/receipts/{receiptId} [get]
+func DownloadReimbursementReceipt(c *fiber.Ctx) error {
+	return downloadReimbursementReceipt(c, nil)
+}
+
+// findReimbursementRequest loads a reimbursement request, limited to the employee's own when employeeID is set
+func findReimbursementRequest(c *fiber.Ctx, employeeID *uuid.UUID) (*models.ReimbursementRequest, *fiber.Error) {
+	reimbursementID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid reimbursement request ID format.")
+	}
+	query := database.DB.Where("id = ?", reimbursementID)
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	var reimbursementRequest models.ReimbursementRequest
+	if err := query.First(&reimbursementRequest).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Reimbursement request not found.")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+	}
+	return &reimbursementRequest, nil
+}
+
+// listReimbursementReceipts lists a request's receipts, limited to the employee's own requests when employeeID is set
+func listReimbursementReceipts(c *fiber.Ctx, employeeID *uuid.UUID) error {
+	reimbursementRequest, fe := findReimbursementRequest(c, employeeID)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	var receipts []models.ReimbursementReceipt
+	if err := database.DB.Where("reimbursement_request_id = ?", reimbursementRequest.ID).Order("created_at, id").Find(&receipts).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch receipts: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newReceiptListItems(receipts)})
+}
+
+// downloadReimbursementReceipt sends a receipt file, limited to the employee's own requests when employeeID is set
+func downloadReimbursementReceipt(c *fiber.Ctx, employeeID *uuid.UUID) error {
+	reimbursementRequest, fe := findReimbursementRequest(c, employeeID)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	receiptID, err := uuid.Parse(c.Params("receiptId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid receipt ID format."})
+	}
+	var receipt models.ReimbursementReceipt
+	if err := database.DB.First(&receipt, "id = ? AND reimbursement_request_id = ?", receiptID, reimbursementRequest.ID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "Receipt not found."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+
+	data, err := services.NewReceiptService(database.DB, storage.Receipts).Read(c.Context(), receipt)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not read receipt: %v", err)})
+	}
+
+	c.Set(fiber.HeaderContentType, receipt.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": receipt.FileName}))
+	c.Set("X-Checksum-SHA256", receipt.Checksum)
+	return c.Status(fiber.StatusOK).Send(data)
+}
@@ -0,0 +1,13 @@
+package controllers
+
+import "github.com/gofiber/fiber/v2"
+
+// respondWithError writes a fiber.Error as the standard JSON error body:
+// "fail" for client errors and "error" for server errors.
+func respondWithError(c *fiber.Ctx, fe *fiber.Error) error {
+	status := "fail"
+	if fe.Code >= fiber.StatusInternalServerError {
+		status = "error"
+	}
+	return c.Status(fe.Code).JSON(fiber.Map{"status": status, "message": fe.Message})
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SalaryHistoryResponse is one entry of an employee's salary history
+type SalaryHistoryResponse struct {
+	ID            uuid.UUID    `json:"id"`
+	Salary        models.Money `json:"salary"`
+	EffectiveFrom string       `json:"effective_from"` // YYYY-MM-DD
+	Reason        *string      `json:"reason,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	CreatedBy     *uuid.UUID   `json:"created_by,omitempty"`
+}
+
+func newSalaryHistoryResponse(h models.SalaryHistory) SalaryHistoryResponse {
+	return SalaryHistoryResponse{
+		ID:            h.ID,
+		Salary:        h.Salary,
+		EffectiveFrom: h.EffectiveFrom.Format("2006-01-02"),
+		Reason:        h.Reason,
+		CreatedAt:     h.CreatedAt,
+		CreatedBy:     h.CreatedBy,
+	}
+}
+
+// SalaryChangePayload struct for recording a salary change
+type SalaryChangePayload struct {
+	Salary        models.Money `json:"salary" validate:"required,gt=0"`
+	EffectiveFrom string       `json:"effective_from" validate:"required" example:"2025-01-15"` // YYYY-MM-DD
+	Reason        string       `json:"reason" example:"Annual review"`
+}
+
+// ListSalaryHistory godoc
+// @Summary List Employee Salary History
+// @Description Allows an admin to list an employee's salaries by effective date, newest first. Payroll pays each part of a period at the salary in force.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=[]SalaryHistoryResponse} "Salary history"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id}/salary-history [get]
+func ListSalaryHistory(c *fiber.Ctx) error {
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	var history []models.SalaryHistory
+	if err := database.DB.Where("employee_id = ?", employee.ID).Order("effective_from DESC").Find(&history).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch salary history: %v", err)})
+	}
+	items := make([]SalaryHistoryResponse, 0, len(history))
+	for _, h := range history {
+		items = append(items, newSalaryHistoryResponse(h))
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items})
+}
+
+// CreateSalaryChange godoc
+// @Summary Record Employee Salary Change
+// @Description Allows an admin to give an employee a new salary from a date, which may be in the past or the future. An entry already effective
+// @Description on that date is replaced. The change cannot take effect in a period the employee already has a payslip for, unless that payroll run is voided.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Param change body SalaryChangePayload true "Salary change"
+// @Success 201 {object} object{status=string,data=SalaryHistoryResponse} "Recorded salary change"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 409 {object} object{status=string,message=string} "The change falls in a paid period"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id}/salary-history [post]
+func CreateSalaryChange(c *fiber.Ctx) error {
+	var payload SalaryChangePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	effectiveFrom, err := time.Parse("2006-01-02", payload.EffectiveFrom)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": "Invalid effective_from format. Use YYYY-MM-DD."})
+	}
+	if err := services.ValidateSalary(payload.Salary); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	oldSalary := employee.Salary
+	var entry *models.SalaryHistory
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		entry, err = services.NewSalaryHistoryService(tx).RecordSalaryChange(employee, services.SalaryChange{
+			Salary:        payload.Salary,
+			EffectiveFrom: effectiveFrom,
+			Reason:        payload.Reason,
+			PerformedBy:   &adminID,
+			IPAddress:     ipAddress,
+		})
+		if err != nil {
+			return salaryChangeError(err)
+		}
+
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employee.ID,
+			UserType:         "employee",
+			Action:           "update_employee_salary",
+			TargetResource:   "employee",
+			TargetResourceID: employee.ID,
+			Changes:          map[string]interface{}{"salary": fiber.Map{"old": oldSalary, "new": payload.Salary, "effective_from": entry.EffectiveFrom.Format("2006-01-02")}},
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      adminID,
+		})
+		return nil
+	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while recording the salary change."})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": newSalaryHistoryResponse(*entry)})
+}
+
+// salaryChangeError maps an error from recording a salary change to a response error
+func salaryChangeError(err error) *fiber.Error {
+	if errors.Is(err, services.ErrSalaryPeriodPaid) {
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+}
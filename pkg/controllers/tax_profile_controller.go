@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaxProfileResponse is an employee's tax profile with the PTKP status it gives under today's tax table
+type TaxProfileResponse struct {
+	EmployeeID    uuid.UUID `json:"employee_id"`
+	TaxID         *string   `json:"tax_id,omitempty"`
+	MaritalStatus string    `json:"marital_status"`
+	Dependants    int       `json:"dependants"`
+	PTKPStatus    string    `json:"ptkp_status" example:"K/1"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func newTaxProfileResponse(profile models.TaxProfile) TaxProfileResponse {
+	maxDependants := profile.Dependants
+	if services.IncomeTaxTables != nil {
+		if table, err := services.IncomeTaxTables.TableFor(time.Now()); err == nil {
+			maxDependants = table.PTKP.MaxDependants
+		}
+	}
+	return TaxProfileResponse{
+		EmployeeID:    profile.EmployeeID,
+		TaxID:         profile.TaxID,
+		MaritalStatus: profile.MaritalStatus,
+		Dependants:    profile.Dependants,
+		PTKPStatus:    profile.PTKPStatus(maxDependants),
+		UpdatedAt:     profile.UpdatedAt,
+	}
+}
+
+// TaxProfilePayload struct for setting an employee's tax profile
+type TaxProfilePayload struct {
+	TaxID         string `json:"tax_id" example:"09.254.294.3-407.000"`                     // NPWP or NIK; leave empty if the employee has none
+	MaritalStatus string `json:"marital_status" validate:"required" enums:"single,married"` // For PTKP
+	Dependants    int    `json:"dependants" example:"1"`                                    // For PTKP
+}
+
+// GetTaxProfile godoc
+// @Summary Get Employee Tax Profile
+// @Description Allows an admin to see the tax ID and PTKP status income tax (PPh 21) is withheld by for an employee.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=TaxProfileResponse} "Tax profile"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 404 {object} object{status=string,message=string} "Employee or tax profile not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id}/tax-profile [get]
+func GetTaxProfile(c *fiber.Ctx) error {
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	return respondWithTaxProfile(c, employee.ID)
+}
+
+// GetMyTaxProfile godoc
+// @Summary Get My Tax Profile
+// @Description Allows an authenticated employee to see the tax ID and PTKP status their income tax is withheld by.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=TaxProfileResponse} "Tax profile"
+// @Failure 401 {object} object{status=string,message=string} "User not authenticated"
+// @Failure 404 {object} object{status=string,message=string} "No tax profile yet"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /employee/tax-profile [get]
+func GetMyTaxProfile(c *fiber.Ctx) error {
+	employeeID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "User not authenticated."})
+	}
+	return respondWithTaxProfile(c, employeeID)
+}
+
+// respondWithTaxProfile sends an employee's tax profile
+func respondWithTaxProfile(c *fiber.Ctx, employeeID uuid.UUID) error {
+	var profile models.TaxProfile
+	if err := database.DB.Where("employee_id = ?", employeeID).First(&profile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": "No tax profile has been set up; income tax is withheld as TK/0 without a tax ID."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newTaxProfileResponse(profile)})
+}
+
+// UpdateTaxProfile godoc
+// @Summary Set Employee Tax Profile
+// @Description Allows an admin to set an employee's tax ID, marital status and dependants, which decide their PTKP and the income tax (PPh 21)
+// @Description withheld from later payslips. Employees without a profile are withheld as single without dependants or a tax ID.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Employee ID (UUID)" format(uuid)
+// @Param profile body TaxProfilePayload true "Tax profile"
+// @Success 200 {object} object{status=string,data=TaxProfileResponse} "Saved tax profile"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Employee not found"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/employees/{id}/tax-profile [put]
+func UpdateTaxProfile(c *fiber.Ctx) error {
+	var payload TaxProfilePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	var taxID *string
+	if strings.TrimSpace(payload.TaxID) != "" {
+		normalized, err := services.NormalizeTaxID(payload.TaxID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+		}
+		taxID = &normalized
+	}
+	maritalStatus := strings.ToLower(strings.TrimSpace(payload.MaritalStatus))
+	if err := services.ValidateTaxProfile(models.TaxProfile{MaritalStatus: maritalStatus, Dependants: payload.Dependants}); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	employee, fe := findEmployeeByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	var profile models.TaxProfile
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("employee_id = ?", employee.ID).First(&profile).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+		}
+		old := profile
+		if profile.ID == uuid.Nil {
+			profile.EmployeeID = employee.ID
+			profile.CreatedBy = &adminID
+		}
+		profile.TaxID = taxID
+		profile.MaritalStatus = maritalStatus
+		profile.Dependants = payload.Dependants
+		profile.UpdatedBy = &adminID
+		profile.IPAddress = &ipAddress
+		if err := tx.Omit(clause.Associations).Save(&profile).Error; err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Could not save tax profile: %v", err))
+		}
+
+		changes := map[string]interface{}{"new": profile}
+		if old.ID != uuid.Nil {
+			changes["old"] = old
+		}
+		services.NewAuditService(tx).CreateAuditLog(services.AuditLogEntryParams{
+			UserID:           employee.ID,
+			UserType:         "employee",
+			Action:           "update_tax_profile",
+			TargetResource:   "tax_profile",
+			TargetResourceID: profile.ID,
+			Changes:          changes,
+			IPAddress:        ipAddress,
+			RequestID:        requestID,
+			PerformedBy:      adminID,
+		})
+		return nil
+	})
+	if err != nil {
+		if fe, ok := err.(*fiber.Error); ok {
+			return respondWithError(c, fe)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "An internal error occurred while saving the tax profile."})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": newTaxProfileResponse(profile)})
+}
+
+// ListTaxTables godoc
+// @Summary List Income Tax Tables
+// @Description Allows an admin to see the income tax (PPh 21) tables loaded from TAX_TABLES_FILE, oldest first. A payslip is taxed by the latest table
+// @Description in effect on the last day of its period. Tables are data: add a version to the file and restart to apply a new regulation.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=[]services.TaxTable} "Tax tables"
+// @Router /admin/tax-tables [get]
+func ListTaxTables(c *fiber.Ctx) error {
+	tables := []services.TaxTable{}
+	if services.IncomeTaxTables != nil {
+		tables = services.IncomeTaxTables.Tables
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": tables})
+}
@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/constants"
+	"payslip-generator/pkg/database"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkSchedulePayload struct for creating or replacing a work schedule
+type WorkSchedulePayload struct {
+	Name        string         `json:"name" validate:"required"`
+	WorkingDays utils.WorkWeek `json:"working_days" validate:"required" swaggertype:"array,string" example:"tuesday,wednesday,thursday,friday,saturday"`
+	HoursPerDay float64        `json:"hours_per_day" validate:"required,gt=0"`
+}
+
+func (p WorkSchedulePayload) toModel() models.WorkSchedule {
+	return models.WorkSchedule{Name: strings.TrimSpace(p.Name), WorkingDays: p.WorkingDays, HoursPerDay: p.HoursPerDay}
+}
+
+// CreateWorkSchedule godoc
+// @Summary Create Work Schedule
+// @Description Allows an admin to define a named work schedule with its working weekdays and daily hours.
+// @Description Employees without a schedule work the standard Monday to Friday, 8 hours a day.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule body WorkSchedulePayload true "Work schedule"
+// @Success 201 {object} object{status=string,data=models.WorkSchedule} "Created work schedule"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 409 {object} object{status=string,message=string} "Name already exists"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/work-schedules [post]
+func CreateWorkSchedule(c *fiber.Ctx) error {
+	var payload WorkSchedulePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	schedule := payload.toModel()
+	if err := services.ValidateWorkSchedule(schedule); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	if fe := checkWorkScheduleNameAvailable(schedule.Name, uuid.Nil); fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	schedule.CreatedBy = &adminID
+	schedule.UpdatedBy = &adminID
+	schedule.IPAddress = &ipAddress
+	if err := database.DB.Create(&schedule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not create work schedule: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "create_work_schedule",
+		TargetResource:   "work_schedule",
+		TargetResourceID: schedule.ID,
+		Changes:          schedule,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": schedule})
+}
+
+// ListWorkSchedules godoc
+// @Summary List Work Schedules
+// @Description Allows an admin to list all work schedules, ordered by name.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,data=[]models.WorkSchedule} "Work schedules"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/work-schedules [get]
+func ListWorkSchedules(c *fiber.Ctx) error {
+	var schedules []models.WorkSchedule
+	if err := database.DB.Order("name").Find(&schedules).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Failed to fetch work schedules: %v", err)})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": schedules})
+}
+
+// UpdateWorkSchedule godoc
+// @Summary Update Work Schedule
+// @Description Allows an admin to change a work schedule. Payroll runs after the change use the new working days and hours; existing payslips are unchanged.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Work Schedule ID (UUID)" format(uuid)
+// @Param schedule body WorkSchedulePayload true "Work schedule"
+// @Success 200 {object} object{status=string,data=models.WorkSchedule} "Updated work schedule"
+// @Failure 400 {object} object{status=string,message=string} "Validation error"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Work schedule not found"
+// @Failure 409 {object} object{status=string,message=string} "Name already exists"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/work-schedules/{id} [put]
+func UpdateWorkSchedule(c *fiber.Ctx) error {
+	var payload WorkSchedulePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	updated := payload.toModel()
+	if err := services.ValidateWorkSchedule(updated); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	schedule, fe := findWorkScheduleByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+	if fe := checkWorkScheduleNameAvailable(updated.Name, schedule.ID); fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	previous := *schedule
+	schedule.Name = updated.Name
+	schedule.WorkingDays = updated.WorkingDays
+	schedule.HoursPerDay = updated.HoursPerDay
+	schedule.UpdatedBy = &adminID
+	schedule.IPAddress = &ipAddress
+	if err := database.DB.Save(schedule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not update work schedule: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "update_work_schedule",
+		TargetResource:   "work_schedule",
+		TargetResourceID: schedule.ID,
+		Changes:          map[string]interface{}{"old": previous, "new": schedule},
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": schedule})
+}
+
+// DeleteWorkSchedule godoc
+// @Summary Delete Work Schedule
+// @Description Allows an admin to delete a work schedule that is not assigned to any employee.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Work Schedule ID (UUID)" format(uuid)
+// @Success 200 {object} object{status=string,data=models.WorkSchedule} "Deleted work schedule"
+// @Failure 400 {object} object{status=string,message=string} "Invalid ID format"
+// @Failure 401 {object} object{status=string,message=string} "Admin ID not found in token or invalid"
+// @Failure 404 {object} object{status=string,message=string} "Work schedule not found"
+// @Failure 409 {object} object{status=string,message=string} "Work schedule is assigned to employees"
+// @Failure 500 {object} object{status=string,message=string} "Internal server error"
+// @Router /admin/work-schedules/{id} [delete]
+func DeleteWorkSchedule(c *fiber.Ctx) error {
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Admin ID not found in token or invalid."})
+	}
+	ipAddress := c.IP()
+	requestIDVal := c.Locals(constants.RequestIDKey.String())
+	requestID, _ := requestIDVal.(string)
+
+	schedule, fe := findWorkScheduleByParam(c)
+	if fe != nil {
+		return respondWithError(c, fe)
+	}
+
+	var assigned int64
+	if err := database.DB.Model(&models.Employee{}).Where("work_schedule_id = ?", schedule.ID).Count(&assigned).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Database error: %v", err)})
+	}
+	if assigned > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": fmt.Sprintf("Work schedule is assigned to %d employee(s). Reassign them first.", assigned)})
+	}
+	if err := database.DB.Delete(schedule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": fmt.Sprintf("Could not delete work schedule: %v", err)})
+	}
+
+	auditService := services.NewAuditService(database.DB)
+	auditService.CreateAuditLog(services.AuditLogEntryParams{
+		UserID:           adminID,
+		UserType:         "admin",
+		Action:           "delete_work_schedule",
+		TargetResource:   "work_schedule",
+		TargetResourceID: schedule.ID,
+		Changes:          schedule,
+		IPAddress:        ipAddress,
+		RequestID:        requestID,
+		PerformedBy:      adminID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": schedule})
+}
+
+// findWorkScheduleByParam loads the work schedule named by the :id route parameter
+func findWorkScheduleByParam(c *fiber.Ctx) (*models.WorkSchedule, *fiber.Error) {
+	scheduleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid work schedule ID format.")
+	}
+
+	var schedule models.WorkSchedule
+	if err := database.DB.First(&schedule, "id = ?", scheduleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Work schedule not found.")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+	}
+	return &schedule, nil
+}
+
+// checkWorkScheduleNameAvailable returns a 409 error if another schedule already uses the name
+func checkWorkScheduleNameAvailable(name string, excludeID uuid.UUID) *fiber.Error {
+	var existingCount int64
+	if err := database.DB.Model(&models.WorkSchedule{}).Where("name = ? AND id <> ?", name, excludeID).Count(&existingCount).Error; err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Database error checking work schedule name.")
+	}
+	if existingCount > 0 {
+		return fiber.NewError(fiber.StatusConflict, "A work schedule with this name already exists.")
+	}
+	return nil
+}
+
+// parseWorkScheduleID validates an optional work schedule ID from an employee payload.
+// An empty string means the standard schedule and returns nil.
+func parseWorkScheduleID(value string) (*uuid.UUID, *fiber.Error) {
+	if value == "" {
+		return nil, nil
+	}
+	scheduleID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid work_schedule_id format.")
+	}
+	if _, err := services.FindWorkSchedule(database.DB, scheduleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Work schedule not found.")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Database error: %v", err))
+	}
+	return &scheduleID, nil
+}
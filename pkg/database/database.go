@@ -3,7 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
-	// "os" // No longer needed for direct os.Getenv here
+	"os"
 	"payslip-generator/pkg/config" // Added
 	"payslip-generator/pkg/models"
 
@@ -71,21 +71,62 @@ func InitTestDB() *gorm.DB {
 }
 
 func migrateDB(db *gorm.DB) {
+	// Overtime recorded before the approval workflow existed was paid without review; keep it approved
+	backfillOvertimeStatus := db.Migrator().HasTable(&models.OvertimeRecord{}) && !db.Migrator().HasColumn(&models.OvertimeRecord{}, "Status")
+
+	// Overtime was recorded in whole hours before it was recorded to the minute
+	if db.Migrator().HasTable(&models.OvertimeRecord{}) && db.Migrator().HasColumn(&models.OvertimeRecord{}, "hours") {
+		migrateOvertimeHoursToMinutes(db)
+	}
+
+	// Money columns must be exact decimals before Money values are read from them
+	migrateMoneyColumns(db)
+
 	// Auto-migrate models
 	err := db.AutoMigrate(
+		&models.WorkSchedule{},
 		&models.Employee{},
 		&models.Admin{},
 		&models.AttendancePeriod{},
 		&models.AttendanceRecord{},
 		&models.OvertimeRecord{},
+		&models.ReimbursementCategory{},
 		&models.ReimbursementRequest{},
+		&models.ReimbursementReceipt{},
 		&models.Payslip{},
+		&models.PayslipLineItem{},
 		&models.AuditLog{},
+		&models.PayrollRun{},
+		&models.Holiday{},
+		&models.LeaveType{},
+		&models.LeaveRequest{},
+		&models.AttendanceCorrection{},
+		&models.DevicePIN{},
+		&models.OvertimePolicy{},
+		&models.PayrollPolicy{},
+		&models.SalaryHistory{},
+		&models.TaxProfile{},
+		&models.PayslipContribution{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
+	if backfillOvertimeStatus {
+		err = db.Model(&models.OvertimeRecord{}).Where("1 = 1").Update("status", models.OvertimeApproved).Error
+		if err != nil {
+			log.Printf("Warning: Failed to backfill overtime status: %v", err)
+		}
+	}
+
+	// Employees from before the salary history get an opening entry with their current salary, effective from when they were created
+	err = db.Exec(`INSERT INTO salary_history (employee_id, salary, effective_from, reason, created_at, updated_at)
+		SELECT e.id, e.salary, e.created_at::date, 'Starting salary', now(), now() FROM employees e
+		WHERE NOT EXISTS (SELECT 1 FROM salary_history h WHERE h.employee_id = e.id)`).Error
+	if err != nil {
+		log.Printf("Warning: Failed to backfill salary history: %v", err)
+	}
+
 	// Add unique constraint for AttendanceRecord (EmployeeID, Date)
 	if !db.Migrator().HasConstraint(&models.AttendanceRecord{}, "uix_employee_date") {
 		err = db.Migrator().CreateConstraint(&models.AttendanceRecord{}, "uix_employee_date")
@@ -94,22 +135,95 @@ func migrateDB(db *gorm.DB) {
 		}
 	}
 
-	// Add unique constraint for Payslip (EmployeeID, AttendancePeriodID)
-	if !db.Migrator().HasConstraint(&models.Payslip{}, "uix_employee_period") {
-		err = db.Migrator().CreateConstraint(&models.Payslip{}, "uix_employee_period")
+	// Payslip (EmployeeID, AttendancePeriodID) is unique among non-voided payslips only,
+	// so a period can be re-run after its payroll was voided.
+	if db.Migrator().HasConstraint(&models.Payslip{}, "uix_employee_period") {
+		err = db.Migrator().DropConstraint(&models.Payslip{}, "uix_employee_period")
 		if err != nil {
-			log.Printf("Warning: Failed to create constraint uix_employee_period: %v", err)
+			log.Printf("Warning: Failed to drop constraint uix_employee_period: %v", err)
 		}
 	}
+	err = db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS uix_employee_period_active ON payslips (employee_id, attendance_period_id) WHERE voided_at IS NULL").Error
+	if err != nil {
+		log.Printf("Warning: Failed to create index uix_employee_period_active: %v", err)
+	}
 
-	// Add check constraint for OvertimeRecord Hours <= 3
-	var constraintCount int64
-	db.Raw("SELECT count(*) FROM information_schema.check_constraints WHERE constraint_name = ? AND table_name = ?", "ck_overtime_hours", "overtime_records").Scan(&constraintCount)
-	if constraintCount == 0 {
-		err = db.Exec("ALTER TABLE overtime_records ADD CONSTRAINT ck_overtime_hours CHECK (hours <= 3)").Error
+	// Only one queued or running payroll job per period
+	err = db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS uix_payroll_run_active ON payroll_runs (attendance_period_id) WHERE status IN ('queued', 'running')").Error
+	if err != nil {
+		log.Printf("Warning: Failed to create index uix_payroll_run_active: %v", err)
+	}
+}
+
+// migrateOvertimeHoursToMinutes replaces the overtime_records hours column with minutes, and rewrites the
+// hours of each tier in the stored breakdowns as minutes. It runs in one transaction so a failure leaves the hours intact.
+func migrateOvertimeHoursToMinutes(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			"ALTER TABLE overtime_records ADD COLUMN IF NOT EXISTS minutes integer",
+			"UPDATE overtime_records SET minutes = hours * 60",
+			"ALTER TABLE overtime_records ALTER COLUMN minutes SET NOT NULL",
+		}
+		if tx.Migrator().HasColumn(&models.OvertimeRecord{}, "breakdown") {
+			statements = append(statements, `UPDATE overtime_records SET breakdown = (
+				SELECT jsonb_agg(jsonb_build_object('minutes', (tier->>'hours')::integer * 60, 'multiplier', tier->'multiplier'))
+				FROM jsonb_array_elements(breakdown) AS tier
+			) WHERE jsonb_typeof(breakdown) = 'array' AND jsonb_array_length(breakdown) > 0`)
+		}
+		// Dropping the column also drops the old CHECK (hours <= 3) constraint
+		statements = append(statements, "ALTER TABLE overtime_records DROP COLUMN hours")
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to migrate overtime hours to minutes: %v", err)
+	}
+}
+
+// moneyColumns are the columns of amounts read as exact decimals, with the decimal type each must have
+var moneyColumns = []struct {
+	Table, Column, Type string
+	Scale               int
+}{
+	{"employees", "salary", "decimal(10,2)", 2},
+	{"payslips", "base_salary", "decimal(10,2)", 2},
+	{"payslips", "prorated_salary", "decimal(10,2)", 2},
+	{"payslips", "unpaid_leave_deduction", "decimal(10,2)", 2},
+	{"payslips", "overtime_pay", "decimal(10,2)", 2},
+	{"payslips", "reimbursements_total", "decimal(10,2)", 2},
+	{"payslips", "take_home_pay", "decimal(10,2)", 2},
+	{"payslip_line_items", "quantity", "decimal(10,4)", 4},
+	{"payslip_line_items", "rate", "decimal(14,4)", 4},
+	{"payslip_line_items", "amount", "decimal(10,2)", 2},
+	{"reimbursement_requests", "amount", "decimal(10,2)", 2},
+	{"reimbursement_categories", "max_per_claim", "decimal(10,2)", 2},
+	{"reimbursement_categories", "cap_amount", "decimal(12,2)", 2},
+}
+
+// migrateMoneyColumns converts money columns that are not exact decimals, e.g. created as double precision
+// by hand or by an older schema, rounding the stored values to the column's scale. Columns that are already
+// decimal, such as every decimal(10,2) column created by AutoMigrate, are left untouched with their data.
+func migrateMoneyColumns(db *gorm.DB) {
+	for _, column := range moneyColumns {
+		var dataType string
+		err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?",
+			column.Table, column.Column).Scan(&dataType).Error
 		if err != nil {
-			log.Printf("Warning: Failed to create CHECK constraint ck_overtime_hours: %v", err)
+			log.Fatalf("Failed to inspect column %s.%s: %v", column.Table, column.Column, err)
+		}
+		if dataType == "" || dataType == "numeric" {
+			continue
+		}
+		statement := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING ROUND(%s::numeric, %d)",
+			column.Table, column.Column, column.Type, column.Column, column.Scale)
+		if err := db.Exec(statement).Error; err != nil {
+			log.Fatalf("Failed to convert %s.%s from %s to %s: %v", column.Table, column.Column, dataType, column.Type, err)
 		}
+		log.Printf("Converted %s.%s from %s to %s", column.Table, column.Column, dataType, column.Type)
 	}
 }
 
@@ -124,12 +238,27 @@ func ClearAllData(db *gorm.DB) error {
 	// Or, temporarily disable foreign key checks if your DB supports it, but that's riskier.
 	tables := []string{
 		"audit_logs",
+		"holidays",
+		"leave_requests",
+		"attendance_corrections",
+		"device_pins",
+		"overtime_policies",
+		"payroll_policies",
+		"leave_types",
+		"payroll_runs",
+		"payslip_line_items",
+		"payslip_contributions",
 		"payslips",
+		"salary_history",
+		"tax_profiles",
+		"reimbursement_receipts",
 		"reimbursement_requests",
+		"reimbursement_categories",
 		"overtime_records",
 		"attendance_records",
 		"attendance_periods",
 		"employees",
+		"work_schedules",
 		"admins",
 	}
 
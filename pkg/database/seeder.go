@@ -1,14 +1,12 @@
 package database
 
 import (
-	"fmt"
 	"log"
 	"math/rand"
 	"payslip-generator/pkg/models"
 	"payslip-generator/pkg/utils"
 
 	"github.com/go-faker/faker/v4"
-	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
@@ -74,7 +72,7 @@ func SeedData(db *gorm.DB) {
 		// faker.Number().Float(2, 30000, 150000) can be used if faker is configured for it
 		// For now, using math/rand and shopspring/decimal for precision
 		salaryVal := 30000 + rand.Float64()*(150000-30000)
-		salary := decimal.NewFromFloat(salaryVal).Round(2).InexactFloat64() // Store as float64 after rounding
+		salary := models.NewMoney(decimal.NewFromFloat(salaryVal))
 
 		employee := models.Employee{
 			Username: username,
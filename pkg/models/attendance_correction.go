@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attendance correction statuses
+const (
+	CorrectionPending  = "pending"
+	CorrectionApproved = "approved"
+	CorrectionRejected = "rejected"
+)
+
+// AttendanceCorrection is an employee's request to record attendance for a past day they missed
+type AttendanceCorrection struct {
+	BaseModel
+	EmployeeID         uuid.UUID  `gorm:"type:uuid;not null;index"`
+	AttendancePeriodID uuid.UUID  `gorm:"type:uuid;not null"`
+	Date               time.Time  `gorm:"type:date;not null"`
+	CheckInTime        time.Time  `gorm:"type:timestamptz;not null"`
+	CheckOutTime       *time.Time `gorm:"type:timestamptz"`
+	Reason             string     `gorm:"type:text;not null"`
+	Status             string     `gorm:"type:varchar(20);not null;default:'pending'"` // pending, approved, rejected
+	ReviewedAt         *time.Time `gorm:"type:timestamptz"`
+	ReviewReason       *string    `gorm:"type:text"` // Required for rejections, optional for approvals
+	AttendanceRecordID *uuid.UUID `gorm:"type:uuid"` // The record created on approval
+
+	Employee         Employee         `gorm:"foreignKey:EmployeeID"`
+	AttendancePeriod AttendancePeriod `gorm:"foreignKey:AttendancePeriodID"`
+}
+
+// TableName specifies the table name for AttendanceCorrection
+func (AttendanceCorrection) TableName() string {
+	return "attendance_corrections"
+}
@@ -6,13 +6,24 @@ import (
 	"github.com/google/uuid"
 )
 
+// Attendance check-out statuses
+const (
+	CheckOutOpen       = "open"        // Checked in, not yet checked out
+	CheckOutRecorded   = "checked_out" // Employee checked out
+	CheckOutAutoClosed = "auto_closed" // Closed at the end of the scheduled day by the missing check-out policy
+	CheckOutFlagged    = "flagged"     // Check-out missing, flagged for admin review
+)
+
 // AttendanceRecord represents an employee's attendance for a specific day
 type AttendanceRecord struct {
 	BaseModel
-	EmployeeID         uuid.UUID `gorm:"type:uuid;not null"`
-	AttendancePeriodID uuid.UUID `gorm:"type:uuid;not null"`
-	Date               time.Time `gorm:"type:date;not null"`
-	CheckInTime        time.Time `gorm:"type:timestamptz;not null"`
+	EmployeeID         uuid.UUID  `gorm:"type:uuid;not null"`
+	AttendancePeriodID uuid.UUID  `gorm:"type:uuid;not null"`
+	Date               time.Time  `gorm:"type:date;not null"`
+	CheckInTime        time.Time  `gorm:"type:timestamptz;not null"`
+	CheckOutTime       *time.Time `gorm:"type:timestamptz"`
+	CheckOutStatus     string     `gorm:"type:varchar(20);not null;default:'open'"` // open, checked_out, auto_closed, flagged
+	WorkedMinutes      *int       `gorm:"type:integer"`                             // Set once the record is checked out or auto-closed
 
 	Employee         Employee         `gorm:"foreignKey:EmployeeID"`
 	AttendancePeriod AttendancePeriod `gorm:"foreignKey:AttendancePeriodID"`
@@ -23,6 +34,14 @@ func (AttendanceRecord) TableName() string {
 	return "attendance_records"
 }
 
+// WorkedDuration returns the time between check-in and check-out, or zero if there is no check-out
+func (a AttendanceRecord) WorkedDuration() time.Duration {
+	if a.CheckOutTime == nil || a.CheckOutTime.Before(a.CheckInTime) {
+		return 0
+	}
+	return a.CheckOutTime.Sub(a.CheckInTime)
+}
+
 // GORM V2 uses a different way to specify composite unique constraints.
 // This is typically done during migration using db.Migrator().CreateConstraint(&AttendanceRecord{}, "uix_employee_date")
 // or directly in the struct tag for simpler cases, but composite foreign keys need explicit handling.
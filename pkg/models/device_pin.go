@@ -0,0 +1,17 @@
+package models
+
+import "github.com/google/uuid"
+
+// DevicePIN maps a user PIN enrolled on the fingerprint terminals to an employee
+type DevicePIN struct {
+	BaseModel
+	PIN        string    `gorm:"type:varchar(32);not null;uniqueIndex:uix_device_pin"`
+	EmployeeID uuid.UUID `gorm:"type:uuid;not null;index"`
+
+	Employee Employee `gorm:"foreignKey:EmployeeID"`
+}
+
+// TableName specifies the table name for DevicePIN
+func (DevicePIN) TableName() string {
+	return "device_pins"
+}
@@ -1,13 +1,42 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 // Employee represents an employee in the system
 type Employee struct {
 	BaseModel
-	Username string  `gorm:"type:varchar(255);unique;not null"`
-	Password string  `gorm:"type:varchar(255);not null"`
-	Salary   float64 `gorm:"type:decimal(10,2);not null"`
+	Username       string     `gorm:"type:varchar(255);unique;not null"`
+	Password       string     `gorm:"type:varchar(255);not null" json:"-"` // bcrypt hash, never serialized
+	Salary         Money      `gorm:"type:decimal(10,2);not null"`         // The salary history entry in force today, kept current as later entries take effect; payroll reads the history
+	DeactivatedAt  *time.Time `gorm:"type:timestamptz"`                    // Deactivated employees cannot log in and are left out of later payroll runs
+	WorkScheduleID *uuid.UUID `gorm:"type:uuid"`                           // Nil means the standard Monday to Friday, 8 hours schedule
+
+	WorkSchedule *WorkSchedule `gorm:"foreignKey:WorkScheduleID" json:",omitempty"`
+}
+
+// AfterCreate opens the employee's salary history with the starting salary, effective from the day the employee is created
+func (e *Employee) AfterCreate(tx *gorm.DB) (err error) {
+	createdAt := e.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	reason := "Starting salary"
+	entry := SalaryHistory{
+		EmployeeID:    e.ID,
+		Salary:        e.Salary,
+		EffectiveFrom: time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 0, 0, 0, 0, time.UTC),
+		Reason:        &reason,
+	}
+	entry.CreatedBy = e.CreatedBy
+	entry.UpdatedBy = e.CreatedBy
+	entry.IPAddress = e.IPAddress
+	return tx.Omit(clause.Associations).Create(&entry).Error
 }
 
 // BeforeSave hashes the employee's password before saving
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Holiday sources
+const (
+	HolidaySourceManual = "manual"
+	HolidaySourceCSV    = "csv"
+	HolidaySourceICS    = "ics"
+)
+
+// Holiday is a company-wide non-working day, such as a public holiday
+type Holiday struct {
+	BaseModel
+	Date   time.Time `gorm:"type:date;not null;uniqueIndex:uix_holiday_date"`
+	Name   string    `gorm:"type:varchar(255);not null"`
+	Source string    `gorm:"type:varchar(50);not null;default:'manual'"` // manual, csv, ics
+}
+
+// TableName specifies the table name for Holiday
+func (Holiday) TableName() string {
+	return "holidays"
+}
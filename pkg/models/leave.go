@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Leave entitlement accrual methods
+const (
+	LeaveAccrualUpfront = "upfront" // The whole yearly entitlement is available from January 1
+	LeaveAccrualMonthly = "monthly" // One twelfth of the yearly entitlement is added at the start of each month
+)
+
+// Leave request statuses
+const (
+	LeavePending  = "pending"
+	LeaveApproved = "approved"
+	LeaveRejected = "rejected"
+)
+
+// LeaveType is a kind of leave employees can request, e.g. annual, sick or unpaid leave
+type LeaveType struct {
+	BaseModel
+	Name              string  `gorm:"type:varchar(100);unique;not null"`
+	Paid              bool    `gorm:"not null"`                                    // Paid leave days count as attended; unpaid days are deducted from salary
+	YearlyEntitlement float64 `gorm:"type:decimal(5,2);not null;default:0"`        // Days per calendar year; only enforced for paid leave
+	Accrual           string  `gorm:"type:varchar(20);not null;default:'upfront'"` // upfront or monthly
+}
+
+// TableName specifies the table name for LeaveType
+func (LeaveType) TableName() string {
+	return "leave_types"
+}
+
+// LeaveRequest is an employee's request for leave over a range of dates
+type LeaveRequest struct {
+	BaseModel
+	EmployeeID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	LeaveTypeID  uuid.UUID  `gorm:"type:uuid;not null"`
+	StartDate    time.Time  `gorm:"type:date;not null"`
+	EndDate      time.Time  `gorm:"type:date;not null"`
+	Days         float64    `gorm:"type:decimal(5,2);not null"` // Working days covered under the employee's schedule, excluding holidays
+	Reason       string     `gorm:"type:text"`
+	Status       string     `gorm:"type:varchar(20);not null;default:'pending'"` // pending, approved, rejected
+	ReviewedAt   *time.Time `gorm:"type:timestamptz"`
+	ReviewReason *string    `gorm:"type:text"` // Required for rejections, optional for approvals
+
+	Employee  Employee  `gorm:"foreignKey:EmployeeID"`
+	LeaveType LeaveType `gorm:"foreignKey:LeaveTypeID"`
+}
+
+// TableName specifies the table name for LeaveRequest
+func (LeaveRequest) TableName() string {
+	return "leave_requests"
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// MoneyPlaces is the number of decimal places money is kept to
+const MoneyPlaces = 2
+
+// Money is an exact amount of money. It is stored in decimal columns without passing through float64,
+// and written to JSON as a string with two decimals, e.g. "1234.50". JSON input may be a string or a number.
+type Money struct {
+	decimal.Decimal
+}
+
+// NewMoney rounds an amount to whole cents
+func NewMoney(amount decimal.Decimal) Money {
+	return Money{amount.Round(MoneyPlaces)}
+}
+
+// ParseMoney parses an amount such as "1234.5" exactly, without rounding it
+func ParseMoney(s string) (Money, error) {
+	amount, err := decimal.NewFromString(s)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return Money{amount}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on invalid input; for constants and tests
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) Money {
+	return Money{m.Decimal.Add(other.Decimal)}
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) Money {
+	return Money{m.Decimal.Sub(other.Decimal)}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{m.Decimal.Neg()}
+}
+
+// HasWholeCents reports whether the amount has at most two decimal places
+func (m Money) HasWholeCents() bool {
+	return m.Decimal.Equal(m.Decimal.Round(MoneyPlaces))
+}
+
+// String formats the amount with two decimals
+func (m Money) String() string {
+	return m.Decimal.StringFixed(MoneyPlaces)
+}
+
+// MarshalJSON writes the amount as a string with two decimals
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.String() + `"`), nil
+}
+
+// UnmarshalJSON reads an amount written as a string or a number; null leaves it unchanged
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if err := m.Decimal.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("invalid amount %s", data)
+	}
+	return nil
+}
+
+// MarshalText writes the amount with two decimals
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText reads an amount from form values and query parameters
+func (m *Money) UnmarshalText(text []byte) error {
+	parsed, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as a decimal string, so the database never sees a float
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal.String(), nil
+}
+
+// Scan reads a decimal column; NULL reads as zero
+func (m *Money) Scan(value interface{}) error {
+	if value == nil {
+		*m = Money{}
+		return nil
+	}
+	return m.Decimal.Scan(value)
+}
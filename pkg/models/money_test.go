@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney_JSON(t *testing.T) {
+	out, err := json.Marshal(struct {
+		Amount Money  `json:"amount"`
+		Limit  *Money `json:"limit,omitempty"`
+	}{Amount: MustParseMoney("1234.5")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"1234.50"}`, string(out), "Money is written as a string with two decimals")
+
+	var in struct {
+		FromString Money  `json:"from_string"`
+		FromNumber Money  `json:"from_number"`
+		Null       Money  `json:"null"`
+		Missing    *Money `json:"missing"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"from_string":"0.10","from_number":0.2,"null":null,"missing":null}`), &in))
+	assert.Equal(t, "0.30", in.FromString.Add(in.FromNumber).String(), "No float rounding error")
+	assert.True(t, in.Null.IsZero())
+	assert.Nil(t, in.Missing)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"from_string":"ten"}`), &in))
+}
+
+func TestMoney_ParseKeepsExactValue(t *testing.T) {
+	m, err := ParseMoney("100.125")
+	require.NoError(t, err)
+	assert.False(t, m.HasWholeCents(), "Parsing does not round, so validation can reject fractional cents")
+	assert.True(t, MustParseMoney("100.10").HasWholeCents())
+
+	_, err = ParseMoney("")
+	assert.Error(t, err)
+	_, err = ParseMoney("1,000")
+	assert.Error(t, err)
+
+	assert.Equal(t, "100.13", NewMoney(m.Decimal).String(), "NewMoney rounds half away from zero")
+	assert.Equal(t, "-0.01", NewMoney(decimal.RequireFromString("-0.005")).String())
+}
+
+func TestMoney_Text(t *testing.T) {
+	var m Money
+	require.NoError(t, m.UnmarshalText([]byte("75.5")))
+	assert.Equal(t, "75.50", m.String())
+	assert.Error(t, m.UnmarshalText([]byte("abc")))
+
+	text, err := MustParseMoney("3").MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "3.00", string(text))
+}
+
+func TestMoney_Database(t *testing.T) {
+	value, err := MustParseMoney("99999999.99").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "99999999.99", value, "Stored as a decimal string, never as a float")
+
+	for _, src := range []interface{}{"4000.50", []byte("4000.50"), 4000.5} {
+		var m Money
+		require.NoError(t, m.Scan(src), "%T", src)
+		assert.Equal(t, "4000.50", m.String(), "%T", src)
+	}
+
+	m := MustParseMoney("12")
+	require.NoError(t, m.Scan(nil))
+	assert.True(t, m.IsZero(), "NULL reads as zero")
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a, b := MustParseMoney("10.10"), MustParseMoney("0.20")
+	assert.Equal(t, "10.30", a.Add(b).String())
+	assert.Equal(t, "9.90", a.Sub(b).String())
+	assert.Equal(t, "-10.10", a.Neg().String())
+	assert.Equal(t, "0.00", Money{}.String())
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// Overtime day types, which select the tiers and daily cap of an overtime policy
+const (
+	OvertimeDayWorkday = "workday"  // A working day of the employee's schedule
+	OvertimeDayRestDay = "rest_day" // A day off under the employee's schedule
+	OvertimeDayHoliday = "holiday"  // A public holiday, whatever the schedule
+)
+
+// Overtime rounding modes, applied to the submitted duration before it is capped and priced
+const (
+	OvertimeRoundNearest = "nearest"
+	OvertimeRoundDown    = "down"
+	OvertimeRoundUp      = "up"
+)
+
+// OvertimeTier pays consecutive overtime hours at a multiple of the hourly salary.
+// Hours is the number of hours the tier covers; 0 on the last tier covers all remaining hours.
+type OvertimeTier struct {
+	Hours      int     `json:"hours"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// OvertimeTierMinutes is the part of an overtime record paid at one tier of the policy
+type OvertimeTierMinutes struct {
+	Minutes    int     `json:"minutes"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// OvertimePolicy is a version of the overtime pay rules. Overtime is priced when it is submitted,
+// using the latest version in effect on the overtime date.
+type OvertimePolicy struct {
+	BaseModel
+	Version              int            `gorm:"not null;uniqueIndex"` // Assigned in sequence when the policy is created
+	Name                 string         `gorm:"type:varchar(100);not null"`
+	EffectiveFrom        time.Time      `gorm:"type:date;not null"` // Applies to overtime dated on or after this day until a later version takes effect
+	WorkdayTiers         []OvertimeTier `gorm:"type:jsonb;serializer:json;not null"`
+	RestDayTiers         []OvertimeTier `gorm:"type:jsonb;serializer:json;not null"`
+	HolidayTiers         []OvertimeTier `gorm:"type:jsonb;serializer:json;not null"`
+	DailyCapHours        int            `gorm:"not null"`                                    // Maximum overtime hours on a working day
+	RestDayDailyCapHours int            `gorm:"not null"`                                    // Maximum overtime hours on a rest day or holiday
+	WeeklyCapHours       int            `gorm:"not null;default:0"`                          // Maximum overtime hours in a Monday to Sunday week; 0 means no weekly cap
+	RoundingMinutes      int            `gorm:"not null;default:1"`                          // Overtime durations are rounded to a multiple of this many minutes; 1 keeps them exact
+	RoundingMode         string         `gorm:"type:varchar(10);not null;default:'nearest'"` // nearest, down or up
+}
+
+// TableName specifies the table name for OvertimePolicy
+func (OvertimePolicy) TableName() string {
+	return "overtime_policies"
+}
+
+// Tiers returns the policy's tiers for a day type
+func (p OvertimePolicy) Tiers(dayType string) []OvertimeTier {
+	switch dayType {
+	case OvertimeDayRestDay:
+		return p.RestDayTiers
+	case OvertimeDayHoliday:
+		return p.HolidayTiers
+	default:
+		return p.WorkdayTiers
+	}
+}
+
+// DailyCap returns the maximum overtime hours for a day type
+func (p OvertimePolicy) DailyCap(dayType string) int {
+	if dayType == OvertimeDayWorkday {
+		return p.DailyCapHours
+	}
+	return p.RestDayDailyCapHours
+}
@@ -6,27 +6,39 @@ import (
 	"github.com/google/uuid"
 )
 
+// Overtime record statuses
+const (
+	OvertimePending  = "pending"
+	OvertimeApproved = "approved"
+	OvertimeRejected = "rejected"
+)
+
 // OvertimeRecord represents an employee's overtime request
 type OvertimeRecord struct {
 	BaseModel
-	EmployeeID     uuid.UUID `gorm:"type:uuid;not null"`
-	Date           time.Time `gorm:"type:date;not null"`
-	Hours          int       `gorm:"type:integer;not null"` // Consider adding validation for max 3
-	SubmittedAt    time.Time `gorm:"type:timestamptz;not null;default:now()"`
-	RateMultiplier float64   `gorm:"type:decimal(3,2);default:2.0"`
+	EmployeeID     uuid.UUID             `gorm:"type:uuid;not null"`
+	Date           time.Time             `gorm:"type:date;not null"`
+	Minutes        int                   `gorm:"type:integer;not null"` // Duration after the overtime policy's rounding, limited by its daily and weekly caps
+	StartTime      *time.Time            `gorm:"type:timestamptz"`      // Set when the overtime was submitted as a start and end time
+	EndTime        *time.Time            `gorm:"type:timestamptz"`
+	SubmittedAt    time.Time             `gorm:"type:timestamptz;not null;default:now()"`
+	RateMultiplier float64               `gorm:"type:decimal(3,2);default:2.0"`               // Average multiplier over the breakdown; records without a breakdown are paid at this rate
+	DayType        string                `gorm:"type:varchar(20)"`                            // workday, rest_day or holiday; empty on overtime submitted before overtime policies
+	PolicyVersion  *int                  `gorm:"type:integer"`                                // Overtime policy version that priced the record; 0 is the built-in default
+	Breakdown      []OvertimeTierMinutes `gorm:"type:jsonb;serializer:json"`                  // Minutes paid at each tier of the policy
+	Status         string                `gorm:"type:varchar(20);not null;default:'pending'"` // pending, approved, rejected; only approved overtime is paid
+	ReviewedAt     *time.Time            `gorm:"type:timestamptz"`                            // When an admin approved or rejected the overtime
+	ReviewReason   *string               `gorm:"type:text"`                                   // Required for rejections, optional for approvals
 
 	Employee Employee `gorm:"foreignKey:EmployeeID"`
 }
 
+// HoursWorked returns the overtime duration in hours
+func (r OvertimeRecord) HoursWorked() float64 {
+	return float64(r.Minutes) / 60
+}
+
 // TableName specifies the table name for OvertimeRecord
 func (OvertimeRecord) TableName() string {
 	return "overtime_records"
 }
-
-// You might want to add a check constraint for Hours <= 3 directly in PostgreSQL.
-// GORM allows creating CHECK constraints during migration:
-// db.Migrator().CreateConstraint(&OvertimeRecord{}, "ck_overtime_hours")
-// And the constraint would be defined in the struct tag like:
-// Hours int `gorm:"type:integer;not null;check:hours <= 3"`
-// However, direct support for CHECK constraints in struct tags can be database-dependent.
-// For now, we will rely on application-level validation or manual DB constraint.
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Salary proration methods of a payroll policy
+const (
+	ProrationWorkingDays  = "working_days"  // Salary / working days in the period under the employee's schedule
+	ProrationCalendarDays = "calendar_days" // Salary / calendar days in the period
+	ProrationDivisor2175  = "divisor_21_75" // Salary / 21.75, the average working days of a month
+	ProrationDivisor22    = "divisor_22"    // Salary / 22
+	ProrationNone         = "none"          // The full salary when every working day is attended or on leave, prorated by working days otherwise; unpaid leave is still deducted
+)
+
+// Payroll rounding modes, applied to each calculated payslip line
+const (
+	PayrollRoundHalfUp   = "half_up"   // Halves round away from zero
+	PayrollRoundHalfEven = "half_even" // Halves round to the even digit (banker's rounding)
+	PayrollRoundDown     = "down"      // Towards zero
+	PayrollRoundUp       = "up"        // Away from zero
+)
+
+// PayrollPolicyRules are the settings of a payroll policy that decide a payslip's amounts.
+// Payslips keep a copy of the rules they were calculated with.
+type PayrollPolicyRules struct {
+	ProrationMethod   string `gorm:"type:varchar(20);not null" json:"proration_method"` // working_days, calendar_days, divisor_21_75, divisor_22 or none
+	RoundingMode      string `gorm:"type:varchar(10);not null" json:"rounding_mode"`    // half_up, half_even, down or up
+	RoundingPrecision int    `gorm:"not null" json:"rounding_precision"`                // Decimal places of each line; 0 for whole amounts, -2 for hundreds
+}
+
+// PayrollPolicy is a version of the company's payroll rules. A payroll run uses the latest version
+// in effect on the first day of its attendance period.
+type PayrollPolicy struct {
+	BaseModel
+	Version            int       `gorm:"not null;uniqueIndex"` // Assigned in sequence when the policy is created
+	Name               string    `gorm:"type:varchar(100);not null"`
+	EffectiveFrom      time.Time `gorm:"type:date;not null"` // Applies to periods starting on or after this day until a later version takes effect
+	PayrollPolicyRules `gorm:"embedded"`
+}
+
+// TableName specifies the table name for PayrollPolicy
+func (PayrollPolicy) TableName() string {
+	return "payroll_policies"
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Payroll run statuses
+const (
+	PayrollRunQueued    = "queued"
+	PayrollRunRunning   = "running"
+	PayrollRunCompleted = "completed"
+	PayrollRunFailed    = "failed"
+)
+
+// PayrollRun tracks a background payroll job for an attendance period
+type PayrollRun struct {
+	BaseModel
+	AttendancePeriodID uuid.UUID      `gorm:"type:uuid;not null"`
+	Status             string         `gorm:"type:varchar(50);not null;default:'queued'"` // queued, running, completed, failed
+	TotalEmployees     int            `gorm:"type:integer;not null;default:0"`
+	ProcessedEmployees int            `gorm:"type:integer;not null;default:0"`
+	PayslipsGenerated  int            `gorm:"type:integer;not null;default:0"`
+	SkippedEmployees   int            `gorm:"type:integer;not null;default:0"` // Already had a payslip, e.g. when resuming a failed run
+	FailedEmployees    int            `gorm:"type:integer;not null;default:0"` // No payslip could be calculated; listed under Warnings and paid by running payroll again
+	StartedAt          *time.Time     `gorm:"type:timestamptz"`
+	FinishedAt         *time.Time     `gorm:"type:timestamptz"`
+	Error              *string        `gorm:"type:text"`
+	Warnings           datatypes.JSON `gorm:"type:jsonb"`        // Items left out of the payslips, e.g. overtime awaiting review
+	RequestID          string         `gorm:"type:varchar(255)"` // Request that queued the run, carried into the audit log
+
+	AttendancePeriod AttendancePeriod `gorm:"foreignKey:AttendancePeriodID"`
+}
+
+// TableName specifies the table name for PayrollRun
+func (PayrollRun) TableName() string {
+	return "payroll_runs"
+}
+
+// We will add the partial unique index `uix_payroll_run_active` on (`AttendancePeriodID`) for queued and
+// running jobs during the auto-migration process in `database.go`, so a period cannot be processed twice at once.
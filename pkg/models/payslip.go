@@ -1,25 +1,44 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 )
 
 // Payslip represents an employee's payslip for a specific period
 type Payslip struct {
 	BaseModel
-	EmployeeID         uuid.UUID `gorm:"type:uuid;not null"`
-	AttendancePeriodID uuid.UUID `gorm:"type:uuid;not null"`
-	BaseSalary         float64   `gorm:"type:decimal(10,2);not null"`
-	ProratedSalary     float64   `gorm:"type:decimal(10,2);not null"`
-	AttendanceCount    int       `gorm:"type:integer;not null"`
-	TotalWorkingDays   int       `gorm:"type:integer;not null"`
-	OvertimeHours      float64   `gorm:"type:decimal(4,2);default:0"`
-	OvertimePay        float64   `gorm:"type:decimal(10,2);default:0"`
-	ReimbursementsTotal float64   `gorm:"type:decimal(10,2);default:0"`
-	TakeHomePay        float64   `gorm:"type:decimal(10,2);not null"`
+	EmployeeID              uuid.UUID           `gorm:"type:uuid;not null"`
+	AttendancePeriodID      uuid.UUID           `gorm:"type:uuid;not null"`
+	BaseSalary              Money               `gorm:"type:decimal(10,2);not null"` // Salary in force at the end of the period
+	ProratedSalary          Money               `gorm:"type:decimal(10,2);not null"`
+	AttendanceCount         int                 `gorm:"type:integer;not null"`
+	TotalWorkingDays        int                 `gorm:"type:integer;not null"`
+	PaidLeaveDays           int                 `gorm:"type:integer;not null;default:0"` // Approved paid leave days, included in AttendanceCount
+	UnpaidLeaveDays         int                 `gorm:"type:integer;not null;default:0"`
+	UnpaidLeaveDeduction    Money               `gorm:"type:decimal(10,2);not null;default:0"`
+	OvertimeHours           float64             `gorm:"type:decimal(4,2);default:0"`
+	OvertimePay             Money               `gorm:"type:decimal(10,2);default:0"`
+	ReimbursementsTotal     Money               `gorm:"type:decimal(10,2);default:0"`
+	SocialSecurityDeduction Money               `gorm:"type:decimal(10,2);not null;default:0"` // Employee social security contributions deducted
+	EmployerContributions   Money               `gorm:"type:decimal(10,2);not null;default:0"` // Employer social security contributions, paid on top of the payslip
+	TaxableIncome           Money               `gorm:"type:decimal(10,2);not null;default:0"` // Gross income tax is withheld on: prorated salary, overtime and taxable employer contributions
+	IncomeTax               Money               `gorm:"type:decimal(10,2);not null;default:0"` // Income tax (PPh 21) withheld; negative when the year-end true-up refunds
+	TakeHomePay             Money               `gorm:"type:decimal(10,2);not null"`
+	PayrollPolicyVersion    int                 `gorm:"not null;default:0"`         // Version of the payroll policy applied; 0 is the built-in default
+	PayrollPolicy           *PayrollPolicyRules `gorm:"type:jsonb;serializer:json"` // The rules applied, so the payslip can be reproduced; nil on payslips from before payroll policies
+	SalarySegments          []SalarySegment     `gorm:"type:jsonb;serializer:json"` // The parts of the period paid at each salary in force; empty on payslips from before salary history
+	TaxCalculation          *TaxCalculation     `gorm:"type:jsonb;serializer:json"` // How IncomeTax was worked out; nil when no tax was calculated
+	Warnings                datatypes.JSON      `gorm:"type:jsonb"`                 // Items left out of the payslip, so a resumed run still reports them; null on payslips from before this was kept
+	VoidedAt                *time.Time          `gorm:"type:timestamptz"`           // Set when the payroll run is voided; voided payslips are kept for history
+	VoidedBy                *uuid.UUID          `gorm:"type:uuid"`
+	VoidReason              *string             `gorm:"type:text"`
 
-	Employee         Employee         `gorm:"foreignKey:EmployeeID"`
-	AttendancePeriod AttendancePeriod `gorm:"foreignKey:AttendancePeriodID"`
+	Employee         Employee          `gorm:"foreignKey:EmployeeID"`
+	AttendancePeriod AttendancePeriod  `gorm:"foreignKey:AttendancePeriodID"`
+	LineItems        []PayslipLineItem `gorm:"foreignKey:PayslipID" json:",omitempty"` // Empty on payslips from before line items were kept
 }
 
 // TableName specifies the table name for Payslip
@@ -27,5 +46,5 @@ func (Payslip) TableName() string {
 	return "payslips"
 }
 
-// We will add the unique constraint `uix_employee_period` for (`EmployeeID`, `AttendancePeriodID`)
-// during the auto-migration process in `database.go`.
+// We will add the partial unique index `uix_employee_period_active` for (`EmployeeID`, `AttendancePeriodID`)
+// over non-voided payslips during the auto-migration process in `database.go`.
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PayslipLineItem is one earning or deduction line of a payslip, kept as the payroll run calculated it
+type PayslipLineItem struct {
+	BaseModel
+	PayslipID   uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Position    int             `gorm:"type:integer;not null"`     // Order of the line on the payslip
+	Type        string          `gorm:"type:varchar(30);not null"` // salary, overtime, reimbursement, unpaid_leave, income_tax
+	Description string          `gorm:"type:text;not null"`
+	SourceID    *uuid.UUID      `gorm:"type:uuid"`                   // Overtime record, reimbursement request or leave request behind the line
+	Quantity    decimal.Decimal `gorm:"type:decimal(10,4);not null"` // Days, overtime hours or 1 for reimbursements
+	Rate        decimal.Decimal `gorm:"type:decimal(14,4);not null"`
+	Amount      Money           `gorm:"type:decimal(10,2);not null"` // Negative for deductions
+}
+
+// TableName specifies the table name for PayslipLineItem
+func (PayslipLineItem) TableName() string {
+	return "payslip_line_items"
+}
@@ -0,0 +1,24 @@
+package models
+
+// Reimbursement category cap windows
+const (
+	ReimbursementCapNone      = "none"   // Only the per-claim maximum applies
+	ReimbursementCapPerPeriod = "period" // Claims in the same attendance period, or calendar month for claims outside any period
+	ReimbursementCapPerYear   = "year"   // Claims submitted in the same calendar year
+)
+
+// ReimbursementCategory is an admin-defined kind of expense, e.g. medical, travel, meals or internet,
+// with the limits that apply to claims in it
+type ReimbursementCategory struct {
+	BaseModel
+	Name            string `gorm:"type:varchar(100);unique;not null"`
+	MaxPerClaim     Money  `gorm:"type:decimal(10,2);not null;default:0"`    // Largest amount of a single claim; 0 means no maximum
+	CapAmount       Money  `gorm:"type:decimal(12,2);not null;default:0"`    // Total an employee may claim in the cap window; 0 means no cap
+	CapPeriod       string `gorm:"type:varchar(20);not null;default:'none'"` // none, period or year
+	ReceiptRequired bool   `gorm:"not null;default:false"`                   // Claims must be submitted with at least one receipt
+}
+
+// TableName specifies the table name for ReimbursementCategory
+func (ReimbursementCategory) TableName() string {
+	return "reimbursement_categories"
+}
@@ -0,0 +1,21 @@
+package models
+
+import "github.com/google/uuid"
+
+// ReimbursementReceipt is a receipt file attached to a reimbursement request. The file itself
+// is kept in the receipt store under StorageKey; the row records what was uploaded.
+type ReimbursementReceipt struct {
+	BaseModel
+	ReimbursementRequestID uuid.UUID `gorm:"type:uuid;not null;index"`
+	FileName               string    `gorm:"type:varchar(255);not null"` // Name of the uploaded file, for downloads
+	ContentType            string    `gorm:"type:varchar(100);not null"` // Detected from the file content, not taken from the client
+	Size                   int64     `gorm:"not null"`
+	Checksum               string    `gorm:"type:char(64);not null"` // Hex SHA-256 of the file, checked again on download
+	StorageKey             string    `gorm:"type:varchar(500);not null;uniqueIndex"`
+	Backend                string    `gorm:"type:varchar(20);not null"` // Store holding the file: local or s3
+}
+
+// TableName specifies the table name for ReimbursementReceipt
+func (ReimbursementReceipt) TableName() string {
+	return "reimbursement_receipts"
+}
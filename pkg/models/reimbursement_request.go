@@ -1,20 +1,36 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// Reimbursement request statuses
+const (
+	ReimbursementPending   = "pending"
+	ReimbursementApproved  = "approved"
+	ReimbursementRejected  = "rejected"
+	ReimbursementPaid      = "paid"
+	ReimbursementCancelled = "cancelled" // Withdrawn by the employee while pending
+)
+
 // ReimbursementRequest represents an employee's request for reimbursement
 type ReimbursementRequest struct {
 	BaseModel
-	EmployeeID         uuid.UUID `gorm:"type:uuid;not null"`
-	AttendancePeriodID uuid.UUID `gorm:"type:uuid"` // Nullable
-	Description        string    `gorm:"type:text;not null"`
-	Amount             float64   `gorm:"type:decimal(10,2);not null"`
-	Status             string    `gorm:"type:varchar(50);default:'pending'"` // e.g., pending, approved, rejected, paid
+	EmployeeID         uuid.UUID  `gorm:"type:uuid;not null"`
+	AttendancePeriodID uuid.UUID  `gorm:"type:uuid"`       // Nullable
+	CategoryID         *uuid.UUID `gorm:"type:uuid;index"` // Required for new requests; empty on requests from before categories existed
+	Description        string     `gorm:"type:text;not null"`
+	Amount             Money      `gorm:"type:decimal(10,2);not null"`
+	Status             string     `gorm:"type:varchar(50);default:'pending'"` // e.g., pending, approved, rejected, paid, cancelled
+	ReviewedAt         *time.Time `gorm:"type:timestamptz"`                   // When an admin approved or rejected the request
+	ReviewReason       *string    `gorm:"type:text"`                          // Required for rejections, optional for approvals
 
-	Employee         Employee         `gorm:"foreignKey:EmployeeID"`
-	AttendancePeriod AttendancePeriod `gorm:"foreignKey:AttendancePeriodID"`
+	Employee         Employee               `gorm:"foreignKey:EmployeeID"`
+	AttendancePeriod AttendancePeriod       `gorm:"foreignKey:AttendancePeriodID"`
+	Category         *ReimbursementCategory `gorm:"foreignKey:CategoryID"`
+	Receipts         []ReimbursementReceipt `gorm:"foreignKey:ReimbursementRequestID"`
 }
 
 // TableName specifies the table name for ReimbursementRequest
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SalaryHistory is an employee's salary from a date until the next entry takes effect. Payroll pays each
+// part of a period at the salary in force on those days.
+type SalaryHistory struct {
+	BaseModel
+	EmployeeID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uix_salary_history_employee_date"`
+	Salary        Money     `gorm:"type:decimal(10,2);not null"`
+	EffectiveFrom time.Time `gorm:"type:date;not null;uniqueIndex:uix_salary_history_employee_date"`
+	Reason        *string   `gorm:"type:text"`
+
+	Employee Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+}
+
+// TableName specifies the table name for SalaryHistory
+func (SalaryHistory) TableName() string {
+	return "salary_history"
+}
+
+// SalarySegment is the part of a payslip's period paid at one salary
+type SalarySegment struct {
+	From        time.Time       `json:"from"` // First day of the segment
+	To          time.Time       `json:"to"`   // Last day of the segment
+	Salary      Money           `json:"salary"`
+	WorkingDays int             `json:"working_days"` // Working days of the period that fall in the segment
+	PaidDays    decimal.Decimal `json:"paid_days"`    // Days paid under the payroll policy's proration method
+	Amount      Money           `json:"amount"`
+}
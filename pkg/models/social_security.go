@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Social security programs: BPJS Kesehatan and the BPJS Ketenagakerjaan programs
+const (
+	ProgramHealth       = "kesehatan" // BPJS Kesehatan, health insurance
+	ProgramOldAge       = "jht"       // Jaminan Hari Tua, old-age savings
+	ProgramPension      = "jp"        // Jaminan Pensiun, pension
+	ProgramWorkAccident = "jkk"       // Jaminan Kecelakaan Kerja, work accident insurance
+	ProgramDeath        = "jkm"       // Jaminan Kematian, death insurance
+)
+
+// PayslipContribution is one social security program's contributions for a payslip: the employee's share, deducted
+// from their pay, and the employer's share, kept for cost reporting and the period's contribution report
+type PayslipContribution struct {
+	BaseModel
+	PayslipID          uuid.UUID       `gorm:"type:uuid;not null;index"`
+	EmployeeID         uuid.UUID       `gorm:"type:uuid;not null"`
+	AttendancePeriodID uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Position           int             `gorm:"type:integer;not null"` // Order of the program in its rates table
+	Program            string          `gorm:"type:varchar(30);not null"`
+	ProgramName        string          `gorm:"type:varchar(100);not null"`
+	RatesVersion       string          `gorm:"type:varchar(30);not null"`   // Version of the social security rates applied
+	Wage               Money           `gorm:"type:decimal(10,2);not null"` // Monthly salary the contributions are based on, after the program's cap
+	EmployeeRate       decimal.Decimal `gorm:"type:decimal(7,6);not null"`
+	EmployerRate       decimal.Decimal `gorm:"type:decimal(7,6);not null"`
+	EmployeeAmount     Money           `gorm:"type:decimal(10,2);not null"`
+	EmployerAmount     Money           `gorm:"type:decimal(10,2);not null"`
+	TaxDeductible      bool            `gorm:"not null;default:false"` // The employee's share is deducted from income for PPh 21
+	EmployerTaxable    bool            `gorm:"not null;default:false"` // The employer's share counts as the employee's income for PPh 21
+
+	Employee Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+}
+
+// TableName specifies the table name for PayslipContribution
+func (PayslipContribution) TableName() string {
+	return "payslip_contributions"
+}
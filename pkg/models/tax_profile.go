@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Marital statuses of a tax profile
+const (
+	MaritalSingle  = "single"
+	MaritalMarried = "married"
+)
+
+// TaxProfile holds what income tax withholding (PPh 21) needs to know about an employee
+type TaxProfile struct {
+	BaseModel
+	EmployeeID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	TaxID         *string   `gorm:"type:varchar(16)"`                // NPWP (15 digits) or NIK (16 digits), digits only; nil without one, which raises the tax withheld
+	MaritalStatus string    `gorm:"type:varchar(10);not null"`       // single or married
+	Dependants    int       `gorm:"type:integer;not null;default:0"` // Dependants claimed for PTKP; the tax table caps how many count
+
+	Employee Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+}
+
+// TableName specifies the table name for TaxProfile
+func (TaxProfile) TableName() string {
+	return "tax_profiles"
+}
+
+// PTKPStatus returns the profile's PTKP status, such as TK/0 or K/2, counting at most maxDependants dependants
+func (p TaxProfile) PTKPStatus(maxDependants int) string {
+	dependants := p.Dependants
+	if dependants > maxDependants {
+		dependants = maxDependants
+	}
+	if p.MaritalStatus == MaritalMarried {
+		return fmt.Sprintf("K/%d", dependants)
+	}
+	return fmt.Sprintf("TK/%d", dependants)
+}
+
+// TaxCalculation records how a payslip's income tax was worked out, so it can be checked and reproduced
+type TaxCalculation struct {
+	TableVersion     string          `json:"table_version"`
+	PTKPStatus       string          `json:"ptkp_status"`
+	HasTaxID         bool            `json:"has_tax_id"`
+	ProfileMissing   bool            `json:"profile_missing,omitempty"` // Withheld as single without dependants or tax ID
+	YearEnd          bool            `json:"year_end"`                  // The year's tax was settled on this payslip, less the tax withheld earlier in the year
+	PeriodsPerYear   decimal.Decimal `json:"periods_per_year"`          // Periods like this one in the tax year, by which the period's income is annualised
+	Months           int             `json:"months,omitempty"`          // Months of the year counted for the occupational cost cap at year end
+	GrossIncome      Money           `json:"gross_income"`              // This period's taxable gross, or the year's at year end
+	OccupationalCost Money           `json:"occupational_cost"`
+	Contributions    Money           `json:"contributions"` // Deductible employee social security contributions (JHT and JP), for the period or the year like GrossIncome
+	AnnualNetIncome  Money           `json:"annual_net_income"`
+	PTKP             Money           `json:"ptkp"`
+	TaxableIncome    Money           `json:"taxable_income"`  // PKP: annual net income less PTKP, rounded down
+	AnnualTax        Money           `json:"annual_tax"`      // Including the surcharge without a tax ID
+	WithheldBefore   Money           `json:"withheld_before"` // Withheld earlier in the year, at year end
+}
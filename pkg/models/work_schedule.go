@@ -0,0 +1,25 @@
+package models
+
+import "payslip-generator/pkg/utils"
+
+// StandardHoursPerDay is the daily hours of employees without an assigned work schedule
+const StandardHoursPerDay = 8
+
+// WorkSchedule is a named working pattern that can be assigned to employees
+type WorkSchedule struct {
+	BaseModel
+	Name        string         `gorm:"type:varchar(100);unique;not null"`
+	WorkingDays utils.WorkWeek `gorm:"type:smallint;not null" swaggertype:"array,string"` // Bitmask of working weekdays, serialized as weekday names
+	HoursPerDay float64        `gorm:"type:decimal(4,2);not null"`
+}
+
+// TableName specifies the table name for WorkSchedule
+func (WorkSchedule) TableName() string {
+	return "work_schedules"
+}
+
+// StandardWorkSchedule is the built-in Monday to Friday, 8 hours a day schedule.
+// It applies to employees without a WorkScheduleID and is not stored in the database.
+func StandardWorkSchedule() WorkSchedule {
+	return WorkSchedule{Name: "Standard", WorkingDays: utils.StandardWorkWeek, HoursPerDay: StandardHoursPerDay}
+}
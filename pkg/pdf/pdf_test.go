@@ -0,0 +1,88 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_XrefPointsAtObjects(t *testing.T) {
+	doc := New("Payslip (March)")
+	page := doc.AddPage()
+	page.Text(40, 60, HelveticaBold, 18, Black, `Total \ (net)`)
+	page.FillRect(0, 0, A4Width, 80, Color{31, 78, 121})
+	page.Line(40, 100, 555, 100, 0.5, Black)
+	doc.AddPage().Text(40, 60, Helvetica, 10, Black, "Page two")
+	out := doc.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), `(Total \\ \(net\)) Tj`)
+	assert.Contains(t, string(out), `/Title (Payslip \(March\))`)
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xrefAt, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xrefAt:], []byte("xref\n0 10\n")), "5 fixed objects and 2 objects per page")
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(out[xrefAt:], -1)
+	require.Len(t, entries, 9)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+
+	assert.Equal(t, out, doc.Bytes(), "Encoding the same document twice gives the same bytes")
+}
+
+func TestDocument_StreamLengths(t *testing.T) {
+	doc := New("Streams")
+	doc.AddPage().Text(10, 10, Helvetica, 12, Black, "Hello")
+	out := doc.Bytes()
+
+	match := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindSubmatch(out)
+	require.NotNil(t, match)
+	length, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	assert.Equal(t, len(match[2]), length)
+}
+
+func TestTextWidth(t *testing.T) {
+	// H 722 + e 556 + l 222 + l 222 + o 556 = 2278 thousandths
+	assert.InDelta(t, 27.336, TextWidth(Helvetica, 12, "Hello"), 0.0001)
+	// H 722 + e 556 + l 278 + l 278 + o 611 in bold
+	assert.InDelta(t, 29.34, TextWidth(HelveticaBold, 12, "Hello"), 0.0001)
+	assert.InDelta(t, 0, TextWidth(Helvetica, 12, ""), 0.0001)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Short", Truncate(Helvetica, 10, "Short", 100))
+	long := "Reimbursement for a very long business trip description"
+	truncated := Truncate(Helvetica, 10, long, 100)
+	assert.LessOrEqual(t, TextWidth(Helvetica, 10, truncated), 100.0)
+	assert.Regexp(t, `^Reimbursement.*\.\.\.$`, truncated)
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "caf\xe9 \x80 ?", encode("café € 日"))
+	assert.Equal(t, "a b", encode("a\nb"))
+}
+
+func TestParseHexColor(t *testing.T) {
+	c, err := ParseHexColor("#1F4E79")
+	require.NoError(t, err)
+	assert.Equal(t, Color{0x1f, 0x4e, 0x79}, c)
+
+	for _, bad := range []string{"1F4E79", "#1F4E7", "#GGGGGG", ""} {
+		_, err := ParseHexColor(bad)
+		assert.Error(t, err, bad)
+	}
+}
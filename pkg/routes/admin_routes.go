@@ -17,8 +17,76 @@ func SetupAdminRoutes(api fiber.Router) {
 	adminProtectedGroup := api.Group("", middleware.RequireLoggedIn(), middleware.RequireUserType("admin"))
 
 	adminProtectedGroup.Post("/attendance-periods", controllers.CreateAttendancePeriod)
+	adminProtectedGroup.Get("/attendance", controllers.ListAttendanceRecords)
+	adminProtectedGroup.Post("/attendance/import", controllers.ImportAttlog)
+	adminProtectedGroup.Post("/attendance/:id/check-out", controllers.ResolveCheckOut)
+	adminProtectedGroup.Get("/attendance-corrections", controllers.ListAttendanceCorrections)
+	adminProtectedGroup.Post("/attendance-corrections/:id/approve", controllers.ApproveAttendanceCorrection)
+	adminProtectedGroup.Post("/attendance-corrections/:id/reject", controllers.RejectAttendanceCorrection)
 	adminProtectedGroup.Post("/payroll", controllers.RunPayroll)
+	adminProtectedGroup.Post("/payroll/preview", controllers.PreviewPayroll)
+	adminProtectedGroup.Post("/payroll/void", controllers.VoidPayroll)
+	adminProtectedGroup.Get("/payroll/runs/:id", controllers.GetPayrollRun)
 	adminProtectedGroup.Get("/payslips-summary", controllers.GetPayslipsSummary)
+	adminProtectedGroup.Get("/payslips.pdf", controllers.GetPayslipsPDF)
+
+	adminProtectedGroup.Post("/employees", controllers.CreateEmployee)
+	adminProtectedGroup.Get("/employees", controllers.ListEmployees)
+	adminProtectedGroup.Post("/employees/import", controllers.ImportEmployees)
+	adminProtectedGroup.Get("/employees/:id", controllers.GetEmployee)
+	adminProtectedGroup.Put("/employees/:id", controllers.UpdateEmployee)
+	adminProtectedGroup.Post("/employees/:id/deactivate", controllers.DeactivateEmployee)
+	adminProtectedGroup.Post("/employees/:id/reactivate", controllers.ReactivateEmployee)
+	adminProtectedGroup.Get("/employees/:id/salary-history", controllers.ListSalaryHistory)
+	adminProtectedGroup.Post("/employees/:id/salary-history", controllers.CreateSalaryChange)
+	adminProtectedGroup.Get("/employees/:id/tax-profile", controllers.GetTaxProfile)
+	adminProtectedGroup.Put("/employees/:id/tax-profile", controllers.UpdateTaxProfile)
+	adminProtectedGroup.Get("/employees/:id/leave-balances", controllers.GetEmployeeLeaveBalances)
+
+	adminProtectedGroup.Get("/device-pins", controllers.ListDevicePINs)
+	adminProtectedGroup.Post("/device-pins", controllers.CreateDevicePIN)
+	adminProtectedGroup.Delete("/device-pins/:id", controllers.DeleteDevicePIN)
+
+	adminProtectedGroup.Get("/holidays", controllers.ListHolidays)
+	adminProtectedGroup.Post("/holidays", controllers.CreateHoliday)
+	adminProtectedGroup.Post("/holidays/import", controllers.ImportHolidays)
+	adminProtectedGroup.Delete("/holidays/:id", controllers.DeleteHoliday)
+
+	adminProtectedGroup.Get("/work-schedules", controllers.ListWorkSchedules)
+	adminProtectedGroup.Post("/work-schedules", controllers.CreateWorkSchedule)
+	adminProtectedGroup.Put("/work-schedules/:id", controllers.UpdateWorkSchedule)
+	adminProtectedGroup.Delete("/work-schedules/:id", controllers.DeleteWorkSchedule)
+
+	adminProtectedGroup.Get("/leave-types", controllers.ListLeaveTypes)
+	adminProtectedGroup.Post("/leave-types", controllers.CreateLeaveType)
+	adminProtectedGroup.Put("/leave-types/:id", controllers.UpdateLeaveType)
+	adminProtectedGroup.Delete("/leave-types/:id", controllers.DeleteLeaveType)
+	adminProtectedGroup.Get("/leave-requests", controllers.ListLeaveRequests)
+	adminProtectedGroup.Post("/leave-requests/:id/approve", controllers.ApproveLeaveRequest)
+	adminProtectedGroup.Post("/leave-requests/:id/reject", controllers.RejectLeaveRequest)
+
+	adminProtectedGroup.Get("/overtime", controllers.ListOvertimeRecords)
+	adminProtectedGroup.Post("/overtime/:id/approve", controllers.ApproveOvertime)
+	adminProtectedGroup.Post("/overtime/:id/reject", controllers.RejectOvertime)
+	adminProtectedGroup.Get("/overtime-policies", controllers.ListOvertimePolicies)
+	adminProtectedGroup.Post("/overtime-policies", controllers.CreateOvertimePolicy)
+	adminProtectedGroup.Get("/overtime-policies/effective", controllers.GetEffectiveOvertimePolicy)
+	adminProtectedGroup.Get("/payroll-policies", controllers.ListPayrollPolicies)
+	adminProtectedGroup.Post("/payroll-policies", controllers.CreatePayrollPolicy)
+	adminProtectedGroup.Get("/payroll-policies/effective", controllers.GetEffectivePayrollPolicy)
+	adminProtectedGroup.Get("/tax-tables", controllers.ListTaxTables)
+	adminProtectedGroup.Get("/social-security-rates", controllers.ListSocialSecurityRates)
+	adminProtectedGroup.Get("/contributions-report", controllers.GetContributionReport)
+
+	adminProtectedGroup.Get("/reimbursement-categories", controllers.ListReimbursementCategories)
+	adminProtectedGroup.Post("/reimbursement-categories", controllers.CreateReimbursementCategory)
+	adminProtectedGroup.Put("/reimbursement-categories/:id", controllers.UpdateReimbursementCategory)
+	adminProtectedGroup.Delete("/reimbursement-categories/:id", controllers.DeleteReimbursementCategory)
+	adminProtectedGroup.Get("/reimbursements", controllers.ListReimbursements)
+	adminProtectedGroup.Post("/reimbursements/:id/approve", controllers.ApproveReimbursement)
+	adminProtectedGroup.Post("/reimbursements/:id/reject", controllers.RejectReimbursement)
+	adminProtectedGroup.Get("/reimbursements/:id/receipts", controllers.ListReimbursementReceipts)
+	adminProtectedGroup.Get("/reimbursements/:id/receipts/:receiptId", controllers.DownloadReimbursementReceipt)
 
 	// Example of another protected route:
 	// adminProtectedGroup.Get("/dashboard", func(c *fiber.Ctx) error {
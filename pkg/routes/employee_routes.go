@@ -3,10 +3,31 @@ package routes
 import (
 	"payslip-generator/pkg/controllers"
 	"payslip-generator/pkg/middleware"
+	"payslip-generator/pkg/services"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// receiptUploadLimit lets the routes that take receipt files accept bodies up to the size of a full set of receipts
+var receiptUploadLimit = middleware.LimitBody(services.MaxReceiptUploadSize, nil)
+
+// AcceptsReceiptUpload reports whether the request is for a route that takes receipt files and applies its own body limit
+func AcceptsReceiptUpload(c *fiber.Ctx) bool {
+	if c.Method() != fiber.MethodPost {
+		return false
+	}
+	path := strings.TrimSuffix(c.Path(), "/")
+	if strings.HasSuffix(path, "/employee/reimbursements") {
+		return true
+	}
+	if rest, ok := strings.CutSuffix(path, "/receipts"); ok {
+		id := rest[strings.LastIndex(rest, "/")+1:]
+		return strings.HasSuffix(rest, "/employee/reimbursements/"+id)
+	}
+	return false
+}
+
 // SetupEmployeeRoutes sets up the employee routes
 func SetupEmployeeRoutes(api fiber.Router) {
 	// Public employee routes (e.g., login)
@@ -16,10 +37,27 @@ func SetupEmployeeRoutes(api fiber.Router) {
 	// This group applies RequireLoggedIn and then RequireUserType("employee")
 	employeeProtectedGroup := api.Group("", middleware.RequireLoggedIn(), middleware.RequireUserType("employee"))
 
+	employeeProtectedGroup.Get("/attendance", controllers.GetMyAttendanceCalendar)
 	employeeProtectedGroup.Post("/attendance", controllers.SubmitAttendance)
+	employeeProtectedGroup.Post("/attendance/check-out", controllers.CheckOut)
+	employeeProtectedGroup.Get("/attendance-corrections", controllers.ListMyAttendanceCorrections)
+	employeeProtectedGroup.Post("/attendance-corrections", controllers.SubmitAttendanceCorrection)
+	employeeProtectedGroup.Get("/overtime", controllers.ListMyOvertime)
 	employeeProtectedGroup.Post("/overtime", controllers.SubmitOvertime)
-	employeeProtectedGroup.Post("/reimbursements", controllers.SubmitReimbursement)
+	employeeProtectedGroup.Get("/reimbursement-categories", controllers.ListReimbursementCategories)
+	employeeProtectedGroup.Get("/reimbursements", controllers.ListMyReimbursements)
+	employeeProtectedGroup.Post("/reimbursements", receiptUploadLimit, controllers.SubmitReimbursement)
+	employeeProtectedGroup.Post("/reimbursements/:id/cancel", controllers.CancelMyReimbursement)
+	employeeProtectedGroup.Post("/reimbursements/:id/receipts", receiptUploadLimit, controllers.UploadReimbursementReceipts)
+	employeeProtectedGroup.Get("/reimbursements/:id/receipts", controllers.ListMyReimbursementReceipts)
+	employeeProtectedGroup.Get("/reimbursements/:id/receipts/:receiptId", controllers.DownloadMyReimbursementReceipt)
+	employeeProtectedGroup.Get("/leave-requests", controllers.ListMyLeaveRequests)
+	employeeProtectedGroup.Post("/leave-requests", controllers.SubmitLeaveRequest)
+	employeeProtectedGroup.Get("/leave-balances", controllers.GetMyLeaveBalances)
+	employeeProtectedGroup.Get("/payslips", controllers.ListMyPayslips)
 	employeeProtectedGroup.Get("/payslip", controllers.GetMyPayslip)
+	employeeProtectedGroup.Get("/payslip.pdf", controllers.GetMyPayslipPDF)
+	employeeProtectedGroup.Get("/tax-profile", controllers.GetMyTaxProfile)
 
 	// Example of another protected route:
 	// employeeProtectedGroup.Get("/profile", func(c *fiber.Ctx) error {
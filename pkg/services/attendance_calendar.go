@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Attendance calendar day statuses
+const (
+	CalendarPresent  = "present"  // Attendance was recorded, whatever the kind of day
+	CalendarLeave    = "leave"    // Approved leave on a working day
+	CalendarHoliday  = "holiday"  // Company holiday
+	CalendarRestDay  = "rest_day" // Not a working day of the employee's schedule
+	CalendarAbsent   = "absent"   // Past working day without attendance or leave
+	CalendarUpcoming = "upcoming" // Working day from today on without attendance yet
+)
+
+// CalendarDay is one day of an employee's attendance calendar
+type CalendarDay struct {
+	Date           string     `json:"date"` // YYYY-MM-DD
+	Weekday        string     `json:"weekday"`
+	Status         string     `json:"status"` // present, leave, holiday, rest_day, absent or upcoming
+	CheckInTime    *time.Time `json:"check_in_time,omitempty"`
+	CheckOutTime   *time.Time `json:"check_out_time,omitempty"`
+	CheckOutStatus string     `json:"check_out_status,omitempty"`
+	WorkedMinutes  *int       `json:"worked_minutes,omitempty"`
+	LeaveType      string     `json:"leave_type,omitempty"`
+	PaidLeave      *bool      `json:"paid_leave,omitempty"`
+	Holiday        string     `json:"holiday,omitempty"` // Holiday name
+}
+
+// CalendarSummary counts the days of an attendance calendar by status
+type CalendarSummary struct {
+	WorkingDays int `json:"working_days"` // Working days of the schedule that are not holidays
+	Present     int `json:"present"`
+	Leave       int `json:"leave"`
+	Absent      int `json:"absent"`
+	Upcoming    int `json:"upcoming"`
+	Holidays    int `json:"holidays"`
+	RestDays    int `json:"rest_days"`
+}
+
+// AttendanceCalendar is an employee's attendance over one attendance period, day by day
+type AttendanceCalendar struct {
+	PeriodID   uuid.UUID       `json:"period_id"`
+	StartDate  string          `json:"start_date"`
+	EndDate    string          `json:"end_date"`
+	PayrollRun bool            `json:"payroll_run"`
+	Summary    CalendarSummary `json:"summary"`
+	Days       []CalendarDay   `json:"days"`
+}
+
+// BuildAttendanceCalendar lays out a period day by day from the employee's attendance records and leave days,
+// the holidays and the working week. Days before today without attendance or leave are absences.
+func BuildAttendanceCalendar(period models.AttendancePeriod, week utils.WorkWeek, holidays []models.Holiday, records []models.AttendanceRecord, leaveDays []LeaveDay, today time.Time) AttendanceCalendar {
+	holidayNames := make(map[string]string, len(holidays))
+	for _, h := range holidays {
+		holidayNames[h.Date.Format("2006-01-02")] = h.Name
+	}
+	recordsByDate := make(map[string]models.AttendanceRecord, len(records))
+	for _, r := range records {
+		recordsByDate[r.Date.Format("2006-01-02")] = r
+	}
+	leaveByDate := make(map[string]LeaveDay, len(leaveDays))
+	for _, l := range leaveDays {
+		leaveByDate[l.Date.Format("2006-01-02")] = l
+	}
+	todayKey := today.Format("2006-01-02")
+
+	calendar := AttendanceCalendar{
+		PeriodID:   period.ID,
+		StartDate:  period.StartDate.Format("2006-01-02"),
+		EndDate:    period.EndDate.Format("2006-01-02"),
+		PayrollRun: period.PayrollRunAt != nil,
+		Days:       []CalendarDay{},
+	}
+	for d := period.StartDate; !d.After(period.EndDate); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		day := CalendarDay{Date: key, Weekday: d.Weekday().String()}
+		holiday, isHoliday := holidayNames[key]
+		working := week.Includes(d.Weekday()) && !isHoliday
+		if working {
+			calendar.Summary.WorkingDays++
+		}
+		if isHoliday {
+			day.Holiday = holiday
+		}
+
+		record, attended := recordsByDate[key]
+		leave, onLeave := leaveByDate[key]
+		switch {
+		case attended:
+			day.Status = CalendarPresent
+			checkIn := record.CheckInTime
+			day.CheckInTime = &checkIn
+			day.CheckOutTime = record.CheckOutTime
+			day.CheckOutStatus = record.CheckOutStatus
+			day.WorkedMinutes = record.WorkedMinutes
+			calendar.Summary.Present++
+		case isHoliday:
+			day.Status = CalendarHoliday
+			calendar.Summary.Holidays++
+		case !working:
+			day.Status = CalendarRestDay
+			calendar.Summary.RestDays++
+		case onLeave:
+			day.Status = CalendarLeave
+			paid := leave.Paid
+			day.LeaveType = leave.LeaveType
+			day.PaidLeave = &paid
+			calendar.Summary.Leave++
+		case key >= todayKey:
+			day.Status = CalendarUpcoming
+			calendar.Summary.Upcoming++
+		default:
+			day.Status = CalendarAbsent
+			calendar.Summary.Absent++
+		}
+		calendar.Days = append(calendar.Days, day)
+	}
+	return calendar
+}
+
+// LoadAttendanceCalendar builds the employee's attendance calendar for a period under their current work schedule
+func LoadAttendanceCalendar(db *gorm.DB, employee models.Employee, period models.AttendancePeriod, today time.Time) (AttendanceCalendar, error) {
+	schedule, err := ResolveWorkSchedule(db, employee)
+	if err != nil {
+		return AttendanceCalendar{}, err
+	}
+
+	var holidays []models.Holiday
+	if err := db.Where("date BETWEEN ? AND ?", period.StartDate, period.EndDate).Find(&holidays).Error; err != nil {
+		return AttendanceCalendar{}, fmt.Errorf("failed to load holidays: %w", err)
+	}
+	holidayDates := make([]time.Time, 0, len(holidays))
+	for _, h := range holidays {
+		holidayDates = append(holidayDates, h.Date)
+	}
+
+	var records []models.AttendanceRecord
+	if err := db.Where("employee_id = ? AND date BETWEEN ? AND ?", employee.ID, period.StartDate, period.EndDate).Find(&records).Error; err != nil {
+		return AttendanceCalendar{}, fmt.Errorf("failed to fetch attendance records: %w", err)
+	}
+
+	var leaveRequests []models.LeaveRequest
+	if err := db.Preload("LeaveType").
+		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", employee.ID, models.LeaveApproved, period.EndDate, period.StartDate).
+		Order("start_date").
+		Find(&leaveRequests).Error; err != nil {
+		return AttendanceCalendar{}, fmt.Errorf("failed to fetch leave requests: %w", err)
+	}
+	leaveDays := ExpandLeaveDays(leaveRequests, period.StartDate, period.EndDate, schedule.WorkingDays, utils.NewHolidaySet(holidayDates...))
+
+	return BuildAttendanceCalendar(period, schedule.WorkingDays, holidays, records, leaveDays, today), nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAttendanceCalendar(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.April, d, 0, 0, 0, 0, time.UTC) }
+	// Monday 1 April to Sunday 7 April 2024
+	period := models.AttendancePeriod{StartDate: day(1), EndDate: day(7)}
+	worked := 480
+	checkOut := day(1).Add(17 * time.Hour)
+	records := []models.AttendanceRecord{
+		{Date: day(1), CheckInTime: day(1).Add(9 * time.Hour), CheckOutTime: &checkOut, CheckOutStatus: models.CheckOutRecorded, WorkedMinutes: &worked},
+		{Date: day(6), CheckInTime: day(6).Add(9 * time.Hour), CheckOutStatus: models.CheckOutOpen}, // Saturday, e.g. imported from a terminal
+	}
+	holidays := []models.Holiday{{Date: day(4), Name: "Company Day"}}
+	leave := []LeaveDay{{Date: day(2), LeaveType: "Annual", Paid: true}}
+
+	calendar := BuildAttendanceCalendar(period, utils.StandardWorkWeek, holidays, records, leave, day(5))
+	require.Len(t, calendar.Days, 7)
+	statuses := make([]string, 0, 7)
+	for _, d := range calendar.Days {
+		statuses = append(statuses, d.Status)
+	}
+	assert.Equal(t, []string{CalendarPresent, CalendarLeave, CalendarAbsent, CalendarHoliday, CalendarUpcoming, CalendarPresent, CalendarRestDay}, statuses)
+
+	assert.Equal(t, "2024-04-01", calendar.Days[0].Date)
+	assert.Equal(t, "Monday", calendar.Days[0].Weekday)
+	require.NotNil(t, calendar.Days[0].WorkedMinutes)
+	assert.Equal(t, 480, *calendar.Days[0].WorkedMinutes)
+	assert.Equal(t, "Annual", calendar.Days[1].LeaveType)
+	require.NotNil(t, calendar.Days[1].PaidLeave)
+	assert.True(t, *calendar.Days[1].PaidLeave)
+	assert.Equal(t, "Company Day", calendar.Days[3].Holiday)
+	assert.False(t, calendar.PayrollRun)
+
+	assert.Equal(t, CalendarSummary{WorkingDays: 4, Present: 2, Leave: 1, Absent: 1, Upcoming: 1, Holidays: 1, RestDays: 1}, calendar.Summary)
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils" // For logger
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Missing check-out policies, selected with MISSING_CHECKOUT_POLICY
+const (
+	MissingCheckOutFlag      = "flag"       // Mark the record for admin review
+	MissingCheckOutAutoClose = "auto_close" // Close the record at the end of the scheduled working day
+)
+
+var (
+	// ErrCheckOutMissing is returned when hours are needed from a record that has no check-out yet
+	ErrCheckOutMissing = errors.New("attendance has no check-out")
+	// ErrInsufficientWorkedHours is returned when recorded hours do not cover the claimed overtime
+	ErrInsufficientWorkedHours = errors.New("recorded hours do not cover the overtime")
+	// ErrOvertimeOutsideAttendance is returned when an overtime window is not between check-in and check-out
+	ErrOvertimeOutsideAttendance = errors.New("overtime is outside the recorded attendance")
+)
+
+// ScheduledCheckOut returns the check-out time implied by the work schedule: check-in plus the
+// scheduled daily hours, but never past the end of the attendance day.
+func ScheduledCheckOut(record models.AttendanceRecord, schedule models.WorkSchedule) time.Time {
+	checkIn := record.CheckInTime
+	checkOut := checkIn.Add(time.Duration(schedule.HoursPerDay * float64(time.Hour)))
+	endOfDay := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 23, 59, 59, 0, checkIn.Location())
+	if checkOut.After(endOfDay) {
+		return endOfDay
+	}
+	return checkOut
+}
+
+// ApplyCheckOut sets the check-out time, status and worked minutes on a record
+func ApplyCheckOut(record *models.AttendanceRecord, checkOut time.Time, status string) {
+	record.CheckOutTime = &checkOut
+	record.CheckOutStatus = status
+	minutes := int(record.WorkedDuration() / time.Minute)
+	record.WorkedMinutes = &minutes
+}
+
+// CloseMissingCheckOuts applies the missing check-out policy to records dated before the given day
+// that are still open. It returns the number of records changed.
+func CloseMissingCheckOuts(db *gorm.DB, policy string, before time.Time) (int, error) {
+	if policy != MissingCheckOutFlag && policy != MissingCheckOutAutoClose {
+		return 0, fmt.Errorf("unknown missing check-out policy %q", policy)
+	}
+	day := time.Date(before.Year(), before.Month(), before.Day(), 0, 0, 0, 0, before.Location())
+
+	var records []models.AttendanceRecord
+	if err := db.Preload("Employee.WorkSchedule").
+		Where("check_out_status = ? AND date < ?", models.CheckOutOpen, day).
+		Find(&records).Error; err != nil {
+		return 0, fmt.Errorf("failed to load open attendance records: %w", err)
+	}
+
+	closed := 0
+	for i := range records {
+		record := &records[i]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if policy == MissingCheckOutAutoClose {
+				schedule, err := ResolveWorkSchedule(tx, record.Employee)
+				if err != nil {
+					return err
+				}
+				ApplyCheckOut(record, ScheduledCheckOut(*record, schedule), models.CheckOutAutoClosed)
+			} else {
+				record.CheckOutStatus = models.CheckOutFlagged
+			}
+
+			// Guard on the status so a check-out recorded meanwhile is not overwritten
+			res := tx.Model(&models.AttendanceRecord{}).
+				Where("id = ? AND check_out_status = ?", record.ID, models.CheckOutOpen).
+				Updates(map[string]interface{}{
+					"check_out_time":   record.CheckOutTime,
+					"check_out_status": record.CheckOutStatus,
+					"worked_minutes":   record.WorkedMinutes,
+				})
+			if res.Error != nil {
+				return fmt.Errorf("failed to update attendance record %s: %w", record.ID, res.Error)
+			}
+			if res.RowsAffected == 0 {
+				return nil
+			}
+			closed++
+
+			NewAuditService(tx).CreateAuditLog(AuditLogEntryParams{
+				UserType:         "system",
+				Action:           "close_missing_check_out",
+				TargetResource:   "attendance_record",
+				TargetResourceID: record.ID,
+				Changes:          map[string]interface{}{"policy": policy, "check_out_status": record.CheckOutStatus, "check_out_time": record.CheckOutTime, "worked_minutes": record.WorkedMinutes},
+			})
+			return nil
+		})
+		if err != nil {
+			return closed, err
+		}
+	}
+	return closed, nil
+}
+
+// StartAttendanceCloser applies the missing check-out policy now and then on every interval until ctx is done.
+func StartAttendanceCloser(ctx context.Context, db *gorm.DB, policy string, interval time.Duration) {
+	run := func() {
+		closed, err := CloseMissingCheckOuts(db, policy, time.Now())
+		if err != nil {
+			utils.Logger.Error("Failed to close missing check-outs", zap.Error(err), zap.String("policy", policy))
+			return
+		}
+		if closed > 0 {
+			utils.Logger.Info("Closed missing check-outs", zap.Int("records", closed), zap.String("policy", policy))
+		}
+	}
+
+	go func() {
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+// ValidateOvertimeAgainstAttendance checks that the day's recorded hours cover the scheduled hours
+// plus the claimed overtime. Records without a check-out, or flagged for review, are rejected.
+func ValidateOvertimeAgainstAttendance(record models.AttendanceRecord, schedule models.WorkSchedule, overtimeMinutes int) error {
+	if record.CheckOutStatus != models.CheckOutRecorded && record.CheckOutStatus != models.CheckOutAutoClosed {
+		return ErrCheckOutMissing
+	}
+	overtime := time.Duration(overtimeMinutes) * time.Minute
+	required := time.Duration(schedule.HoursPerDay*float64(time.Hour)) + overtime
+	worked := record.WorkedDuration()
+	if worked < required {
+		return fmt.Errorf("%w: worked %s, need %s (%.2f scheduled hours + %s overtime)",
+			ErrInsufficientWorkedHours, formatHoursMinutes(worked), formatHoursMinutes(required), schedule.HoursPerDay, formatHoursMinutes(overtime))
+	}
+	return nil
+}
+
+// ValidateOvertimeWindow checks that overtime submitted with start and end times lies between the day's
+// check-in and check-out. The record must already have passed ValidateOvertimeAgainstAttendance.
+func ValidateOvertimeWindow(record models.AttendanceRecord, start, end time.Time) error {
+	if record.CheckOutTime == nil {
+		return ErrCheckOutMissing
+	}
+	if start.Before(record.CheckInTime) || end.After(*record.CheckOutTime) {
+		return fmt.Errorf("%w: %s to %s is not within %s to %s", ErrOvertimeOutsideAttendance,
+			start.Format("15:04"), end.Format("15:04"), record.CheckInTime.In(start.Location()).Format("15:04"), record.CheckOutTime.In(start.Location()).Format("15:04"))
+	}
+	return nil
+}
+
+func formatHoursMinutes(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func attendanceAt(checkIn time.Time, worked time.Duration, status string) models.AttendanceRecord {
+	record := models.AttendanceRecord{CheckInTime: checkIn, CheckOutStatus: status}
+	if status == models.CheckOutRecorded || status == models.CheckOutAutoClosed {
+		ApplyCheckOut(&record, checkIn.Add(worked), status)
+	}
+	return record
+}
+
+func TestScheduledCheckOut(t *testing.T) {
+	standard := models.StandardWorkSchedule()
+	testCases := []struct {
+		name     string
+		checkIn  time.Time
+		schedule models.WorkSchedule
+		expected time.Time
+	}{
+		{name: "Check-in plus scheduled hours", checkIn: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), schedule: standard, expected: time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)},
+		{name: "Fractional hours", checkIn: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC), schedule: models.WorkSchedule{HoursPerDay: 7.5}, expected: time.Date(2024, 1, 15, 15, 30, 0, 0, time.UTC)},
+		{name: "Capped at end of day", checkIn: time.Date(2024, 1, 15, 20, 0, 0, 0, time.UTC), schedule: standard, expected: time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			record := models.AttendanceRecord{CheckInTime: tc.checkIn}
+			assert.Equal(t, tc.expected, ScheduledCheckOut(record, tc.schedule))
+		})
+	}
+}
+
+func TestApplyCheckOut(t *testing.T) {
+	checkIn := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	record := models.AttendanceRecord{CheckInTime: checkIn, CheckOutStatus: models.CheckOutOpen}
+
+	ApplyCheckOut(&record, checkIn.Add(9*time.Hour+30*time.Minute+45*time.Second), models.CheckOutRecorded)
+
+	assert.Equal(t, models.CheckOutRecorded, record.CheckOutStatus)
+	if assert.NotNil(t, record.WorkedMinutes) {
+		assert.Equal(t, 570, *record.WorkedMinutes)
+	}
+	assert.Equal(t, 9*time.Hour+30*time.Minute+45*time.Second, record.WorkedDuration())
+}
+
+func TestValidateOvertimeAgainstAttendance(t *testing.T) {
+	checkIn := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	standard := models.StandardWorkSchedule()
+	testCases := []struct {
+		name          string
+		record        models.AttendanceRecord
+		schedule      models.WorkSchedule
+		minutes       int
+		expectedError error
+	}{
+		{name: "Worked hours cover overtime", record: attendanceAt(checkIn, 10*time.Hour, models.CheckOutRecorded), schedule: standard, minutes: 120},
+		{name: "Worked hours exceed overtime", record: attendanceAt(checkIn, 11*time.Hour+15*time.Minute, models.CheckOutRecorded), schedule: standard, minutes: 180},
+		{name: "Partial hour of overtime", record: attendanceAt(checkIn, 8*time.Hour+45*time.Minute, models.CheckOutRecorded), schedule: standard, minutes: 45},
+		{name: "A minute short", record: attendanceAt(checkIn, 8*time.Hour+44*time.Minute, models.CheckOutRecorded), schedule: standard, minutes: 45, expectedError: ErrInsufficientWorkedHours},
+		{name: "Shorter schedule", record: attendanceAt(checkIn, 8*time.Hour+30*time.Minute, models.CheckOutRecorded), schedule: models.WorkSchedule{HoursPerDay: 7.5}, minutes: 60},
+		{name: "Not enough hours", record: attendanceAt(checkIn, 9*time.Hour+59*time.Minute, models.CheckOutRecorded), schedule: standard, minutes: 120, expectedError: ErrInsufficientWorkedHours},
+		{name: "Auto-closed day has no overtime", record: attendanceAt(checkIn, 8*time.Hour, models.CheckOutAutoClosed), schedule: standard, minutes: 60, expectedError: ErrInsufficientWorkedHours},
+		{name: "Still open", record: attendanceAt(checkIn, 0, models.CheckOutOpen), schedule: standard, minutes: 60, expectedError: ErrCheckOutMissing},
+		{name: "Flagged for review", record: attendanceAt(checkIn, 0, models.CheckOutFlagged), schedule: standard, minutes: 60, expectedError: ErrCheckOutMissing},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateOvertimeAgainstAttendance(tc.record, tc.schedule, tc.minutes)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateOvertimeWindow(t *testing.T) {
+	checkIn := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	record := attendanceAt(checkIn, 10*time.Hour, models.CheckOutRecorded) // Until 19:00
+	at := func(hour, minute int) time.Time { return time.Date(2024, 1, 15, hour, minute, 0, 0, time.UTC) }
+
+	assert.NoError(t, ValidateOvertimeWindow(record, at(17, 0), at(19, 0)))
+	assert.ErrorIs(t, ValidateOvertimeWindow(record, at(17, 30), at(19, 15)), ErrOvertimeOutsideAttendance)
+	assert.ErrorIs(t, ValidateOvertimeWindow(record, at(8, 0), at(9, 30)), ErrOvertimeOutsideAttendance)
+	assert.ErrorIs(t, ValidateOvertimeWindow(attendanceAt(checkIn, 0, models.CheckOutOpen), at(17, 0), at(18, 0)), ErrCheckOutMissing)
+}
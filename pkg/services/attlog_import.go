@@ -0,0 +1,416 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxAttlogPunches caps the number of punches in a single attlog import
+const MaxAttlogPunches = 100000
+
+// attlogRepeatWindow is how soon after the first punch of the day a later punch is treated as a
+// repeated scan rather than a check-out
+const attlogRepeatWindow = time.Minute
+
+// Attlog day statuses
+const (
+	AttlogDayCreated    = "created"     // A new attendance record was created
+	AttlogDayCheckedOut = "checked_out" // An open record was given the imported check-out
+	AttlogDayDuplicate  = "duplicate"   // The day is already recorded, nothing changed
+	AttlogDaySkipped    = "skipped"     // The day cannot be recorded, see the reason
+)
+
+// ErrInvalidAttlogFile is returned when an attlog file cannot be parsed
+var ErrInvalidAttlogFile = errors.New("invalid attlog file")
+
+// AttlogPunch is one line of a fingerprint terminal's attlog export
+type AttlogPunch struct {
+	Line       int
+	PIN        string
+	Time       time.Time
+	VerifyMode int // Device specific, e.g. 0 password, 1 fingerprint, 15 face
+}
+
+// AttlogDay is the first and, when there is one, last punch of a PIN on a calendar day
+type AttlogDay struct {
+	PIN      string
+	Date     time.Time
+	CheckIn  time.Time
+	CheckOut *time.Time // Nil when the day has a single punch, or only repeats of the first one
+	Punches  int
+}
+
+// AttlogUnmappedPIN is a PIN in the file that has no employee mapping
+type AttlogUnmappedPIN struct {
+	PIN     string `json:"pin"`
+	Punches int    `json:"punches"`
+}
+
+// AttlogDayResult is the outcome of importing one AttlogDay
+type AttlogDayResult struct {
+	PIN                string     `json:"pin"`
+	EmployeeID         uuid.UUID  `json:"employee_id"`
+	Date               string     `json:"date"`
+	CheckInTime        time.Time  `json:"check_in_time"`
+	CheckOutTime       *time.Time `json:"check_out_time,omitempty"`
+	Status             string     `json:"status"` // created, checked_out, duplicate, skipped
+	Reason             string     `json:"reason,omitempty"`
+	AttendanceRecordID *uuid.UUID `json:"attendance_record_id,omitempty"`
+}
+
+// AttlogImportReport summarizes an attlog import
+type AttlogImportReport struct {
+	TotalPunches     int                 `json:"total_punches"`
+	DuplicatePunches int                 `json:"duplicate_punches"` // Lines repeating an earlier PIN and timestamp
+	Created          int                 `json:"created"`
+	CheckedOut       int                 `json:"checked_out"`
+	Duplicates       int                 `json:"duplicates"` // Days already recorded
+	Skipped          int                 `json:"skipped"`
+	UnmappedPINs     []AttlogUnmappedPIN `json:"unmapped_pins,omitempty"`
+	Days             []AttlogDayResult   `json:"days"`
+}
+
+// AttlogImportOptions controls an attlog import
+type AttlogImportOptions struct {
+	PerformedBy *uuid.UUID // Admin running the import, nil when run from the CLI
+	IPAddress   string
+	RequestID   string
+}
+
+// ParseAttlog reads a tab-separated attlog export: user PIN, timestamp (YYYY-MM-DD HH:MM:SS) and verify mode,
+// optionally followed by further device columns, which are ignored. Timestamps are in the terminal's local
+// time and are interpreted in loc.
+func ParseAttlog(r io.Reader, loc *time.Location) ([]AttlogPunch, error) {
+	var punches []AttlogPunch
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("%w: line %d: expected PIN, timestamp and verify mode separated by tabs", ErrInvalidAttlogFile, line)
+		}
+		pin := strings.TrimSpace(fields[0])
+		if pin == "" {
+			return nil, fmt.Errorf("%w: line %d: PIN is required", ErrInvalidAttlogFile, line)
+		}
+		timestamp, err := time.ParseInLocation("2006-01-02 15:04:05", strings.TrimSpace(fields[1]), loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid timestamp %q, use YYYY-MM-DD HH:MM:SS", ErrInvalidAttlogFile, line, fields[1])
+		}
+		verifyMode, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid verify mode %q", ErrInvalidAttlogFile, line, fields[2])
+		}
+
+		if len(punches) >= MaxAttlogPunches {
+			return nil, fmt.Errorf("%w: more than %d punches", ErrInvalidAttlogFile, MaxAttlogPunches)
+		}
+		punches = append(punches, AttlogPunch{Line: line, PIN: pin, Time: timestamp, VerifyMode: verifyMode})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAttlogFile, err)
+	}
+	if len(punches) == 0 {
+		return nil, fmt.Errorf("%w: no punches found", ErrInvalidAttlogFile)
+	}
+	return punches, nil
+}
+
+// GroupAttlogPunches folds punches into one AttlogDay per PIN and calendar day, sorted by PIN and date.
+// The first punch is the check-in and the last the check-out. It also returns how many punches repeat
+// an earlier PIN and timestamp exactly; those are not counted in AttlogDay.Punches.
+func GroupAttlogPunches(punches []AttlogPunch) ([]AttlogDay, int) {
+	type dayKey struct {
+		pin  string
+		date string
+	}
+	days := make(map[dayKey]*AttlogDay)
+	seen := make(map[string]bool)
+	duplicates := 0
+	for _, p := range punches {
+		punchKey := p.PIN + "|" + p.Time.Format(time.RFC3339)
+		if seen[punchKey] {
+			duplicates++
+			continue
+		}
+		seen[punchKey] = true
+
+		key := dayKey{pin: p.PIN, date: p.Time.Format("2006-01-02")}
+		day, ok := days[key]
+		if !ok {
+			date := time.Date(p.Time.Year(), p.Time.Month(), p.Time.Day(), 0, 0, 0, 0, p.Time.Location())
+			days[key] = &AttlogDay{PIN: p.PIN, Date: date, CheckIn: p.Time, Punches: 1}
+			continue
+		}
+		day.Punches++
+		last := day.CheckIn
+		if day.CheckOut != nil {
+			last = *day.CheckOut
+		}
+		switch {
+		case p.Time.Before(day.CheckIn):
+			// Out-of-order export: the old check-in becomes a candidate check-out
+			if day.CheckOut == nil {
+				day.CheckOut = &last
+			}
+			day.CheckIn = p.Time
+		case p.Time.After(last):
+			t := p.Time
+			day.CheckOut = &t
+		}
+	}
+
+	result := make([]AttlogDay, 0, len(days))
+	for _, day := range days {
+		if day.CheckOut != nil && day.CheckOut.Sub(day.CheckIn) < attlogRepeatWindow {
+			day.CheckOut = nil
+		}
+		result = append(result, *day)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].PIN != result[j].PIN {
+			return result[i].PIN < result[j].PIN
+		}
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result, duplicates
+}
+
+// AttlogImporter creates attendance records from fingerprint terminal exports
+type AttlogImporter struct {
+	DB *gorm.DB
+}
+
+// NewAttlogImporter creates a new instance of AttlogImporter.
+func NewAttlogImporter(db *gorm.DB) *AttlogImporter {
+	return &AttlogImporter{DB: db}
+}
+
+// Import records the punches in one transaction. PINs are mapped to employees through the device_pins table;
+// days on which the employee already has attendance are left alone unless the record is still open and the
+// file has a check-out for it, so importing the same file twice is harmless. Days that cannot be recorded,
+// such as non-working days or days in a period whose payroll has run, are skipped with a reason.
+func (i *AttlogImporter) Import(punches []AttlogPunch, opts AttlogImportOptions) (*AttlogImportReport, error) {
+	days, duplicatePunches := GroupAttlogPunches(punches)
+	report := &AttlogImportReport{TotalPunches: len(punches), DuplicatePunches: duplicatePunches, Days: []AttlogDayResult{}}
+	if len(days) == 0 {
+		return report, nil
+	}
+
+	err := i.DB.Transaction(func(tx *gorm.DB) error {
+		var mappings []models.DevicePIN
+		if err := tx.Preload("Employee.WorkSchedule").Find(&mappings).Error; err != nil {
+			return fmt.Errorf("failed to load device PINs: %w", err)
+		}
+		employees := make(map[string]models.Employee, len(mappings))
+		for _, m := range mappings {
+			employees[m.PIN] = m.Employee
+		}
+
+		start, end := days[0].Date, days[0].Date
+		for _, day := range days {
+			if day.Date.Before(start) {
+				start = day.Date
+			}
+			if day.Date.After(end) {
+				end = day.Date
+			}
+		}
+		holidays, err := NewHolidayService(tx).LoadHolidaySet(start, end)
+		if err != nil {
+			return err
+		}
+		var periods []models.AttendancePeriod
+		if err := tx.Where("start_date <= ? AND end_date >= ?", end, start).Find(&periods).Error; err != nil {
+			return fmt.Errorf("failed to load attendance periods: %w", err)
+		}
+
+		unmapped := make(map[string]int)
+		var createdIDs, checkedOutIDs []uuid.UUID
+		for _, day := range days {
+			employee, ok := employees[day.PIN]
+			if !ok {
+				unmapped[day.PIN] += day.Punches
+				continue
+			}
+			result, err := i.importDay(tx, day, employee, holidays, periods, opts)
+			if err != nil {
+				return err
+			}
+			switch result.Status {
+			case AttlogDayCreated:
+				report.Created++
+				createdIDs = append(createdIDs, *result.AttendanceRecordID)
+			case AttlogDayCheckedOut:
+				report.CheckedOut++
+				checkedOutIDs = append(checkedOutIDs, *result.AttendanceRecordID)
+			case AttlogDayDuplicate:
+				report.Duplicates++
+			case AttlogDaySkipped:
+				report.Skipped++
+			}
+			report.Days = append(report.Days, result)
+		}
+		for pin, count := range unmapped {
+			report.UnmappedPINs = append(report.UnmappedPINs, AttlogUnmappedPIN{PIN: pin, Punches: count})
+		}
+		sort.Slice(report.UnmappedPINs, func(a, b int) bool { return report.UnmappedPINs[a].PIN < report.UnmappedPINs[b].PIN })
+
+		userType := "admin"
+		if opts.PerformedBy == nil {
+			userType = "system"
+		}
+		NewAuditService(tx).CreateAuditLog(AuditLogEntryParams{
+			UserID:         derefUUID(opts.PerformedBy),
+			UserType:       userType,
+			Action:         "import_attlog",
+			TargetResource: "attendance_record",
+			Changes: map[string]interface{}{
+				"total_punches":          report.TotalPunches,
+				"created":                report.Created,
+				"checked_out":            report.CheckedOut,
+				"duplicates":             report.Duplicates,
+				"skipped":                report.Skipped,
+				"unmapped_pins":          len(report.UnmappedPINs),
+				"created_record_ids":     createdIDs,
+				"checked_out_record_ids": checkedOutIDs,
+			},
+			IPAddress:   opts.IPAddress,
+			RequestID:   opts.RequestID,
+			PerformedBy: derefUUID(opts.PerformedBy),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// importDay records one employee day, or explains why it was not recorded
+func (i *AttlogImporter) importDay(tx *gorm.DB, day AttlogDay, employee models.Employee, holidays utils.HolidaySet, periods []models.AttendancePeriod, opts AttlogImportOptions) (AttlogDayResult, error) {
+	result := AttlogDayResult{
+		PIN:          day.PIN,
+		EmployeeID:   employee.ID,
+		Date:         day.Date.Format("2006-01-02"),
+		CheckInTime:  day.CheckIn,
+		CheckOutTime: day.CheckOut,
+	}
+	skip := func(reason string) (AttlogDayResult, error) {
+		result.Status = AttlogDaySkipped
+		result.Reason = reason
+		return result, nil
+	}
+
+	if employee.DeactivatedAt != nil && !day.Date.Before(*employee.DeactivatedAt) {
+		return skip("employee is deactivated")
+	}
+	schedule, err := ResolveWorkSchedule(tx, employee)
+	if err != nil {
+		return result, fmt.Errorf("failed to load work schedule for employee %s: %w", employee.ID, err)
+	}
+	if !schedule.WorkingDays.Includes(day.Date.Weekday()) {
+		return skip(fmt.Sprintf("%s is not a working day of the work schedule (%s)", day.Date.Weekday(), schedule.Name))
+	}
+	if holidays.Contains(day.Date) {
+		return skip("date is a holiday")
+	}
+	var period *models.AttendancePeriod
+	for idx := range periods {
+		p := &periods[idx]
+		if !day.Date.Before(p.StartDate) && !day.Date.After(p.EndDate) {
+			period = p
+			break
+		}
+	}
+	if period == nil {
+		return skip("no attendance period covers the date")
+	}
+	if period.PayrollRunAt != nil {
+		return skip("payroll has been run for the attendance period")
+	}
+
+	var existing models.AttendanceRecord
+	err = tx.Where("employee_id = ? AND date = ?", employee.ID, result.Date).First(&existing).Error
+	switch {
+	case err == nil:
+		result.AttendanceRecordID = &existing.ID
+		if existing.CheckOutStatus != models.CheckOutOpen || day.CheckOut == nil || !day.CheckOut.After(existing.CheckInTime) {
+			result.Status = AttlogDayDuplicate
+			return result, nil
+		}
+		ApplyCheckOut(&existing, *day.CheckOut, models.CheckOutRecorded)
+		updates := map[string]interface{}{
+			"check_out_time":   existing.CheckOutTime,
+			"check_out_status": existing.CheckOutStatus,
+			"worked_minutes":   existing.WorkedMinutes,
+			"updated_by":       opts.PerformedBy,
+		}
+		if opts.IPAddress != "" {
+			updates["ip_address"] = opts.IPAddress
+		}
+		res := tx.Model(&models.AttendanceRecord{}).
+			Where("id = ? AND check_out_status = ?", existing.ID, models.CheckOutOpen).
+			Updates(updates)
+		if res.Error != nil {
+			return result, fmt.Errorf("could not record check-out for %s on %s: %w", day.PIN, result.Date, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			result.Status = AttlogDayDuplicate
+			return result, nil
+		}
+		result.Status = AttlogDayCheckedOut
+		return result, nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return result, fmt.Errorf("failed to look up attendance for %s on %s: %w", day.PIN, result.Date, err)
+	}
+
+	record := models.AttendanceRecord{
+		EmployeeID:         employee.ID,
+		AttendancePeriodID: period.ID,
+		Date:               day.Date,
+		CheckInTime:        day.CheckIn,
+		CheckOutStatus:     models.CheckOutOpen,
+	}
+	if day.CheckOut != nil {
+		ApplyCheckOut(&record, *day.CheckOut, models.CheckOutRecorded)
+	}
+	record.CreatedBy = opts.PerformedBy
+	record.UpdatedBy = opts.PerformedBy
+	if opts.IPAddress != "" {
+		record.IPAddress = &opts.IPAddress
+	}
+	// A record created concurrently for the same day hits uix_employee_date and is reported as a duplicate
+	res := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if res.Error != nil {
+		return result, fmt.Errorf("could not create attendance for %s on %s: %w", day.PIN, result.Date, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		result.Status = AttlogDayDuplicate
+		return result, nil
+	}
+	result.Status = AttlogDayCreated
+	result.AttendanceRecordID = &record.ID
+	return result, nil
+}
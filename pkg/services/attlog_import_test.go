@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAttlog(t *testing.T) {
+	data := "\ufeff     1\t2024-01-15 08:59:12\t1\t0\t1\t0\r\n" +
+		"\n" +
+		"    23\t2024-01-15 09:03:40\t15\n"
+	punches, err := ParseAttlog(strings.NewReader(data), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, punches, 2)
+	assert.Equal(t, "1", punches[0].PIN)
+	assert.Equal(t, time.Date(2024, time.January, 15, 8, 59, 12, 0, time.UTC), punches[0].Time)
+	assert.Equal(t, 1, punches[0].VerifyMode)
+	assert.Equal(t, 1, punches[0].Line)
+	assert.Equal(t, "23", punches[1].PIN)
+	assert.Equal(t, 15, punches[1].VerifyMode)
+	assert.Equal(t, 3, punches[1].Line)
+
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "Comma separated", data: "1,2024-01-15 08:59:12,1\n"},
+		{name: "Missing verify mode", data: "1\t2024-01-15 08:59:12\n"},
+		{name: "Bad timestamp", data: "1\t15/01/2024 08:59\t1\n"},
+		{name: "Bad verify mode", data: "1\t2024-01-15 08:59:12\tfinger\n"},
+		{name: "Empty PIN", data: " \t2024-01-15 08:59:12\t1\n"},
+		{name: "Empty file", data: "\n\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseAttlog(strings.NewReader(tc.data), time.UTC)
+			assert.True(t, errors.Is(err, ErrInvalidAttlogFile), "got %v", err)
+		})
+	}
+}
+
+func TestGroupAttlogPunches(t *testing.T) {
+	at := func(day, hour, minute, second int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, second, 0, time.UTC)
+	}
+	punches := []AttlogPunch{
+		{PIN: "7", Time: at(15, 17, 31, 0)},
+		{PIN: "7", Time: at(15, 12, 0, 0)},
+		{PIN: "7", Time: at(15, 8, 58, 0)},
+		{PIN: "7", Time: at(15, 8, 58, 0)}, // Exact repeat
+		{PIN: "7", Time: at(16, 9, 0, 0)},
+		{PIN: "7", Time: at(16, 9, 0, 30)}, // Second scan at the same check-in
+		{PIN: "3", Time: at(16, 8, 45, 0)},
+	}
+
+	days, duplicates := GroupAttlogPunches(punches)
+	assert.Equal(t, 1, duplicates)
+	require.Len(t, days, 3)
+
+	assert.Equal(t, "3", days[0].PIN)
+	assert.Nil(t, days[0].CheckOut, "A single punch has no check-out")
+
+	assert.Equal(t, "7", days[1].PIN)
+	assert.Equal(t, at(15, 0, 0, 0), days[1].Date)
+	assert.Equal(t, at(15, 8, 58, 0), days[1].CheckIn)
+	require.NotNil(t, days[1].CheckOut)
+	assert.Equal(t, at(15, 17, 31, 0), *days[1].CheckOut)
+	assert.Equal(t, 3, days[1].Punches)
+
+	assert.Equal(t, at(16, 9, 0, 0), days[2].CheckIn)
+	assert.Nil(t, days[2].CheckOut, "Punches within a minute of the check-in are repeats")
+	assert.Equal(t, 2, days[2].Punches)
+}
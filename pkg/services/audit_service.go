@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -60,7 +61,7 @@ func (s *AuditService) CreateAuditLog(params AuditLogEntryParams) error {
 		Action:           params.Action,
 		TargetResource:   params.TargetResource,
 		TargetResourceID: params.TargetResourceID, // Can be Nil if not applicable
-		Changes:          changesJSON,
+		Changes:          datatypes.JSON(changesJSON),
 		IPAddress:        params.IPAddress,
 		RequestID:        params.RequestID,
 		// PerformedBy: params.PerformedBy, // Add this field to models.AuditLog if needed to distinguish actor from affected user
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxEmployeeImportRows caps the size of a single employee import
+const MaxEmployeeImportRows = 5000
+
+// Employee import row statuses
+const (
+	ImportRowValid   = "valid"
+	ImportRowInvalid = "invalid"
+	ImportRowCreated = "created"
+)
+
+var employeeImportColumns = map[string]bool{"username": true, "password": true, "salary": true}
+
+// EmployeeImportOptions controls an employee import
+type EmployeeImportOptions struct {
+	DryRun      bool
+	PerformedBy *uuid.UUID // Admin running the import, nil when run from the CLI
+	IPAddress   string
+	RequestID   string
+}
+
+// EmployeeImportRow is the validation result for one CSV line
+type EmployeeImportRow struct {
+	Line              int          `json:"line"` // 1-based line number in the file, header is line 1
+	Username          string       `json:"username"`
+	Salary            models.Money `json:"salary"`
+	Status            string       `json:"status"` // valid, invalid, created
+	Errors            []string     `json:"errors,omitempty"`
+	EmployeeID        uuid.UUID    `json:"employee_id,omitempty"`
+	Invited           bool         `json:"invited"`                      // No password was supplied, a temporary one was generated
+	TemporaryPassword string       `json:"temporary_password,omitempty"` // Only returned once, after a real import
+}
+
+// EmployeeImportReport summarizes an employee import
+type EmployeeImportReport struct {
+	DryRun      bool                `json:"dry_run"`
+	TotalRows   int                 `json:"total_rows"`
+	ValidRows   int                 `json:"valid_rows"`
+	InvalidRows int                 `json:"invalid_rows"`
+	CreatedRows int                 `json:"created_rows"`
+	Warnings    []string            `json:"warnings,omitempty"`
+	Rows        []EmployeeImportRow `json:"rows"`
+}
+
+// ErrInvalidEmployeeImport is returned when the file is malformed or any row fails validation.
+// Nothing is written in that case; the report explains what to fix.
+var ErrInvalidEmployeeImport = errors.New("employee import contains invalid rows")
+
+// EmployeeImporter creates employees in bulk from CSV files
+type EmployeeImporter struct {
+	DB *gorm.DB
+}
+
+// NewEmployeeImporter creates a new instance of EmployeeImporter.
+func NewEmployeeImporter(db *gorm.DB) *EmployeeImporter {
+	return &EmployeeImporter{DB: db}
+}
+
+// Import reads a CSV with a header row containing username, salary and optionally password columns.
+// All rows are validated first; employees are only created, in one transaction, when every row is valid
+// and DryRun is false. Rows without a password get a generated temporary password.
+func (i *EmployeeImporter) Import(r io.Reader, opts EmployeeImportOptions) (*EmployeeImportReport, error) {
+	report := &EmployeeImportReport{DryRun: opts.DryRun}
+	passwords, err := i.parse(r, report)
+	if err != nil {
+		return report, err
+	}
+	if err := i.checkExistingUsernames(report); err != nil {
+		return report, err
+	}
+
+	for _, row := range report.Rows {
+		if row.Status == ImportRowValid {
+			report.ValidRows++
+		} else {
+			report.InvalidRows++
+		}
+	}
+	if report.InvalidRows > 0 {
+		return report, ErrInvalidEmployeeImport
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	err = i.DB.Transaction(func(tx *gorm.DB) error {
+		var createdIDs []uuid.UUID
+		for idx := range report.Rows {
+			row := &report.Rows[idx]
+			password := passwords[idx]
+			if password == "" {
+				generated, err := generateTemporaryPassword()
+				if err != nil {
+					return err
+				}
+				password = generated
+				row.TemporaryPassword = generated
+			}
+			hashedPassword, err := utils.HashPassword(password)
+			if err != nil {
+				return fmt.Errorf("line %d: could not hash password: %w", row.Line, err)
+			}
+
+			employee := models.Employee{Username: row.Username, Password: hashedPassword, Salary: row.Salary}
+			employee.CreatedBy = opts.PerformedBy
+			employee.UpdatedBy = opts.PerformedBy
+			if opts.IPAddress != "" {
+				employee.IPAddress = &opts.IPAddress
+			}
+			if err := tx.Create(&employee).Error; err != nil {
+				return fmt.Errorf("line %d: could not create employee: %w", row.Line, err)
+			}
+			row.EmployeeID = employee.ID
+			row.Status = ImportRowCreated
+			createdIDs = append(createdIDs, employee.ID)
+		}
+		report.CreatedRows = len(createdIDs)
+
+		userType := "admin"
+		if opts.PerformedBy == nil {
+			userType = "system"
+		}
+		NewAuditService(tx).CreateAuditLog(AuditLogEntryParams{
+			UserID:         derefUUID(opts.PerformedBy),
+			UserType:       userType,
+			Action:         "import_employees",
+			TargetResource: "employee",
+			Changes:        map[string]interface{}{"created": report.CreatedRows, "employee_ids": createdIDs},
+			IPAddress:      opts.IPAddress,
+			RequestID:      opts.RequestID,
+			PerformedBy:    derefUUID(opts.PerformedBy),
+		})
+		return nil
+	})
+	if err != nil {
+		for idx := range report.Rows {
+			report.Rows[idx].Status = ImportRowValid
+			report.Rows[idx].EmployeeID = uuid.Nil
+			report.Rows[idx].TemporaryPassword = ""
+		}
+		report.CreatedRows = 0
+		return report, err
+	}
+	return report, nil
+}
+
+// parse reads and validates every row, returning the plain passwords aligned with report.Rows
+func (i *EmployeeImporter) parse(r io.Reader, report *EmployeeImportReport) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Report short rows ourselves instead of aborting
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidEmployeeImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read header: %v", ErrInvalidEmployeeImport, err)
+	}
+
+	columns := make(map[string]int)
+	for idx, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !employeeImportColumns[name] {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("column %q is not supported and was ignored", name))
+			continue
+		}
+		columns[name] = idx
+	}
+	for _, required := range []string{"username", "salary"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing required column %q", ErrInvalidEmployeeImport, required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		idx, ok := columns[name]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	var passwords []string
+	seen := make(map[string]int) // username -> first line it appeared on
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidEmployeeImport, err)
+		}
+		line, _ := reader.FieldPos(0) // Blank lines are skipped by the reader, so ask it where we are
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue // Skip whitespace-only lines
+		}
+		if len(report.Rows) >= MaxEmployeeImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidEmployeeImport, MaxEmployeeImportRows)
+		}
+
+		row := EmployeeImportRow{Line: line, Username: NormalizeUsername(field(record, "username"))}
+		if err := ValidateUsername(row.Username); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		} else if first, dup := seen[row.Username]; dup {
+			row.Errors = append(row.Errors, fmt.Sprintf("duplicate username, already used on line %d", first))
+		} else {
+			seen[row.Username] = line
+		}
+
+		salaryStr := field(record, "salary")
+		if salaryStr == "" {
+			row.Errors = append(row.Errors, "salary is required")
+		} else if salary, err := models.ParseMoney(salaryStr); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("salary %q is not a number", salaryStr))
+		} else if err := ValidateSalary(salary); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		} else {
+			row.Salary = salary
+		}
+
+		password := field(record, "password")
+		if password == "" {
+			row.Invited = true
+		} else if err := ValidatePassword(password); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+
+		row.Status = ImportRowValid
+		if len(row.Errors) > 0 {
+			row.Status = ImportRowInvalid
+		}
+		report.Rows = append(report.Rows, row)
+		passwords = append(passwords, password)
+	}
+
+	report.TotalRows = len(report.Rows)
+	if report.TotalRows == 0 {
+		return nil, fmt.Errorf("%w: no data rows", ErrInvalidEmployeeImport)
+	}
+	return passwords, nil
+}
+
+// checkExistingUsernames flags rows whose username is already taken in the database
+func (i *EmployeeImporter) checkExistingUsernames(report *EmployeeImportReport) error {
+	var usernames []string
+	for _, row := range report.Rows {
+		if row.Username != "" {
+			usernames = append(usernames, row.Username)
+		}
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	var existing []string
+	if err := i.DB.Model(&models.Employee{}).Where("username IN ?", usernames).Pluck("username", &existing).Error; err != nil {
+		return fmt.Errorf("failed to check existing usernames: %w", err)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, u := range existing {
+		taken[u] = true
+	}
+	for idx := range report.Rows {
+		row := &report.Rows[idx]
+		if taken[row.Username] {
+			row.Errors = append(row.Errors, "username already exists")
+			row.Status = ImportRowInvalid
+		}
+	}
+	return nil
+}
+
+const temporaryPasswordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// generateTemporaryPassword returns a random 12-character password for invited employees
+func generateTemporaryPassword() (string, error) {
+	var sb strings.Builder
+	max := big.NewInt(int64(len(temporaryPasswordAlphabet)))
+	for n := 0; n < 12; n++ {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("could not generate temporary password: %w", err)
+		}
+		sb.WriteByte(temporaryPasswordAlphabet[idx.Int64()])
+	}
+	return sb.String(), nil
+}
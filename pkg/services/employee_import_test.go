@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmployeeImporterParse(t *testing.T) {
+	csvData := strings.Join([]string{
+		"Username,Salary,Password,Department",
+		"alice,5000000,secretpass1,Finance",
+		"bob,4500000.50,,Sales",
+		"alice,3000000,secretpass2,Finance",
+		"carol,-10,secretpass3,",
+		"dave,abc,short,",
+		",1000,,",
+		"",
+		"erin,1000.123,,",
+	}, "\n")
+
+	report := &EmployeeImportReport{}
+	passwords, err := (&EmployeeImporter{}).parse(strings.NewReader(csvData), report)
+	require.NoError(t, err)
+
+	require.Len(t, report.Rows, 7)
+	assert.Equal(t, 7, report.TotalRows)
+	assert.Len(t, passwords, 7)
+	assert.Equal(t, []string{`column "department" is not supported and was ignored`}, report.Warnings)
+
+	alice := report.Rows[0]
+	assert.Equal(t, 2, alice.Line)
+	assert.Equal(t, ImportRowValid, alice.Status)
+	assert.Equal(t, "5000000.00", alice.Salary.String())
+	assert.False(t, alice.Invited)
+	assert.Equal(t, "secretpass1", passwords[0])
+
+	bob := report.Rows[1]
+	assert.Equal(t, ImportRowValid, bob.Status)
+	assert.True(t, bob.Invited, "missing password means the employee is invited with a temporary password")
+
+	dupAlice := report.Rows[2]
+	assert.Equal(t, ImportRowInvalid, dupAlice.Status)
+	assert.Contains(t, dupAlice.Errors, "duplicate username, already used on line 2")
+
+	assert.Equal(t, ImportRowInvalid, report.Rows[3].Status, "negative salary")
+
+	dave := report.Rows[4]
+	assert.Equal(t, ImportRowInvalid, dave.Status)
+	assert.Len(t, dave.Errors, 2, "bad salary and short password are both reported")
+
+	missingUsername := report.Rows[5]
+	assert.Equal(t, 7, missingUsername.Line)
+	assert.Equal(t, ImportRowInvalid, missingUsername.Status)
+
+	erin := report.Rows[6]
+	assert.Equal(t, 9, erin.Line, "blank lines are skipped but still counted for line numbers")
+	assert.Equal(t, ImportRowInvalid, erin.Status, "more than two decimal places")
+}
+
+func TestEmployeeImporterParse_MalformedFiles(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "Empty file", data: ""},
+		{name: "Header only", data: "username,salary\n"},
+		{name: "Missing salary column", data: "username,password\nalice,secretpass1\n"},
+		{name: "Unterminated quote", data: "username,salary\n\"alice,1000\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := (&EmployeeImporter{}).parse(strings.NewReader(tc.data), &EmployeeImportReport{})
+			assert.True(t, errors.Is(err, ErrInvalidEmployeeImport), "got %v", err)
+		})
+	}
+}
+
+func TestGenerateTemporaryPassword(t *testing.T) {
+	first, err := generateTemporaryPassword()
+	require.NoError(t, err)
+	second, err := generateTemporaryPassword()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 12)
+	assert.NoError(t, ValidatePassword(first))
+	assert.NotEqual(t, first, second)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+	"payslip-generator/pkg/models"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxSalary is the largest salary that fits the decimal(10,2) salary columns
+var MaxSalary = decimal.RequireFromString("99999999.99")
+
+// MinPasswordLength is the minimum length of an employee password
+const MinPasswordLength = 8
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{3,255}$`)
+
+// NormalizeUsername trims surrounding whitespace from a username
+func NormalizeUsername(username string) string {
+	return strings.TrimSpace(username)
+}
+
+// ValidateUsername checks that a username is 3-255 characters of letters, digits, dots, dashes or underscores.
+func ValidateUsername(username string) error {
+	if username == "" {
+		return fmt.Errorf("username is required")
+	}
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("username must be 3-255 characters of letters, digits, '.', '_' or '-'")
+	}
+	return nil
+}
+
+// ValidatePassword checks the minimum password length.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	return nil
+}
+
+// ValidateSalary checks that a salary is positive, has at most two decimal places and fits the database column.
+func ValidateSalary(salary models.Money) error {
+	amount := salary.Decimal
+	if !amount.IsPositive() {
+		return fmt.Errorf("salary must be greater than zero")
+	}
+	if amount.GreaterThan(MaxSalary) {
+		return fmt.Errorf("salary must not exceed %s", MaxSalary.String())
+	}
+	if !salary.HasWholeCents() {
+		return fmt.Errorf("salary must have at most two decimal places")
+	}
+	return nil
+}
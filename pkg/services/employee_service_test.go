@@ -0,0 +1,49 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSalary(t *testing.T) {
+	testCases := []struct {
+		name        string
+		salary      string
+		expectError bool
+	}{
+		{name: "Typical salary", salary: "5000000", expectError: false},
+		{name: "Two decimal places", salary: "1234.56", expectError: false},
+		{name: "Largest allowed", salary: "99999999.99", expectError: false},
+		{name: "Zero", salary: "0", expectError: true},
+		{name: "Negative", salary: "-100", expectError: true},
+		{name: "Too large for decimal(10,2)", salary: "100000000", expectError: true},
+		{name: "Fractional cents", salary: "100.123", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSalary(models.MustParseMoney(tc.salary))
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	assert.NoError(t, ValidateUsername("jane.doe"))
+	assert.NoError(t, ValidateUsername("emp_001"))
+	assert.Error(t, ValidateUsername(""))
+	assert.Error(t, ValidateUsername("ab"), "Should reject usernames shorter than 3 characters")
+	assert.Error(t, ValidateUsername("jane doe"), "Should reject whitespace")
+	assert.Equal(t, "jane", NormalizeUsername("  jane "))
+}
+
+func TestValidatePassword(t *testing.T) {
+	assert.NoError(t, ValidatePassword("longenough"))
+	assert.Error(t, ValidatePassword("short"))
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxHolidaySpanDays caps how many days a single multi-day ICS event may expand to
+const maxHolidaySpanDays = 31
+
+// ErrInvalidHolidayFile is returned when a holiday CSV or ICS file cannot be parsed
+var ErrInvalidHolidayFile = errors.New("invalid holiday file")
+
+// HolidayEntry is one parsed holiday before it is stored
+type HolidayEntry struct {
+	Date time.Time
+	Name string
+}
+
+// HolidayImportResult summarizes a holiday import
+type HolidayImportResult struct {
+	Created  int              `json:"created"`
+	Updated  int              `json:"updated"` // Existing dates whose name or source changed
+	Warnings []string         `json:"warnings,omitempty"`
+	Holidays []models.Holiday `json:"holidays"`
+}
+
+// HolidayService manages the company holiday calendar
+type HolidayService struct {
+	DB *gorm.DB
+}
+
+// NewHolidayService creates a new instance of HolidayService.
+func NewHolidayService(db *gorm.DB) *HolidayService {
+	return &HolidayService{DB: db}
+}
+
+// LoadHolidaySet returns the holidays between start and end (inclusive)
+func (s *HolidayService) LoadHolidaySet(start, end time.Time) (utils.HolidaySet, error) {
+	var dates []time.Time
+	if err := s.DB.Model(&models.Holiday{}).Where("date BETWEEN ? AND ?", start, end).Pluck("date", &dates).Error; err != nil {
+		return nil, fmt.Errorf("failed to load holidays: %w", err)
+	}
+	return utils.NewHolidaySet(dates...), nil
+}
+
+// CountWorkingDays returns the number of days between start and end that are working days of the week and not holidays
+func (s *HolidayService) CountWorkingDays(start, end time.Time, week utils.WorkWeek) (int, error) {
+	holidays, err := s.LoadHolidaySet(start, end)
+	if err != nil {
+		return 0, err
+	}
+	return utils.CalculateWorkingDays(start, end, week, holidays), nil
+}
+
+// FindHoliday returns the holiday on the given date, or nil if it is not a holiday
+func (s *HolidayService) FindHoliday(date time.Time) (*models.Holiday, error) {
+	var holiday models.Holiday
+	err := s.DB.Where("date = ?", date.Format("2006-01-02")).First(&holiday).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up holiday: %w", err)
+	}
+	return &holiday, nil
+}
+
+// Import stores the entries in one transaction. Dates that already exist are updated in place,
+// so importing the same calendar twice is harmless.
+func (s *HolidayService) Import(entries []HolidayEntry, source string, performedBy *uuid.UUID, ipAddress, requestID string) (*HolidayImportResult, error) {
+	result := &HolidayImportResult{}
+
+	// Keep the first name for dates listed more than once
+	seen := make(map[string]bool)
+	var unique []HolidayEntry
+	for _, e := range entries {
+		key := e.Date.Format("2006-01-02")
+		if seen[key] {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s is listed more than once; keeping the first entry", key))
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, e)
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i].Date.Before(unique[j].Date) })
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		for _, e := range unique {
+			var holiday models.Holiday
+			err := tx.Where("date = ?", e.Date.Format("2006-01-02")).First(&holiday).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				holiday = models.Holiday{Date: e.Date, Name: e.Name, Source: source}
+				holiday.CreatedBy = performedBy
+				holiday.UpdatedBy = performedBy
+				if ipAddress != "" {
+					holiday.IPAddress = &ipAddress
+				}
+				if err := tx.Create(&holiday).Error; err != nil {
+					return fmt.Errorf("could not create holiday %s: %w", e.Date.Format("2006-01-02"), err)
+				}
+				result.Created++
+			case err != nil:
+				return fmt.Errorf("failed to look up holiday: %w", err)
+			case holiday.Name != e.Name || holiday.Source != source:
+				holiday.Name = e.Name
+				holiday.Source = source
+				holiday.UpdatedBy = performedBy
+				if ipAddress != "" {
+					holiday.IPAddress = &ipAddress
+				}
+				if err := tx.Save(&holiday).Error; err != nil {
+					return fmt.Errorf("could not update holiday %s: %w", e.Date.Format("2006-01-02"), err)
+				}
+				result.Updated++
+			}
+			result.Holidays = append(result.Holidays, holiday)
+		}
+
+		userType := "admin"
+		if performedBy == nil {
+			userType = "system"
+		}
+		NewAuditService(tx).CreateAuditLog(AuditLogEntryParams{
+			UserID:         derefUUID(performedBy),
+			UserType:       userType,
+			Action:         "import_holidays",
+			TargetResource: "holiday",
+			Changes:        map[string]interface{}{"source": source, "created": result.Created, "updated": result.Updated},
+			IPAddress:      ipAddress,
+			RequestID:      requestID,
+			PerformedBy:    derefUUID(performedBy),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseHolidayFile parses an "ics" or "csv" holiday file and returns the holiday source to record
+func ParseHolidayFile(r io.Reader, format string) ([]HolidayEntry, string, error) {
+	switch strings.ToLower(format) {
+	case "ics", "ical":
+		entries, err := ParseHolidaysICS(r)
+		return entries, models.HolidaySourceICS, err
+	case "csv":
+		entries, err := ParseHolidaysCSV(r)
+		return entries, models.HolidaySourceCSV, err
+	default:
+		return nil, "", fmt.Errorf("%w: unsupported format %q, use ics or csv", ErrInvalidHolidayFile, format)
+	}
+}
+
+// ParseHolidaysCSV reads a CSV with a header row containing date (YYYY-MM-DD) and name columns
+func ParseHolidaysCSV(r io.Reader) ([]HolidayEntry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read header: %v", ErrInvalidHolidayFile, err)
+	}
+	dateCol, nameCol := -1, -1
+	for idx, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "date":
+			dateCol = idx
+		case "name":
+			nameCol = idx
+		}
+	}
+	if dateCol < 0 || nameCol < 0 {
+		return nil, fmt.Errorf("%w: header must contain date and name columns", ErrInvalidHolidayFile)
+	}
+
+	var entries []HolidayEntry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidHolidayFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[dateCol]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid date %q, use YYYY-MM-DD", ErrInvalidHolidayFile, line, record[dateCol])
+		}
+		name := strings.TrimSpace(record[nameCol])
+		if name == "" {
+			return nil, fmt.Errorf("%w: line %d: name is required", ErrInvalidHolidayFile, line)
+		}
+		entries = append(entries, HolidayEntry{Date: date, Name: name})
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no holidays found", ErrInvalidHolidayFile)
+	}
+	return entries, nil
+}
+
+// ParseHolidaysICS reads the VEVENTs of an iCalendar file. Each event's SUMMARY becomes the holiday name;
+// all-day events spanning several days (DTEND is exclusive) produce one holiday per day.
+func ParseHolidaysICS(r io.Reader) ([]HolidayEntry, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHolidayFile, err)
+	}
+
+	var entries []HolidayEntry
+	inEvent := false
+	var start, end, summary string
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		property := strings.ToUpper(name)
+		if idx := strings.Index(property, ";"); idx >= 0 {
+			property = property[:idx] // Drop parameters such as ;VALUE=DATE
+		}
+
+		switch {
+		case property == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			inEvent = true
+			start, end, summary = "", "", ""
+		case property == "END" && strings.EqualFold(value, "VEVENT"):
+			inEvent = false
+			expanded, err := icsEventDates(start, end)
+			if err != nil {
+				return nil, err
+			}
+			if summary == "" {
+				summary = "Holiday"
+			}
+			for _, d := range expanded {
+				entries = append(entries, HolidayEntry{Date: d, Name: summary})
+			}
+		case !inEvent:
+		case property == "DTSTART":
+			start = value
+		case property == "DTEND":
+			end = value
+		case property == "SUMMARY":
+			summary = unescapeICSText(value)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no events found", ErrInvalidHolidayFile)
+	}
+	return entries, nil
+}
+
+// unfoldICSLines joins continuation lines (RFC 5545 section 3.1) and drops blank lines
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// icsEventDates expands an event's DTSTART/DTEND into the calendar dates it covers
+func icsEventDates(start, end string) ([]time.Time, error) {
+	if len(start) < 8 {
+		return nil, fmt.Errorf("%w: event has an invalid or missing DTSTART %q", ErrInvalidHolidayFile, start)
+	}
+	startDate, err := time.Parse("20060102", start[:8])
+	if err != nil {
+		return nil, fmt.Errorf("%w: event has an invalid DTSTART %q", ErrInvalidHolidayFile, start)
+	}
+
+	dates := []time.Time{startDate}
+	// Only all-day events (date without time) can span several days; timed events count for their start date
+	if len(end) == 8 {
+		endDate, err := time.Parse("20060102", end)
+		if err != nil {
+			return nil, fmt.Errorf("%w: event has an invalid DTEND %q", ErrInvalidHolidayFile, end)
+		}
+		for d := startDate.AddDate(0, 0, 1); d.Before(endDate); d = d.AddDate(0, 0, 1) {
+			if len(dates) >= maxHolidaySpanDays {
+				return nil, fmt.Errorf("%w: event starting %s spans more than %d days", ErrInvalidHolidayFile, startDate.Format("2006-01-02"), maxHolidaySpanDays)
+			}
+			dates = append(dates, d)
+		}
+	}
+	return dates, nil
+}
+
+func unescapeICSText(s string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(strings.TrimSpace(s))
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHolidaysCSV(t *testing.T) {
+	entries, err := ParseHolidaysCSV(strings.NewReader("Name,Date\nNew Year's Day,2024-01-01\n\"Independence Day, observed\",2024-08-17\n"))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), entries[0].Date)
+	assert.Equal(t, "New Year's Day", entries[0].Name)
+	assert.Equal(t, "Independence Day, observed", entries[1].Name)
+
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "Missing name column", data: "date\n2024-01-01\n"},
+		{name: "Bad date", data: "date,name\n01/01/2024,New Year\n"},
+		{name: "Empty name", data: "date,name\n2024-01-01,\n"},
+		{name: "No rows", data: "date,name\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseHolidaysCSV(strings.NewReader(tc.data))
+			assert.True(t, errors.Is(err, ErrInvalidHolidayFile), "got %v", err)
+		})
+	}
+}
+
+func TestParseHolidaysICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240101",
+		"DTEND;VALUE=DATE:20240102",
+		"SUMMARY:New Year's Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240410",
+		"DTEND;VALUE=DATE:20240412",
+		"SUMMARY:Eid al-Fitr\\, observed over two",
+		"  days",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20240817T000000Z",
+		"DTEND:20240818T000000Z",
+		"SUMMARY:Independence Day",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	entries, err := ParseHolidaysICS(strings.NewReader(ics))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "2024-01-01", entries[0].Date.Format("2006-01-02"))
+	assert.Equal(t, "2024-04-10", entries[1].Date.Format("2006-01-02"))
+	assert.Equal(t, "2024-04-11", entries[2].Date.Format("2006-01-02"), "DTEND is exclusive")
+	assert.Equal(t, "Eid al-Fitr, observed over two days", entries[1].Name, "folded and escaped summary")
+	assert.Equal(t, "2024-08-17", entries[3].Date.Format("2006-01-02"), "timed events count for their start date only")
+}
+
+func TestParseHolidaysICS_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "No events", data: "BEGIN:VCALENDAR\nEND:VCALENDAR\n"},
+		{name: "Missing DTSTART", data: "BEGIN:VEVENT\nSUMMARY:Nothing\nEND:VEVENT\n"},
+		{name: "Event too long", data: "BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nDTEND;VALUE=DATE:20240301\nEND:VEVENT\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseHolidaysICS(strings.NewReader(tc.data))
+			assert.True(t, errors.Is(err, ErrInvalidHolidayFile), "got %v", err)
+		})
+	}
+}
+
+func TestParseHolidayFile_UnknownFormat(t *testing.T) {
+	_, _, err := ParseHolidayFile(strings.NewReader(""), "xlsx")
+	assert.True(t, errors.Is(err, ErrInvalidHolidayFile))
+}
@@ -0,0 +1,407 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// ErrNoTaxTable is returned when no income tax table is in effect on a date
+var ErrNoTaxTable = errors.New("no income tax table in effect")
+
+// TaxBracket is the marginal rate on taxable income up to UpTo. The last bracket has no UpTo and taxes the rest.
+type TaxBracket struct {
+	UpTo *models.Money   `json:"up_to,omitempty"`
+	Rate decimal.Decimal `json:"rate"` // 0.05 is 5%
+}
+
+// PTKPAmounts are the annual non-taxable incomes (PTKP) allowed for the taxpayer, a spouse and each dependant
+type PTKPAmounts struct {
+	Taxpayer      models.Money `json:"taxpayer"`
+	Married       models.Money `json:"married"`
+	PerDependant  models.Money `json:"per_dependant"`
+	MaxDependants int          `json:"max_dependants"`
+}
+
+// TaxTable is one version of the income tax (PPh 21) rules, effective from a date until the next version.
+// Tables are data loaded from TAX_TABLES_FILE, so a regulation change only needs a new entry in that file.
+type TaxTable struct {
+	Version                   string          `json:"version"`
+	Name                      string          `json:"name"`
+	EffectiveFrom             string          `json:"effective_from"` // YYYY-MM-DD; compared with the last day of the period
+	Brackets                  []TaxBracket    `json:"brackets"`
+	PTKP                      PTKPAmounts     `json:"ptkp"`
+	OccupationalCostRate      decimal.Decimal `json:"occupational_cost_rate"`       // Share of gross income deducted as occupational cost (biaya jabatan)
+	OccupationalCostAnnualCap models.Money    `json:"occupational_cost_annual_cap"` // Deducted at most from the annualised gross, or pro rata by month at year end
+	NoTaxIDSurcharge          decimal.Decimal `json:"no_tax_id_surcharge"`          // Share added to the tax of employees without a tax ID
+	TaxableIncomeRounding     models.Money    `json:"taxable_income_rounding"`      // Taxable income (PKP) is rounded down to a multiple of it
+}
+
+// TaxTableSet holds every version of the income tax rules
+type TaxTableSet struct {
+	Tables []TaxTable `json:"tables"` // Sorted by effective date
+}
+
+// IncomeTaxTables are the tables payroll withholds income tax by, set up by LoadIncomeTaxTables. Without them no tax is withheld.
+var IncomeTaxTables *TaxTableSet
+
+// LoadIncomeTaxTables reads IncomeTaxTables from a JSON file
+func LoadIncomeTaxTables(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read income tax tables: %w", err)
+	}
+	tables, err := ParseTaxTables(data)
+	if err != nil {
+		return fmt.Errorf("invalid income tax tables in %s: %w", path, err)
+	}
+	IncomeTaxTables = tables
+	return nil
+}
+
+// ParseTaxTables decodes and validates tax tables. Unknown fields are rejected so a misspelt rate is not silently ignored.
+func ParseTaxTables(data []byte) (*TaxTableSet, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var set TaxTableSet
+	if err := decoder.Decode(&set); err != nil {
+		return nil, err
+	}
+	if len(set.Tables) == 0 {
+		return nil, fmt.Errorf("at least one table is required")
+	}
+	versions := make(map[string]bool)
+	dates := make(map[string]bool)
+	for _, table := range set.Tables {
+		if err := ValidateTaxTable(table); err != nil {
+			return nil, fmt.Errorf("table %q: %w", table.Version, err)
+		}
+		if versions[table.Version] {
+			return nil, fmt.Errorf("version %q is listed more than once", table.Version)
+		}
+		if dates[table.EffectiveFrom] {
+			return nil, fmt.Errorf("more than one table is effective from %s", table.EffectiveFrom)
+		}
+		versions[table.Version], dates[table.EffectiveFrom] = true, true
+	}
+	sort.SliceStable(set.Tables, func(i, j int) bool { return set.Tables[i].EffectiveFrom < set.Tables[j].EffectiveFrom })
+	return &set, nil
+}
+
+// ValidateTaxTable checks a table's dates, brackets and amounts
+func ValidateTaxTable(table TaxTable) error {
+	if strings.TrimSpace(table.Version) == "" {
+		return fmt.Errorf("version is required")
+	}
+	if _, err := time.Parse("2006-01-02", table.EffectiveFrom); err != nil {
+		return fmt.Errorf("effective_from must be a date in YYYY-MM-DD format")
+	}
+	if len(table.Brackets) == 0 {
+		return fmt.Errorf("at least one bracket is required")
+	}
+	previous := decimal.Zero
+	for i, bracket := range table.Brackets {
+		if bracket.Rate.IsNegative() || bracket.Rate.GreaterThan(decimal.NewFromInt(1)) {
+			return fmt.Errorf("bracket %d: rate must be between 0 and 1", i+1)
+		}
+		last := i == len(table.Brackets)-1
+		if bracket.UpTo == nil {
+			if !last {
+				return fmt.Errorf("bracket %d: only the last bracket may have no up_to", i+1)
+			}
+			continue
+		}
+		if last {
+			return fmt.Errorf("bracket %d: the last bracket must have no up_to", i+1)
+		}
+		if !bracket.UpTo.GreaterThan(previous) {
+			return fmt.Errorf("bracket %d: up_to must be greater than the previous bracket's", i+1)
+		}
+		previous = bracket.UpTo.Decimal
+	}
+	if table.PTKP.Taxpayer.IsNegative() || table.PTKP.Married.IsNegative() || table.PTKP.PerDependant.IsNegative() || table.PTKP.MaxDependants < 0 {
+		return fmt.Errorf("PTKP amounts and max_dependants must not be negative")
+	}
+	if table.OccupationalCostRate.IsNegative() || table.OccupationalCostRate.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("occupational_cost_rate must be between 0 and 1")
+	}
+	if table.OccupationalCostAnnualCap.IsNegative() {
+		return fmt.Errorf("occupational_cost_annual_cap must not be negative")
+	}
+	if table.NoTaxIDSurcharge.IsNegative() {
+		return fmt.Errorf("no_tax_id_surcharge must not be negative")
+	}
+	if table.TaxableIncomeRounding.IsNegative() {
+		return fmt.Errorf("taxable_income_rounding must not be negative")
+	}
+	return nil
+}
+
+// TableFor returns the latest table effective on or before a date
+func (s *TaxTableSet) TableFor(date time.Time) (TaxTable, error) {
+	key := date.Format("2006-01-02")
+	for i := len(s.Tables) - 1; i >= 0; i-- {
+		if s.Tables[i].EffectiveFrom <= key {
+			return s.Tables[i], nil
+		}
+	}
+	return TaxTable{}, fmt.Errorf("%w on %s", ErrNoTaxTable, key)
+}
+
+// PTKPFor returns the annual non-taxable income for a profile
+func (t TaxTable) PTKPFor(profile models.TaxProfile) decimal.Decimal {
+	dependants := profile.Dependants
+	if dependants > t.PTKP.MaxDependants {
+		dependants = t.PTKP.MaxDependants
+	}
+	ptkp := t.PTKP.Taxpayer.Decimal.Add(t.PTKP.PerDependant.Mul(decimal.NewFromInt(int64(dependants))))
+	if profile.MaritalStatus == models.MaritalMarried {
+		ptkp = ptkp.Add(t.PTKP.Married.Decimal)
+	}
+	return ptkp
+}
+
+// AnnualTax applies the brackets to an annual taxable income
+func (t TaxTable) AnnualTax(taxableIncome decimal.Decimal) decimal.Decimal {
+	tax, lower := decimal.Zero, decimal.Zero
+	for _, bracket := range t.Brackets {
+		if !taxableIncome.GreaterThan(lower) {
+			break
+		}
+		upper := taxableIncome
+		if bracket.UpTo != nil && bracket.UpTo.LessThan(upper) {
+			upper = bracket.UpTo.Decimal
+		}
+		tax = tax.Add(upper.Sub(lower).Mul(bracket.Rate))
+		if bracket.UpTo == nil {
+			break
+		}
+		lower = bracket.UpTo.Decimal
+	}
+	return tax
+}
+
+// TaxYearToDate sums an employee's earlier payslips in the same tax year
+type TaxYearToDate struct {
+	GrossIncome   models.Money
+	Contributions models.Money // Deductible employee social security contributions
+	Withheld      models.Money
+	Months        int // Calendar months from the first earlier payslip's period up to the month before this period ends
+}
+
+// TaxInput is what the payroll engine needs to withhold an employee's income tax for a period
+type TaxInput struct {
+	Table          TaxTable
+	Profile        *models.TaxProfile // Nil withholds as single without dependants or tax ID
+	YearToDate     TaxYearToDate
+	YearEnd        bool            // Settle the year's tax: this is the employee's last period of the tax year, or they have left
+	PeriodsPerYear decimal.Decimal // Periods like this one in the tax year, see PeriodsPerYear; zero is 12, for calendar months
+}
+
+// PeriodsPerYear returns how many periods like this one make up its tax year, by which the period's income is annualised:
+// 12 for a calendar month, otherwise the working days of the tax year over those of the period under the work week.
+// Holidays are left out of both counts.
+func PeriodsPerYear(period models.AttendancePeriod, week utils.WorkWeek) decimal.Decimal {
+	start, end := period.StartDate, period.EndDate
+	if start.Day() == 1 && start.Year() == end.Year() && start.Month() == end.Month() && end.AddDate(0, 0, 1).Day() == 1 {
+		return decimal.NewFromInt(12)
+	}
+	days := utils.CalculateWorkingDays(start, end, week, nil)
+	if days == 0 {
+		return decimal.NewFromInt(12)
+	}
+	yearStart := time.Date(end.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearDays := utils.CalculateWorkingDays(yearStart, yearStart.AddDate(1, 0, -1), week, nil)
+	return decimal.NewFromInt(int64(yearDays)).Div(decimal.NewFromInt(int64(days)))
+}
+
+// CalculateIncomeTax works out the income tax (PPh 21) to withhold on a period's taxable gross income and deductible employee
+// contributions (JHT and JP). Each period the gross and contributions are annualised by the periods in the year, occupational cost
+// is taken off up to its annual cap, and the tax on the result is spread evenly over the periods. At year end the tax on the year's
+// actual income is worked out instead and the tax withheld earlier in the year is taken off it, so the withholding may be negative,
+// a refund. The withholding is returned unrounded.
+func CalculateIncomeTax(input TaxInput, gross, contributions decimal.Decimal) (models.TaxCalculation, decimal.Decimal) {
+	table := input.Table
+	profile := models.TaxProfile{MaritalStatus: models.MaritalSingle}
+	if input.Profile != nil {
+		profile = *input.Profile
+	}
+	hasTaxID := profile.TaxID != nil && *profile.TaxID != ""
+	periodsPerYear := input.PeriodsPerYear
+	if !periodsPerYear.IsPositive() {
+		periodsPerYear = decimal.NewFromInt(12)
+	}
+
+	months := 0
+	var occupationalCost, annualNet decimal.Decimal
+	if input.YearEnd {
+		months = input.YearToDate.Months + 1
+		gross = gross.Add(input.YearToDate.GrossIncome.Decimal)
+		contributions = contributions.Add(input.YearToDate.Contributions.Decimal)
+		occupationalCost = decimal.Min(
+			gross.Mul(table.OccupationalCostRate),
+			table.OccupationalCostAnnualCap.Mul(decimal.NewFromInt(int64(months))).Div(decimal.NewFromInt(12)),
+		)
+		annualNet = gross.Sub(occupationalCost).Sub(contributions)
+	} else {
+		// The cap applies to the annualised gross, so it is exact whatever the length of the period
+		annualGross := gross.Mul(periodsPerYear)
+		annualOccupationalCost := decimal.Min(annualGross.Mul(table.OccupationalCostRate), table.OccupationalCostAnnualCap.Decimal)
+		annualNet = annualGross.Sub(annualOccupationalCost).Sub(contributions.Mul(periodsPerYear))
+		occupationalCost = annualOccupationalCost.Div(periodsPerYear)
+	}
+	ptkp := table.PTKPFor(profile)
+	taxableIncome := decimal.Max(annualNet.Sub(ptkp), decimal.Zero)
+	if table.TaxableIncomeRounding.IsPositive() {
+		taxableIncome = taxableIncome.Div(table.TaxableIncomeRounding.Decimal).Floor().Mul(table.TaxableIncomeRounding.Decimal)
+	}
+	annualTax := table.AnnualTax(taxableIncome)
+	if !hasTaxID {
+		annualTax = annualTax.Mul(decimal.NewFromInt(1).Add(table.NoTaxIDSurcharge))
+	}
+
+	calculation := models.TaxCalculation{
+		TableVersion:     table.Version,
+		PTKPStatus:       profile.PTKPStatus(table.PTKP.MaxDependants),
+		HasTaxID:         hasTaxID,
+		ProfileMissing:   input.Profile == nil,
+		YearEnd:          input.YearEnd,
+		PeriodsPerYear:   periodsPerYear.Round(4),
+		Months:           months,
+		GrossIncome:      models.NewMoney(gross),
+		OccupationalCost: models.NewMoney(occupationalCost),
+		Contributions:    models.NewMoney(contributions),
+		AnnualNetIncome:  models.NewMoney(annualNet),
+		PTKP:             models.NewMoney(ptkp),
+		TaxableIncome:    models.NewMoney(taxableIncome),
+		AnnualTax:        models.NewMoney(annualTax),
+	}
+	if input.YearEnd {
+		calculation.WithheldBefore = input.YearToDate.Withheld
+		return calculation, annualTax.Sub(input.YearToDate.Withheld.Decimal)
+	}
+	return calculation, annualTax.Div(periodsPerYear)
+}
+
+// NormalizeTaxID strips the punctuation from a tax ID and checks it is a 15-digit NPWP or a 16-digit NIK
+func NormalizeTaxID(taxID string) (string, error) {
+	var digits strings.Builder
+	for _, r := range taxID {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '.' || r == '-' || r == ' ':
+		default:
+			return "", fmt.Errorf("tax ID may only contain digits, dots and dashes")
+		}
+	}
+	if n := digits.Len(); n != 15 && n != 16 {
+		return "", fmt.Errorf("tax ID must be a 15-digit NPWP or a 16-digit NIK")
+	}
+	return digits.String(), nil
+}
+
+// ValidateTaxProfile checks a profile's marital status and dependants. Its tax ID should already be normalised.
+func ValidateTaxProfile(profile models.TaxProfile) error {
+	if profile.MaritalStatus != models.MaritalSingle && profile.MaritalStatus != models.MaritalMarried {
+		return fmt.Errorf("marital status must be %q or %q", models.MaritalSingle, models.MaritalMarried)
+	}
+	if profile.Dependants < 0 || profile.Dependants > 20 {
+		return fmt.Errorf("dependants must be between 0 and 20")
+	}
+	return nil
+}
+
+// LoadTaxInput fetches the employee's tax profile and their taxable income and tax withheld earlier in the tax year, which
+// is the calendar year the period ends in, with the deductible contributions of those payslips. Payslips from before income tax
+// count their prorated salary and overtime as gross. The period's income is annualised by PeriodsPerYear under the employee's
+// work week, and the year's tax is settled on the last period of the tax year: no later period starts in the year and one as
+// long as it would end in the next, or the employee has left.
+// It returns nil when no tax tables are loaded.
+func LoadTaxInput(db *gorm.DB, employee models.Employee, period models.AttendancePeriod, week utils.WorkWeek) (*TaxInput, error) {
+	if IncomeTaxTables == nil {
+		return nil, nil
+	}
+	table, err := IncomeTaxTables.TableFor(period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	input := &TaxInput{Table: table, PeriodsPerYear: PeriodsPerYear(period, week)}
+
+	var profile models.TaxProfile
+	err = db.Where("employee_id = ?", employee.ID).First(&profile).Error
+	if err == nil {
+		input.Profile = &profile
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch tax profile for employee %s: %w", employee.ID, err)
+	}
+
+	var totals struct {
+		GrossIncome decimal.Decimal
+		Withheld    decimal.Decimal
+		FirstStart  *time.Time
+	}
+	yearStart := time.Date(period.EndDate.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := db.Model(&models.Payslip{}).
+		Select("COALESCE(SUM(CASE WHEN payslips.tax_calculation IS NULL THEN payslips.prorated_salary + payslips.overtime_pay ELSE payslips.taxable_income END), 0) AS gross_income, "+
+			"COALESCE(SUM(payslips.income_tax), 0) AS withheld, MIN(attendance_periods.start_date) AS first_start").
+		Joins("JOIN attendance_periods ON attendance_periods.id = payslips.attendance_period_id").
+		Where("payslips.employee_id = ? AND payslips.voided_at IS NULL AND attendance_periods.end_date >= ? AND attendance_periods.end_date < ?",
+			employee.ID, yearStart.Format("2006-01-02"), period.StartDate.Format("2006-01-02")).
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to total this year's payslips for employee %s: %w", employee.ID, err)
+	}
+	var contributions decimal.Decimal
+	if err := db.Model(&models.PayslipContribution{}).
+		Select("COALESCE(SUM(payslip_contributions.employee_amount), 0)").
+		Joins("JOIN payslips ON payslips.id = payslip_contributions.payslip_id").
+		Joins("JOIN attendance_periods ON attendance_periods.id = payslips.attendance_period_id").
+		Where("payslip_contributions.employee_id = ? AND payslip_contributions.tax_deductible AND payslips.voided_at IS NULL AND attendance_periods.end_date >= ? AND attendance_periods.end_date < ?",
+			employee.ID, yearStart.Format("2006-01-02"), period.StartDate.Format("2006-01-02")).
+		Scan(&contributions).Error; err != nil {
+		return nil, fmt.Errorf("failed to total this year's contributions for employee %s: %w", employee.ID, err)
+	}
+	// Months are counted by calendar month rather than by payslip, so weekly or fortnightly periods count once per month
+	months := 0
+	if totals.FirstStart != nil {
+		firstMonth := time.January
+		if !totals.FirstStart.Before(yearStart) {
+			firstMonth = totals.FirstStart.Month()
+		}
+		months = max(int(period.EndDate.Month())-int(firstMonth), 0)
+	}
+	input.YearToDate = TaxYearToDate{
+		GrossIncome:   models.NewMoney(totals.GrossIncome),
+		Contributions: models.NewMoney(contributions),
+		Withheld:      models.NewMoney(totals.Withheld),
+		Months:        months,
+	}
+
+	lastDay := time.Date(period.EndDate.Year(), period.EndDate.Month(), period.EndDate.Day(), 23, 59, 59, 0, time.UTC)
+	if employee.DeactivatedAt != nil && !employee.DeactivatedAt.After(lastDay) {
+		input.YearEnd = true
+		return input, nil
+	}
+	periodDays := int(period.EndDate.Sub(period.StartDate).Hours()/24) + 1
+	if period.EndDate.AddDate(0, 0, periodDays).Year() == period.EndDate.Year() {
+		return input, nil
+	}
+	var laterPeriods int64
+	if err := db.Model(&models.AttendancePeriod{}).
+		Where("start_date > ? AND start_date <= ?", period.EndDate.Format("2006-01-02"), yearStart.AddDate(1, 0, -1).Format("2006-01-02")).
+		Count(&laterPeriods).Error; err != nil {
+		return nil, fmt.Errorf("failed to look for later periods of the tax year: %w", err)
+	}
+	input.YearEnd = laterPeriods == 0
+	return input, nil
+}
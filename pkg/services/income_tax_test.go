@@ -0,0 +1,206 @@
+package services
+
+import (
+	"os"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shippedTaxTable returns the table in effect on a date from the tables shipped with the repository
+func shippedTaxTable(t *testing.T, date time.Time) TaxTable {
+	t.Helper()
+	data, err := os.ReadFile("../../config/income_tax_tables.json")
+	require.NoError(t, err)
+	tables, err := ParseTaxTables(data)
+	require.NoError(t, err)
+	table, err := tables.TableFor(date)
+	require.NoError(t, err)
+	return table
+}
+
+func taxProfile(maritalStatus string, dependants int, taxID string) *models.TaxProfile {
+	profile := &models.TaxProfile{MaritalStatus: maritalStatus, Dependants: dependants}
+	if taxID != "" {
+		profile.TaxID = &taxID
+	}
+	return profile
+}
+
+func TestParseTaxTables_ShippedTables(t *testing.T) {
+	data, err := os.ReadFile("../../config/income_tax_tables.json")
+	require.NoError(t, err)
+	tables, err := ParseTaxTables(data)
+	require.NoError(t, err)
+
+	table, err := tables.TableFor(time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2016-01", table.Version)
+	table, err = tables.TableFor(time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2022-01", table.Version)
+	_, err = tables.TableFor(time.Date(2015, time.December, 31, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrNoTaxTable)
+}
+
+func TestParseTaxTables_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "No tables", data: `{"tables": []}`},
+		{name: "Misspelt field", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "brackets": [{"rate": "0.05"}], "no_taxid_surcharge": "0.2"}]}`},
+		{name: "Bad date", data: `{"tables": [{"version": "1", "effective_from": "01/01/2024", "brackets": [{"rate": "0.05"}]}]}`},
+		{name: "Unbounded middle bracket", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "brackets": [{"rate": "0.05"}, {"rate": "0.15"}]}]}`},
+		{name: "Bounded last bracket", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "brackets": [{"up_to": "100", "rate": "0.05"}]}]}`},
+		{name: "Brackets out of order", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "brackets": [{"up_to": "100", "rate": "0.05"}, {"up_to": "50", "rate": "0.1"}, {"rate": "0.15"}]}]}`},
+		{name: "Rate above 100%", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "brackets": [{"rate": "5"}]}]}`},
+		{name: "Same effective date", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "brackets": [{"rate": "0.05"}]}, {"version": "2", "effective_from": "2024-01-01", "brackets": [{"rate": "0.05"}]}]}`},
+	}
+	for _, tc := range testCases {
+		_, err := ParseTaxTables([]byte(tc.data))
+		assert.Error(t, err, tc.name)
+	}
+}
+
+func TestTaxTable_AnnualTax(t *testing.T) {
+	hpp := shippedTaxTable(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC))
+	before := shippedTaxTable(t, time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC))
+	testCases := []struct {
+		table    TaxTable
+		income   string
+		expected string
+	}{
+		{table: hpp, income: "0", expected: "0"},
+		{table: hpp, income: "60000000", expected: "3000000"},
+		{table: hpp, income: "70000000", expected: "4500000"},
+		{table: hpp, income: "300000000", expected: "44000000"},
+		{table: before, income: "60000000", expected: "4000000"},
+	}
+	for _, tc := range testCases {
+		tax := tc.table.AnnualTax(decimal.RequireFromString(tc.income))
+		assert.True(t, tax.Equal(decimal.RequireFromString(tc.expected)), "%s under %s: got %s", tc.income, tc.table.Version, tax)
+	}
+}
+
+func TestCalculateIncomeTax(t *testing.T) {
+	table := shippedTaxTable(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC))
+	testCases := []struct {
+		name        string
+		input       TaxInput
+		gross       string
+		withholding string
+		ptkpStatus  string
+	}{
+		{
+			// 10,000,000 less 500,000 occupational cost, times 12, less 54,000,000 PTKP: 5% of 60,000,000, a twelfth a month
+			name: "Monthly, single", input: TaxInput{Table: table, Profile: taxProfile(models.MaritalSingle, 0, "092542943407000")},
+			gross: "10000000", withholding: "250000", ptkpStatus: "TK/0",
+		},
+		{
+			name: "Monthly, married with two dependants", input: TaxInput{Table: table, Profile: taxProfile(models.MaritalMarried, 2, "092542943407000")},
+			gross: "10000000", withholding: "193750", ptkpStatus: "K/2",
+		},
+		{
+			name: "Dependants above the cap", input: TaxInput{Table: table, Profile: taxProfile(models.MaritalMarried, 5, "092542943407000")},
+			gross: "10000000", withholding: "175000", ptkpStatus: "K/3",
+		},
+		{
+			name: "Without a tax ID", input: TaxInput{Table: table, Profile: taxProfile(models.MaritalSingle, 0, "")},
+			gross: "10000000", withholding: "300000", ptkpStatus: "TK/0",
+		},
+		{
+			name: "Without a profile", input: TaxInput{Table: table},
+			gross: "10000000", withholding: "300000", ptkpStatus: "TK/0",
+		},
+		{
+			name: "Below PTKP", input: TaxInput{Table: table, Profile: taxProfile(models.MaritalSingle, 0, "092542943407000")},
+			gross: "4000000", withholding: "0", ptkpStatus: "TK/0",
+		},
+		{
+			// 130,000,000 for the year less the 6,000,000 cap and PTKP: 5% of 60,000,000 and 15% of 10,000,000, less 2,750,000 withheld
+			name: "Year end", input: TaxInput{
+				Table: table, Profile: taxProfile(models.MaritalSingle, 0, "092542943407000"), YearEnd: true,
+				YearToDate: TaxYearToDate{GrossIncome: models.MustParseMoney("110000000"), Withheld: models.MustParseMoney("2750000"), Months: 11},
+			},
+			gross: "20000000", withholding: "1750000", ptkpStatus: "TK/0",
+		},
+		{
+			// 90,000,000 less the 1,500,000 cap for three months and PTKP: 5% of 34,500,000, below the 2,000,000 withheld
+			name: "Year end after leaving in March", input: TaxInput{
+				Table: table, Profile: taxProfile(models.MaritalSingle, 0, "092542943407000"), YearEnd: true,
+				YearToDate: TaxYearToDate{GrossIncome: models.MustParseMoney("60000000"), Withheld: models.MustParseMoney("2000000"), Months: 2},
+			},
+			gross: "30000000", withholding: "-275000", ptkpStatus: "TK/0",
+		},
+	}
+	for _, tc := range testCases {
+		calculation, withholding := CalculateIncomeTax(tc.input, decimal.RequireFromString(tc.gross), decimal.Zero)
+		assert.True(t, withholding.Equal(decimal.RequireFromString(tc.withholding)), "%s: got %s", tc.name, withholding)
+		assert.Equal(t, tc.ptkpStatus, calculation.PTKPStatus, tc.name)
+		assert.Equal(t, tc.input.Profile == nil, calculation.ProfileMissing, tc.name)
+		assert.Equal(t, table.Version, calculation.TableVersion, tc.name)
+	}
+}
+
+func TestCalculateIncomeTax_DeductsContributions(t *testing.T) {
+	input := TaxInput{Table: shippedTaxTable(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)), Profile: taxProfile(models.MaritalSingle, 0, "092542943407000")}
+	// 10,000,000 less 500,000 occupational cost and 300,000 JHT and JP, times 12, less PTKP: 5% of 56,400,000
+	calculation, withholding := CalculateIncomeTax(input, decimal.RequireFromString("10000000"), decimal.RequireFromString("300000"))
+	assert.True(t, withholding.Equal(decimal.RequireFromString("235000")), "got %s", withholding)
+	assert.Equal(t, "300000.00", calculation.Contributions.String())
+
+	input.YearEnd = true
+	input.YearToDate = TaxYearToDate{GrossIncome: models.MustParseMoney("110000000"), Contributions: models.MustParseMoney("3300000"), Withheld: models.MustParseMoney("2585000"), Months: 11}
+	calculation, withholding = CalculateIncomeTax(input, decimal.RequireFromString("10000000"), decimal.RequireFromString("300000"))
+	assert.Equal(t, "3600000.00", calculation.Contributions.String(), "The year's contributions")
+	assert.True(t, withholding.Equal(decimal.RequireFromString("235000")), "got %s", withholding)
+}
+
+func TestPeriodsPerYear(t *testing.T) {
+	march := models.AttendancePeriod{StartDate: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "12", PeriodsPerYear(march, utils.StandardWorkWeek).String())
+
+	// 2024 has 262 weekdays
+	week := models.AttendancePeriod{StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "52.4", PeriodsPerYear(week, utils.StandardWorkWeek).String())
+
+	// A month's length from the 16th counts its working days like any other period
+	midMonth := models.AttendancePeriod{StartDate: time.Date(2024, time.March, 16, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "12.4762", PeriodsPerYear(midMonth, utils.StandardWorkWeek).Round(4).String())
+}
+
+func TestCalculateIncomeTax_Weekly(t *testing.T) {
+	input := TaxInput{
+		Table:          shippedTaxTable(t, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)),
+		Profile:        taxProfile(models.MaritalSingle, 0, "092542943407000"),
+		PeriodsPerYear: decimal.RequireFromString("52.4"),
+	}
+	// 2,500,000 a week is 131,000,000 a year, less the 6,000,000 cap and PTKP: 5% of 60,000,000 and 15% of 11,000,000,
+	// spread over 52.4 weeks
+	calculation, withholding := CalculateIncomeTax(input, decimal.RequireFromString("2500000"), decimal.Zero)
+	assert.Equal(t, "88740.46", withholding.StringFixed(2))
+	assert.Equal(t, "125000000.00", calculation.AnnualNetIncome.String())
+	assert.Equal(t, "4650000.00", calculation.AnnualTax.String())
+	assert.Equal(t, "52.4", calculation.PeriodsPerYear.String())
+	assert.Zero(t, calculation.Months)
+}
+
+func TestNormalizeTaxID(t *testing.T) {
+	taxID, err := NormalizeTaxID("09.254.294.3-407.000")
+	require.NoError(t, err)
+	assert.Equal(t, "092542943407000", taxID)
+	taxID, err = NormalizeTaxID("3171234567890001")
+	require.NoError(t, err)
+	assert.Equal(t, "3171234567890001", taxID, "A NIK is accepted")
+
+	for _, invalid := range []string{"12345", "09.254.294.3-407.00A", "31712345678900011"} {
+		_, err := NormalizeTaxID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// MaxYearlyLeaveEntitlement is the upper bound for a leave type's yearly entitlement in days
+const MaxYearlyLeaveEntitlement = 366
+
+var (
+	// ErrInsufficientLeaveBalance is returned when a paid leave request exceeds the available balance
+	ErrInsufficientLeaveBalance = errors.New("insufficient leave balance")
+	// ErrLeaveOverlap is returned when a leave request overlaps another pending or approved request
+	ErrLeaveOverlap = errors.New("leave request overlaps an existing request")
+	// ErrLeavePayrollClosed is returned when leave falls in a period whose payroll has already been run, or that has already
+	// paid the employee
+	ErrLeavePayrollClosed = errors.New("payroll has already been run for a period covering these dates")
+	// ErrLeaveSpansYears is returned when a leave request crosses a year boundary, since balances are kept per calendar year
+	ErrLeaveSpansYears = errors.New("leave request spans two calendar years")
+)
+
+// LeaveDay is one working day of approved leave, as used by payroll
+type LeaveDay struct {
+	Date           time.Time
+	LeaveRequestID uuid.UUID
+	LeaveType      string
+	Paid           bool
+}
+
+// LeaveBalance is an employee's position for one leave type in one calendar year
+type LeaveBalance struct {
+	LeaveTypeID       uuid.UUID `json:"leave_type_id"`
+	LeaveType         string    `json:"leave_type"`
+	Paid              bool      `json:"paid"`
+	Year              int       `json:"year"`
+	YearlyEntitlement float64   `json:"yearly_entitlement"`
+	Accrued           float64   `json:"accrued"`   // Entitlement earned so far this year
+	Used              float64   `json:"used"`      // Approved leave days
+	Pending           float64   `json:"pending"`   // Days in requests awaiting approval
+	Available         float64   `json:"available"` // Accrued minus used and pending; unpaid leave is not limited
+}
+
+// ValidateLeaveType checks a leave type's name, entitlement and accrual method
+func ValidateLeaveType(leaveType models.LeaveType) error {
+	if strings.TrimSpace(leaveType.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(leaveType.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if leaveType.YearlyEntitlement < 0 || leaveType.YearlyEntitlement > MaxYearlyLeaveEntitlement {
+		return fmt.Errorf("yearly entitlement must be between 0 and %d days", MaxYearlyLeaveEntitlement)
+	}
+	if !decimal.NewFromFloat(leaveType.YearlyEntitlement).Equal(decimal.NewFromFloat(leaveType.YearlyEntitlement).Round(2)) {
+		return fmt.Errorf("yearly entitlement must have at most 2 decimal places")
+	}
+	switch leaveType.Accrual {
+	case models.LeaveAccrualUpfront, models.LeaveAccrualMonthly:
+	default:
+		return fmt.Errorf("accrual must be %q or %q", models.LeaveAccrualUpfront, models.LeaveAccrualMonthly)
+	}
+	return nil
+}
+
+// AccruedLeaveDays returns the entitlement earned in the given year as of a date. Employees who joined
+// during the year accrue from their joining month; monthly accrual adds a twelfth at the start of each month.
+func AccruedLeaveDays(leaveType models.LeaveType, joinedAt time.Time, year int, asOf time.Time) float64 {
+	if asOf.Year() < year || (!joinedAt.IsZero() && joinedAt.Year() > year) {
+		return 0
+	}
+	firstMonth := 1
+	if !joinedAt.IsZero() && joinedAt.Year() == year {
+		firstMonth = int(joinedAt.Month())
+	}
+	lastMonth := 12
+	if asOf.Year() == year && leaveType.Accrual == models.LeaveAccrualMonthly {
+		lastMonth = int(asOf.Month())
+	}
+	if lastMonth < firstMonth {
+		return 0
+	}
+
+	months := decimal.NewFromInt(int64(lastMonth - firstMonth + 1))
+	accrued := decimal.NewFromFloat(leaveType.YearlyEntitlement).Mul(months).Div(decimal.NewFromInt(12))
+	return accrued.Round(2).InexactFloat64()
+}
+
+// ExpandLeaveDays returns the working days between start and end (inclusive) covered by the requests,
+// skipping days outside the work week and holidays. A date covered by several requests is listed once.
+func ExpandLeaveDays(requests []models.LeaveRequest, start, end time.Time, week utils.WorkWeek, holidays utils.HolidaySet) []LeaveDay {
+	seen := make(map[string]struct{})
+	var days []LeaveDay
+	for _, lr := range requests {
+		from, to := lr.StartDate, lr.EndDate
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			if !utils.IsWorkingDay(d, week, holidays) {
+				continue
+			}
+			key := d.Format("2006-01-02")
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			days = append(days, LeaveDay{Date: d, LeaveRequestID: lr.ID, LeaveType: lr.LeaveType.Name, Paid: lr.LeaveType.Paid})
+		}
+	}
+	return days
+}
+
+// LeaveService manages leave requests and balances
+type LeaveService struct {
+	DB *gorm.DB
+}
+
+// NewLeaveService creates a new instance of LeaveService.
+func NewLeaveService(db *gorm.DB) *LeaveService {
+	return &LeaveService{DB: db}
+}
+
+// CountLeaveDays returns the working days between start and end under the employee's schedule, excluding holidays
+func (s *LeaveService) CountLeaveDays(employee models.Employee, start, end time.Time) (int, error) {
+	schedule, err := ResolveWorkSchedule(s.DB, employee)
+	if err != nil {
+		return 0, err
+	}
+	return NewHolidayService(s.DB).CountWorkingDays(start, end, schedule.WorkingDays)
+}
+
+// Balances returns the employee's balance for every leave type in the given year, as of a date
+func (s *LeaveService) Balances(employee models.Employee, year int, asOf time.Time) ([]LeaveBalance, error) {
+	var leaveTypes []models.LeaveType
+	if err := s.DB.Order("name").Find(&leaveTypes).Error; err != nil {
+		return nil, fmt.Errorf("failed to load leave types: %w", err)
+	}
+	balances := make([]LeaveBalance, 0, len(leaveTypes))
+	for _, lt := range leaveTypes {
+		balance, err := s.Balance(employee, lt, year, asOf, nil)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// Balance returns the employee's balance for one leave type. The request excludeID, if set,
+// is left out of the pending days so a request can be checked against the rest of the balance.
+func (s *LeaveService) Balance(employee models.Employee, leaveType models.LeaveType, year int, asOf time.Time, excludeID *uuid.UUID) (LeaveBalance, error) {
+	balance := LeaveBalance{
+		LeaveTypeID:       leaveType.ID,
+		LeaveType:         leaveType.Name,
+		Paid:              leaveType.Paid,
+		Year:              year,
+		YearlyEntitlement: leaveType.YearlyEntitlement,
+		Accrued:           AccruedLeaveDays(leaveType, employee.CreatedAt, year, asOf),
+	}
+
+	var totals []struct {
+		Status string
+		Days   float64
+	}
+	query := s.DB.Model(&models.LeaveRequest{}).
+		Select("status, COALESCE(SUM(days), 0) AS days").
+		Where("employee_id = ? AND leave_type_id = ? AND status IN ?", employee.ID, leaveType.ID, []string{models.LeavePending, models.LeaveApproved}).
+		Where("EXTRACT(YEAR FROM start_date) = ?", year)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	if err := query.Group("status").Scan(&totals).Error; err != nil {
+		return balance, fmt.Errorf("failed to sum leave requests: %w", err)
+	}
+	for _, t := range totals {
+		if t.Status == models.LeaveApproved {
+			balance.Used = t.Days
+		} else {
+			balance.Pending = t.Days
+		}
+	}
+
+	balance.Available = decimal.NewFromFloat(balance.Accrued).
+		Sub(decimal.NewFromFloat(balance.Used)).
+		Sub(decimal.NewFromFloat(balance.Pending)).
+		InexactFloat64()
+	return balance, nil
+}
+
+// CheckLeaveRequest verifies that a request can be submitted or approved: it must fall within one calendar year,
+// must not overlap the employee's other pending or approved requests, must not fall in a period whose payroll
+// has been run, and paid leave must fit in the balance accrued by its start date.
+func (s *LeaveService) CheckLeaveRequest(employee models.Employee, leaveType models.LeaveType, request models.LeaveRequest) error {
+	// Balances count a request's days against the year it starts in, so it cannot take days from the next year's entitlement
+	if request.StartDate.Year() != request.EndDate.Year() {
+		return ErrLeaveSpansYears
+	}
+
+	var overlapping int64
+	query := s.DB.Model(&models.LeaveRequest{}).
+		Where("employee_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?",
+			employee.ID, []string{models.LeavePending, models.LeaveApproved}, request.EndDate, request.StartDate)
+	if request.ID != uuid.Nil {
+		query = query.Where("id <> ?", request.ID)
+	}
+	if err := query.Count(&overlapping).Error; err != nil {
+		return fmt.Errorf("failed to check overlapping leave: %w", err)
+	}
+	if overlapping > 0 {
+		return ErrLeaveOverlap
+	}
+
+	var closedPeriods int64
+	if err := s.DB.Model(&models.AttendancePeriod{}).
+		Where("payroll_run_at IS NOT NULL AND start_date <= ? AND end_date >= ?", request.EndDate, request.StartDate).
+		Count(&closedPeriods).Error; err != nil {
+		return fmt.Errorf("failed to check attendance periods: %w", err)
+	}
+	if closedPeriods > 0 {
+		return ErrLeavePayrollClosed
+	}
+	paid, err := HasActivePayslip(s.DB, employee.ID, request.StartDate, request.EndDate)
+	if err != nil {
+		return err
+	}
+	if paid {
+		return ErrLeavePayrollClosed
+	}
+
+	if !leaveType.Paid {
+		return nil
+	}
+	var excludeID *uuid.UUID
+	if request.ID != uuid.Nil {
+		excludeID = &request.ID
+	}
+	balance, err := s.Balance(employee, leaveType, request.StartDate.Year(), request.StartDate, excludeID)
+	if err != nil {
+		return err
+	}
+	if request.Days > balance.Available {
+		return fmt.Errorf("%w: %s has %s days available, %s requested", ErrInsufficientLeaveBalance, leaveType.Name,
+			decimal.NewFromFloat(balance.Available).String(), decimal.NewFromFloat(request.Days).String())
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLeaveType(t *testing.T) {
+	testCases := []struct {
+		name        string
+		leaveType   models.LeaveType
+		expectError bool
+	}{
+		{name: "Paid upfront", leaveType: models.LeaveType{Name: "Annual", Paid: true, YearlyEntitlement: 12, Accrual: models.LeaveAccrualUpfront}, expectError: false},
+		{name: "Paid monthly", leaveType: models.LeaveType{Name: "Annual", Paid: true, YearlyEntitlement: 14.5, Accrual: models.LeaveAccrualMonthly}, expectError: false},
+		{name: "Unpaid without entitlement", leaveType: models.LeaveType{Name: "Unpaid", Accrual: models.LeaveAccrualUpfront}, expectError: false},
+		{name: "Missing name", leaveType: models.LeaveType{Name: "", Accrual: models.LeaveAccrualUpfront}, expectError: true},
+		{name: "Negative entitlement", leaveType: models.LeaveType{Name: "Annual", YearlyEntitlement: -1, Accrual: models.LeaveAccrualUpfront}, expectError: true},
+		{name: "Entitlement too large", leaveType: models.LeaveType{Name: "Annual", YearlyEntitlement: 400, Accrual: models.LeaveAccrualUpfront}, expectError: true},
+		{name: "Too precise", leaveType: models.LeaveType{Name: "Annual", YearlyEntitlement: 12.125, Accrual: models.LeaveAccrualUpfront}, expectError: true},
+		{name: "Unknown accrual", leaveType: models.LeaveType{Name: "Annual", YearlyEntitlement: 12, Accrual: "weekly"}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateLeaveType(tc.leaveType)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccruedLeaveDays(t *testing.T) {
+	upfront := models.LeaveType{YearlyEntitlement: 12, Accrual: models.LeaveAccrualUpfront}
+	monthly := models.LeaveType{YearlyEntitlement: 12, Accrual: models.LeaveAccrualMonthly}
+	joinedBefore := time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name      string
+		leaveType models.LeaveType
+		joinedAt  time.Time
+		year      int
+		asOf      time.Time
+		expected  float64
+	}{
+		{name: "Upfront grants the full year", leaveType: upfront, joinedAt: joinedBefore, year: 2024, asOf: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), expected: 12},
+		{name: "Monthly accrues through the current month", leaveType: monthly, joinedAt: joinedBefore, year: 2024, asOf: time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), expected: 3},
+		{name: "Monthly for a past year is complete", leaveType: monthly, joinedAt: joinedBefore, year: 2023, asOf: time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), expected: 12},
+		{name: "Future year has nothing yet", leaveType: upfront, joinedAt: joinedBefore, year: 2025, asOf: time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), expected: 0},
+		{name: "Upfront prorated from the joining month", leaveType: upfront, joinedAt: time.Date(2024, time.October, 20, 0, 0, 0, 0, time.UTC), year: 2024, asOf: time.Date(2024, time.October, 21, 0, 0, 0, 0, time.UTC), expected: 3},
+		{name: "Monthly from the joining month", leaveType: monthly, joinedAt: time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC), year: 2024, asOf: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), expected: 3},
+		{name: "Year before joining", leaveType: upfront, joinedAt: time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC), year: 2023, asOf: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), expected: 0},
+		{name: "Fractions rounded to two decimals", leaveType: models.LeaveType{YearlyEntitlement: 14, Accrual: models.LeaveAccrualMonthly}, joinedAt: joinedBefore, year: 2024, asOf: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), expected: 1.17},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.expected, AccruedLeaveDays(tc.leaveType, tc.joinedAt, tc.year, tc.asOf), 0.001)
+		})
+	}
+}
+
+func TestExpandLeaveDays(t *testing.T) {
+	date := func(day int) time.Time { return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC) }
+	annual := models.LeaveRequest{StartDate: date(7), EndDate: date(12), LeaveType: models.LeaveType{Name: "Annual", Paid: true}}
+	annual.ID = uuid.New()
+	overlapping := models.LeaveRequest{StartDate: date(12), EndDate: date(13), LeaveType: models.LeaveType{Name: "Unpaid"}}
+	overlapping.ID = uuid.New()
+
+	// Thursday 7th to Wednesday 13th; the weekend and Monday 11th (a holiday) are skipped, and the period ends on the 12th
+	days := ExpandLeaveDays([]models.LeaveRequest{annual, overlapping}, date(1), date(12), utils.StandardWorkWeek, utils.NewHolidaySet(date(11)))
+
+	var got []string
+	for _, d := range days {
+		got = append(got, d.Date.Format("2006-01-02"))
+		assert.Equal(t, annual.ID, d.LeaveRequestID)
+		assert.True(t, d.Paid)
+	}
+	assert.Equal(t, []string{"2024-03-07", "2024-03-08", "2024-03-12"}, got)
+}
+
+func TestCheckLeaveRequest_SpansYears(t *testing.T) {
+	// Rejected before any lookup, so no database is needed
+	request := models.LeaveRequest{
+		StartDate: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Days:      2,
+	}
+	err := NewLeaveService(nil).CheckLeaveRequest(models.Employee{}, models.LeaveType{Paid: true, YearlyEntitlement: 12}, request)
+	assert.ErrorIs(t, err, ErrLeaveSpansYears)
+}
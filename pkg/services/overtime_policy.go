@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// MaxOvertimeMultiplier is the largest tier multiplier an overtime policy may use; it fits the record's decimal(3,2)
+const MaxOvertimeMultiplier = 9.99
+
+var (
+	// ErrOvertimeCapExceeded is returned when overtime is over a daily or weekly cap of the overtime policy
+	ErrOvertimeCapExceeded = errors.New("overtime cap exceeded")
+	// ErrOvertimeTooShort is returned when overtime rounds down to no time at all
+	ErrOvertimeTooShort = errors.New("overtime too short")
+)
+
+// DefaultOvertimePolicy is the built-in policy used when no version is in effect. It follows
+// PP 35/2021 for a five-day week: on workdays 1.5x for the first hour and 2x after, on rest days
+// and holidays 2x for the first eight hours, 3x for the ninth and 4x for the tenth and eleventh.
+// Overtime is limited to 4 hours a workday and 18 hours a week and is counted to the minute.
+// It is not stored and has version 0.
+func DefaultOvertimePolicy() models.OvertimePolicy {
+	restDay := []models.OvertimeTier{{Hours: 8, Multiplier: 2}, {Hours: 1, Multiplier: 3}, {Hours: 2, Multiplier: 4}}
+	return models.OvertimePolicy{
+		Name:                 "Statutory (PP 35/2021, five-day week)",
+		WorkdayTiers:         []models.OvertimeTier{{Hours: 1, Multiplier: 1.5}, {Hours: 0, Multiplier: 2}},
+		RestDayTiers:         restDay,
+		HolidayTiers:         restDay,
+		DailyCapHours:        4,
+		RestDayDailyCapHours: 11,
+		WeeklyCapHours:       18,
+		RoundingMinutes:      1,
+		RoundingMode:         models.OvertimeRoundNearest,
+	}
+}
+
+// ValidateOvertimePolicy checks a policy's name, caps and tiers. Every hour allowed by a day
+// type's daily cap must be covered by one of its tiers.
+func ValidateOvertimePolicy(policy models.OvertimePolicy) error {
+	if strings.TrimSpace(policy.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(policy.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if policy.DailyCapHours < 1 || policy.DailyCapHours > 24 {
+		return fmt.Errorf("daily cap must be between 1 and 24 hours")
+	}
+	if policy.RestDayDailyCapHours < 1 || policy.RestDayDailyCapHours > 24 {
+		return fmt.Errorf("rest day daily cap must be between 1 and 24 hours")
+	}
+	if policy.WeeklyCapHours < 0 || policy.WeeklyCapHours > 7*24 {
+		return fmt.Errorf("weekly cap must be between 0 and %d hours", 7*24)
+	}
+	if policy.RoundingMinutes < 1 || policy.RoundingMinutes > 60 {
+		return fmt.Errorf("rounding must be between 1 and 60 minutes")
+	}
+	switch policy.RoundingMode {
+	case models.OvertimeRoundNearest, models.OvertimeRoundDown, models.OvertimeRoundUp:
+	default:
+		return fmt.Errorf("rounding mode must be %q, %q or %q", models.OvertimeRoundNearest, models.OvertimeRoundDown, models.OvertimeRoundUp)
+	}
+	for _, dayType := range []string{models.OvertimeDayWorkday, models.OvertimeDayRestDay, models.OvertimeDayHoliday} {
+		if err := validateOvertimeTiers(policy.Tiers(dayType), policy.DailyCap(dayType)); err != nil {
+			return fmt.Errorf("%s tiers: %w", dayType, err)
+		}
+	}
+	return nil
+}
+
+func validateOvertimeTiers(tiers []models.OvertimeTier, dailyCap int) error {
+	if len(tiers) == 0 {
+		return fmt.Errorf("at least one tier is required")
+	}
+	covered := 0
+	for i, tier := range tiers {
+		if tier.Multiplier < 1 || tier.Multiplier > MaxOvertimeMultiplier {
+			return fmt.Errorf("tier %d: multiplier must be between 1 and %.2f", i+1, MaxOvertimeMultiplier)
+		}
+		if !decimal.NewFromFloat(tier.Multiplier).Equal(decimal.NewFromFloat(tier.Multiplier).Round(2)) {
+			return fmt.Errorf("tier %d: multiplier must have at most 2 decimal places", i+1)
+		}
+		if tier.Hours < 0 {
+			return fmt.Errorf("tier %d: hours must not be negative", i+1)
+		}
+		if tier.Hours == 0 {
+			if i != len(tiers)-1 {
+				return fmt.Errorf("tier %d: only the last tier may cover the remaining hours", i+1)
+			}
+			return nil
+		}
+		covered += tier.Hours
+	}
+	if covered < dailyCap {
+		return fmt.Errorf("tiers cover %d hours but the daily cap is %d", covered, dailyCap)
+	}
+	return nil
+}
+
+// RoundOvertimeMinutes rounds an overtime duration to the policy's increment. Halfway durations round up when rounding to the nearest.
+func RoundOvertimeMinutes(policy models.OvertimePolicy, minutes int) int {
+	step := policy.RoundingMinutes
+	if step <= 1 {
+		return minutes
+	}
+	switch policy.RoundingMode {
+	case models.OvertimeRoundDown:
+		return minutes / step * step
+	case models.OvertimeRoundUp:
+		return (minutes + step - 1) / step * step
+	default:
+		return (minutes + step/2) / step * step
+	}
+}
+
+// ApplyOvertimePolicy splits overtime minutes into the policy's tiers for the day type
+func ApplyOvertimePolicy(policy models.OvertimePolicy, dayType string, minutes int) ([]models.OvertimeTierMinutes, error) {
+	if dailyCap := policy.DailyCap(dayType); minutes > dailyCap*60 {
+		return nil, fmt.Errorf("%w: at most %d hours on a %s", ErrOvertimeCapExceeded, dailyCap, strings.ReplaceAll(dayType, "_", " "))
+	}
+	var breakdown []models.OvertimeTierMinutes
+	remaining := minutes
+	for _, tier := range policy.Tiers(dayType) {
+		if remaining == 0 {
+			break
+		}
+		n := tier.Hours * 60
+		if n == 0 || n > remaining {
+			n = remaining
+		}
+		breakdown = append(breakdown, models.OvertimeTierMinutes{Minutes: n, Multiplier: tier.Multiplier})
+		remaining -= n
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("%w: the %s tiers cover only %d minutes", ErrOvertimeCapExceeded, dayType, minutes-remaining)
+	}
+	return breakdown, nil
+}
+
+// AverageOvertimeMultiplier returns the time-weighted multiplier of a breakdown, rounded to 2 decimal places
+func AverageOvertimeMultiplier(breakdown []models.OvertimeTierMinutes) float64 {
+	minutes := decimal.Zero
+	weighted := decimal.Zero
+	for _, tier := range breakdown {
+		m := decimal.NewFromInt(int64(tier.Minutes))
+		minutes = minutes.Add(m)
+		weighted = weighted.Add(m.Mul(decimal.NewFromFloat(tier.Multiplier)))
+	}
+	if minutes.IsZero() {
+		return 0
+	}
+	return weighted.Div(minutes).Round(2).InexactFloat64()
+}
+
+// OvertimePolicyService resolves overtime policies and prices overtime
+type OvertimePolicyService struct {
+	DB *gorm.DB
+}
+
+// NewOvertimePolicyService creates a new OvertimePolicyService
+func NewOvertimePolicyService(db *gorm.DB) *OvertimePolicyService {
+	return &OvertimePolicyService{DB: db}
+}
+
+// PolicyFor returns the latest policy version in effect on a date, or the default policy if none is
+func (s *OvertimePolicyService) PolicyFor(date time.Time) (models.OvertimePolicy, error) {
+	var policy models.OvertimePolicy
+	err := s.DB.Where("effective_from <= ?", date.Format("2006-01-02")).Order("effective_from DESC, version DESC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultOvertimePolicy(), nil
+	}
+	if err != nil {
+		return models.OvertimePolicy{}, fmt.Errorf("failed to load overtime policy: %w", err)
+	}
+	return policy, nil
+}
+
+// DayType classifies a date for overtime: holidays first, then the schedule's working days
+func (s *OvertimePolicyService) DayType(schedule models.WorkSchedule, date time.Time) (string, error) {
+	holiday, err := NewHolidayService(s.DB).FindHoliday(date)
+	if err != nil {
+		return "", err
+	}
+	if holiday != nil {
+		return models.OvertimeDayHoliday, nil
+	}
+	if !schedule.WorkingDays.Includes(date.Weekday()) {
+		return models.OvertimeDayRestDay, nil
+	}
+	return models.OvertimeDayWorkday, nil
+}
+
+// PriceOvertime applies the policy in effect on the record's date and day type: it rounds the record's
+// minutes, sets its day type, policy version, breakdown and average multiplier, and checks the daily and
+// weekly caps. Overtime already submitted in the same week counts towards the weekly cap unless it was rejected.
+func (s *OvertimePolicyService) PriceOvertime(record *models.OvertimeRecord, schedule models.WorkSchedule) error {
+	dayType, err := s.DayType(schedule, record.Date)
+	if err != nil {
+		return err
+	}
+	policy, err := s.PolicyFor(record.Date)
+	if err != nil {
+		return err
+	}
+	minutes := RoundOvertimeMinutes(policy, record.Minutes)
+	if minutes <= 0 {
+		return fmt.Errorf("%w: %d minutes round to zero at %d minute increments", ErrOvertimeTooShort, record.Minutes, policy.RoundingMinutes)
+	}
+	breakdown, err := ApplyOvertimePolicy(policy, dayType, minutes)
+	if err != nil {
+		return err
+	}
+	if policy.WeeklyCapHours > 0 {
+		claimed, err := s.weekOvertimeMinutes(record.EmployeeID, record.Date)
+		if err != nil {
+			return err
+		}
+		if claimed+minutes > policy.WeeklyCapHours*60 {
+			return fmt.Errorf("%w: %s of %d hours this week are already claimed", ErrOvertimeCapExceeded, formatHoursMinutes(time.Duration(claimed)*time.Minute), policy.WeeklyCapHours)
+		}
+	}
+
+	version := policy.Version
+	record.Minutes = minutes
+	record.DayType = dayType
+	record.PolicyVersion = &version
+	record.Breakdown = breakdown
+	record.RateMultiplier = AverageOvertimeMultiplier(breakdown)
+	return nil
+}
+
+// weekOvertimeMinutes sums the employee's pending and approved overtime in the Monday to Sunday week of the date
+func (s *OvertimePolicyService) weekOvertimeMinutes(employeeID uuid.UUID, date time.Time) (int, error) {
+	weekStart := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	var minutes int
+	err := s.DB.Model(&models.OvertimeRecord{}).
+		Where("employee_id = ? AND date BETWEEN ? AND ? AND status <> ?", employeeID, weekStart.Format("2006-01-02"), weekStart.AddDate(0, 0, 6).Format("2006-01-02"), models.OvertimeRejected).
+		Select("COALESCE(SUM(minutes), 0)").Scan(&minutes).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum weekly overtime: %w", err)
+	}
+	return minutes, nil
+}
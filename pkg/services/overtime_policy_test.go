@@ -0,0 +1,94 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOvertimePolicy(t *testing.T) {
+	policy := DefaultOvertimePolicy()
+	testCases := []struct {
+		name          string
+		dayType       string
+		minutes       int
+		expected      []models.OvertimeTierMinutes
+		expectedError error
+	}{
+		{name: "Part of the first workday hour", dayType: models.OvertimeDayWorkday, minutes: 45, expected: []models.OvertimeTierMinutes{{Minutes: 45, Multiplier: 1.5}}},
+		{name: "Workday time after the first hour", dayType: models.OvertimeDayWorkday, minutes: 150, expected: []models.OvertimeTierMinutes{{Minutes: 60, Multiplier: 1.5}, {Minutes: 90, Multiplier: 2}}},
+		{name: "Rest day within the first tier", dayType: models.OvertimeDayRestDay, minutes: 300, expected: []models.OvertimeTierMinutes{{Minutes: 300, Multiplier: 2}}},
+		{name: "Holiday into the top tier", dayType: models.OvertimeDayHoliday, minutes: 600, expected: []models.OvertimeTierMinutes{{Minutes: 480, Multiplier: 2}, {Minutes: 60, Multiplier: 3}, {Minutes: 60, Multiplier: 4}}},
+		{name: "Over the workday cap", dayType: models.OvertimeDayWorkday, minutes: 241, expectedError: ErrOvertimeCapExceeded},
+		{name: "Over the rest day cap", dayType: models.OvertimeDayRestDay, minutes: 12 * 60, expectedError: ErrOvertimeCapExceeded},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			breakdown, err := ApplyOvertimePolicy(policy, tc.dayType, tc.minutes)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, breakdown)
+		})
+	}
+}
+
+func TestRoundOvertimeMinutes(t *testing.T) {
+	testCases := []struct {
+		mode     string
+		step     int
+		minutes  int
+		expected int
+	}{
+		{mode: models.OvertimeRoundNearest, step: 1, minutes: 47, expected: 47},
+		{mode: models.OvertimeRoundNearest, step: 15, minutes: 52, expected: 45},
+		{mode: models.OvertimeRoundNearest, step: 15, minutes: 53, expected: 60},
+		{mode: models.OvertimeRoundNearest, step: 15, minutes: 7, expected: 0},
+		{mode: models.OvertimeRoundDown, step: 15, minutes: 59, expected: 45},
+		{mode: models.OvertimeRoundUp, step: 15, minutes: 46, expected: 60},
+		{mode: models.OvertimeRoundUp, step: 30, minutes: 60, expected: 60},
+	}
+	for _, tc := range testCases {
+		policy := models.OvertimePolicy{RoundingMinutes: tc.step, RoundingMode: tc.mode}
+		assert.Equal(t, tc.expected, RoundOvertimeMinutes(policy, tc.minutes), "%d minutes, %s %d", tc.minutes, tc.mode, tc.step)
+	}
+}
+
+func TestAverageOvertimeMultiplier(t *testing.T) {
+	assert.Equal(t, 1.83, AverageOvertimeMultiplier([]models.OvertimeTierMinutes{{Minutes: 60, Multiplier: 1.5}, {Minutes: 120, Multiplier: 2}}))
+	assert.Equal(t, 1.75, AverageOvertimeMultiplier([]models.OvertimeTierMinutes{{Minutes: 60, Multiplier: 1.5}, {Minutes: 60, Multiplier: 2}}))
+	assert.Zero(t, AverageOvertimeMultiplier(nil))
+}
+
+func TestValidateOvertimePolicy(t *testing.T) {
+	require.NoError(t, ValidateOvertimePolicy(DefaultOvertimePolicy()))
+
+	testCases := []struct {
+		name   string
+		modify func(p *models.OvertimePolicy)
+	}{
+		{name: "Missing name", modify: func(p *models.OvertimePolicy) { p.Name = " " }},
+		{name: "No workday cap", modify: func(p *models.OvertimePolicy) { p.DailyCapHours = 0 }},
+		{name: "Negative weekly cap", modify: func(p *models.OvertimePolicy) { p.WeeklyCapHours = -1 }},
+		{name: "No tiers", modify: func(p *models.OvertimePolicy) { p.HolidayTiers = nil }},
+		{name: "Multiplier below one", modify: func(p *models.OvertimePolicy) { p.WorkdayTiers = []models.OvertimeTier{{Multiplier: 0.5}} }},
+		{name: "Multiplier too precise", modify: func(p *models.OvertimePolicy) { p.WorkdayTiers = []models.OvertimeTier{{Multiplier: 1.555}} }},
+		{name: "Open tier before the last", modify: func(p *models.OvertimePolicy) {
+			p.WorkdayTiers = []models.OvertimeTier{{Multiplier: 1.5}, {Hours: 1, Multiplier: 2}}
+		}},
+		{name: "Tiers shorter than the cap", modify: func(p *models.OvertimePolicy) { p.RestDayDailyCapHours = 12 }},
+		{name: "Rounding over an hour", modify: func(p *models.OvertimePolicy) { p.RoundingMinutes = 90 }},
+		{name: "Unknown rounding mode", modify: func(p *models.OvertimePolicy) { p.RoundingMode = "ceiling" }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := DefaultOvertimePolicy()
+			tc.modify(&policy)
+			assert.Error(t, ValidateOvertimePolicy(policy))
+		})
+	}
+}
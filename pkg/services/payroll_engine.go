@@ -0,0 +1,596 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// DefaultWorkHoursPerDay is the number of hours in a working day used to derive the hourly overtime rate
+// when the input does not carry a work schedule.
+const DefaultWorkHoursPerDay = models.StandardHoursPerDay
+
+// Payroll line item types
+const (
+	LineItemSalary         = "salary"
+	LineItemOvertime       = "overtime"
+	LineItemReimbursement  = "reimbursement"
+	LineItemUnpaidLeave    = "unpaid_leave"
+	LineItemIncomeTax      = "income_tax"
+	LineItemSocialSecurity = "social_security"
+)
+
+// Payroll warning types
+const (
+	WarningUnapprovedOvertime    = "unapproved_overtime"
+	WarningMissingTaxProfile     = "missing_tax_profile"
+	WarningPayslipFailed         = "payslip_failed"
+	WarningNoSocialSecurityRates = "no_social_security_rates"
+	WarningIncomeTaxNotWithheld  = "income_tax_not_withheld"
+)
+
+// PayslipFailedWarning reports an employee whose payslip could not be calculated, so the rest of the payroll goes ahead without them
+func PayslipFailedWarning(employee models.Employee, err error) PayrollWarning {
+	return PayrollWarning{
+		Type:       WarningPayslipFailed,
+		EmployeeID: employee.ID,
+		Message:    fmt.Sprintf("No payslip was generated for %s: %v", employee.Username, err),
+	}
+}
+
+// ErrNoWorkingDays is returned when an employee's work schedule has no working days in the period
+var ErrNoWorkingDays = errors.New("no working days in this period")
+
+// PayrollInput holds everything the PayrollEngine needs to compute one employee's payslip.
+// It is plain data so it can be built from the database, a CLI, or directly in tests.
+type PayrollInput struct {
+	Employee          models.Employee
+	Period            models.AttendancePeriod
+	WorkSchedule      models.WorkSchedule    // Its HoursPerDay sets the hourly overtime rate; zero falls back to the engine default
+	Policy            models.PayrollPolicy   // Proration and rounding; without a proration method the default policy applies
+	SalaryHistory     []models.SalaryHistory // The employee's salaries by effective date; without any, the employee's Salary applies to the whole period
+	Holidays          utils.HolidaySet       // Excluded when counting the working days of each salary segment
+	TotalWorkingDays  int                    // Working days in the period under the employee's schedule, excluding holidays
+	AttendanceRecords []models.AttendanceRecord
+	LeaveDays         []LeaveDay              // Approved leave on working days in the period
+	OvertimeRecords   []models.OvertimeRecord // Approved overtime, paid
+	PendingOvertime   []models.OvertimeRecord // Overtime awaiting review, not paid but reported as warnings
+	Reimbursements    []models.ReimbursementRequest
+	SocialSecurity    *SocialSecurityTable // Contribution rates; nil makes no contributions
+	NoSocialSecurity  bool                 // Rates are loaded but none covers the period, so no contributions are made; reported as a warning
+	Tax               *TaxInput            // Income tax to withhold; nil withholds none
+}
+
+// PayrollLineItem is a single earning or deduction line that makes up a payslip
+type PayrollLineItem struct {
+	Type        string          `json:"type"` // salary, overtime, reimbursement, unpaid_leave, social_security, income_tax
+	Description string          `json:"description"`
+	SourceID    uuid.UUID       `json:"source_id"` // ID of the overtime/reimbursement record or leave request, Nil for salary and statutory deductions
+	Quantity    decimal.Decimal `json:"quantity"`  // Days paid, overtime hours, leave days, or 1 for reimbursements and statutory deductions
+	Rate        decimal.Decimal `json:"rate"`
+	Amount      models.Money    `json:"amount"` // Rounded by the payroll policy, except reimbursements; payslip totals are the sums of the lines
+}
+
+// PayrollWarning flags an item left out of a payslip that may need attention, such as overtime nobody has reviewed yet
+type PayrollWarning struct {
+	Type       string    `json:"type"` // unapproved_overtime, missing_tax_profile, payslip_failed, no_social_security_rates, income_tax_not_withheld
+	EmployeeID uuid.UUID `json:"employee_id"`
+	SourceID   uuid.UUID `json:"source_id"` // ID of the record left out, Nil when no record is
+	Message    string    `json:"message"`
+}
+
+// PayrollResult is the output of PayrollEngine.Calculate.
+// Payslip is not persisted; callers are responsible for setting audit fields and saving it.
+type PayrollResult struct {
+	Payslip       models.Payslip        `json:"payslip"`
+	LineItems     []PayrollLineItem     `json:"line_items"`
+	Contributions []PayrollContribution `json:"contributions,omitempty"`
+	RatesVersion  string                `json:"rates_version,omitempty"` // Version of the social security rates the contributions used
+	Warnings      []PayrollWarning      `json:"warnings,omitempty"`
+}
+
+// PayrollEngine computes payslips from attendance, overtime and reimbursement inputs.
+// It has no database dependency so salary rules can be unit-tested in isolation.
+type PayrollEngine struct {
+	HoursPerDay int
+}
+
+// NewPayrollEngine creates a new instance of PayrollEngine.
+func NewPayrollEngine() *PayrollEngine {
+	return &PayrollEngine{HoursPerDay: DefaultWorkHoursPerDay}
+}
+
+// Calculate computes the prorated salary, unpaid leave deductions, overtime pay, reimbursements, social security contributions, income tax
+// and take-home pay for one employee.
+// The period is split where the salary history changes the salary, and each segment is prorated at the salary in force by the
+// policy's method: the daily rate is the salary divided by the method's days, and the days the employee was absent without leave
+// are taken off the segment's share of those days. A segment that is not prorated is paid in full only when every working day
+// in it was attended or on leave, and by working days otherwise. Unpaid leave is deducted at the same daily rate. Overtime is always paid from
+// the salary per working day in force on its date, so it does not depend on the proration method. Social security contributions
+// are on the salary earned in the period, up to the salary in force at its end. Income tax is withheld on the prorated salary, overtime
+// and the employer contributions that count as income, less the employee contributions that are deductible; reimbursements are not
+// income. Tax is only withheld up to the pay left after contributions, so take-home pay is never negative.
+func (e *PayrollEngine) Calculate(input PayrollInput) (*PayrollResult, error) {
+	if input.TotalWorkingDays <= 0 {
+		return nil, fmt.Errorf("total working days must be greater than zero")
+	}
+	policy := input.Policy
+	if policy.ProrationMethod == "" {
+		policy = DefaultPayrollPolicy()
+	}
+	rules := policy.PayrollPolicyRules
+	hoursPerDay := decimal.NewFromFloat(input.WorkSchedule.HoursPerDay)
+	if !hoursPerDay.IsPositive() {
+		hoursPerDay = decimal.NewFromInt(int64(e.HoursPerDay))
+	}
+	if !hoursPerDay.IsPositive() {
+		return nil, fmt.Errorf("hours per day must be greater than zero")
+	}
+
+	var lineItems []PayrollLineItem
+
+	// Count each attended date once, even if duplicates slipped through
+	uniqueAttendanceDates := make(map[string]struct{})
+	for _, ar := range input.AttendanceRecords {
+		uniqueAttendanceDates[ar.Date.Format("2006-01-02")] = struct{}{}
+	}
+	attendedDates := make([]string, 0, len(uniqueAttendanceDates))
+	for key := range uniqueAttendanceDates {
+		attendedDates = append(attendedDates, key)
+	}
+	attendedDays := len(attendedDates)
+
+	// Paid leave counts as attended. Unpaid leave is paid like attendance and then deducted on its own line,
+	// so the payslip shows it explicitly. Days the employee attended anyway are not counted as leave.
+	var paidLeaveDates []string
+	var unpaidLeave []LeaveDay
+	for _, ld := range input.LeaveDays {
+		key := ld.Date.Format("2006-01-02")
+		if _, ok := uniqueAttendanceDates[key]; ok {
+			continue
+		}
+		uniqueAttendanceDates[key] = struct{}{}
+		if ld.Paid {
+			paidLeaveDates = append(paidLeaveDates, key)
+			continue
+		}
+		unpaidLeave = append(unpaidLeave, ld)
+	}
+	paidLeaveDays, totalUnpaidLeaveDays := len(paidLeaveDates), len(unpaidLeave)
+	attendanceCount := attendedDays + paidLeaveDays
+
+	// The period is paid in segments, one for each salary in force during it
+	segments := SalarySegments(input.SalaryHistory, input.Employee.Salary, input.Period.StartDate, input.Period.EndDate)
+	remainingWorkingDays := input.TotalWorkingDays
+	for i := range segments[:len(segments)-1] {
+		days := utils.CalculateWorkingDays(segments[i].From, segments[i].To, input.WorkSchedule.WorkingDays, input.Holidays)
+		if days > remainingWorkingDays {
+			days = remainingWorkingDays
+		}
+		segments[i].WorkingDays = days
+		remainingWorkingDays -= days
+	}
+	segments[len(segments)-1].WorkingDays = remainingWorkingDays
+
+	workingDays := decimal.NewFromInt(int64(input.TotalWorkingDays))
+	calendarDays := int(input.Period.EndDate.Sub(input.Period.StartDate).Hours()/24) + 1
+	divisor := ProrationDivisor(rules.ProrationMethod, input.TotalWorkingDays, calendarDays)
+
+	salaryPay := decimal.Zero
+	var unpaidLeaveLines []PayrollLineItem
+	unpaidLeaveDeduction := decimal.Zero
+	for i := range segments {
+		segment := &segments[i]
+		from, to := segment.From.Format("2006-01-02"), segment.To.Format("2006-01-02")
+		inSegment := func(key string) bool { return key >= from && key <= to }
+		attended, paidLeave := countDates(attendedDates, inSegment), countDates(paidLeaveDates, inSegment)
+		unpaidLeaveDays := make(map[uuid.UUID]int)
+		var unpaidLeaveRequests []LeaveDay // First day of each unpaid request in the segment, in order
+		for _, ld := range unpaidLeave {
+			if !inSegment(ld.Date.Format("2006-01-02")) {
+				continue
+			}
+			if unpaidLeaveDays[ld.LeaveRequestID] == 0 {
+				unpaidLeaveRequests = append(unpaidLeaveRequests, ld)
+			}
+			unpaidLeaveDays[ld.LeaveRequestID]++
+		}
+		unpaid := 0
+		for _, days := range unpaidLeaveDays {
+			unpaid += days
+		}
+		salaryDays := attended + paidLeave + unpaid
+		absentDays := segment.WorkingDays - salaryDays
+		if absentDays < 0 {
+			absentDays = 0
+		}
+
+		dailySalary := segment.Salary.Decimal.Div(divisor)
+		var paidDays decimal.Decimal
+		var description string
+		switch {
+		case rules.ProrationMethod == models.ProrationNone && salaryDays >= segment.WorkingDays:
+			paidDays = decimal.NewFromInt(int64(segment.WorkingDays))
+			description = fmt.Sprintf("Salary, not prorated (%d of %d working days)", salaryDays, segment.WorkingDays)
+		case rules.ProrationMethod == models.ProrationWorkingDays || rules.ProrationMethod == models.ProrationNone:
+			// Without full attendance, a salary that is not prorated falls back to working days, as under the default policy
+			paidDays = decimal.NewFromInt(int64(salaryDays))
+			description = fmt.Sprintf("Salary for %d of %d working days", salaryDays, segment.WorkingDays)
+		default:
+			// Each segment's share of the days is its calendar days, or the fixed divisor split by working days
+			days := divisor.Mul(decimal.NewFromInt(int64(segment.WorkingDays))).Div(workingDays)
+			unit := "days (fixed divisor)"
+			if rules.ProrationMethod == models.ProrationCalendarDays {
+				days = decimal.NewFromInt(int64(segment.To.Sub(segment.From).Hours()/24) + 1)
+				unit = "calendar days"
+			}
+			paidDays = decimal.Max(days.Sub(decimal.NewFromInt(int64(absentDays))), decimal.Zero)
+			description = fmt.Sprintf("Salary for %s of %s %s, %d of %d working days", paidDays.Round(2), days.Round(2), unit, salaryDays, segment.WorkingDays)
+		}
+		if len(segments) > 1 {
+			description = fmt.Sprintf("%s at %s, %s to %s", description, segment.Salary, from, to)
+		}
+		if paidLeave > 0 || unpaid > 0 {
+			description = fmt.Sprintf("%s (%d attended, %d paid leave, %d unpaid leave)", description, attended, paidLeave, unpaid)
+		}
+		segment.PaidDays = paidDays
+		segment.Amount = RoundPayrollAmount(rules, dailySalary.Mul(paidDays))
+		salaryPay = salaryPay.Add(segment.Amount.Decimal)
+		lineItems = append(lineItems, PayrollLineItem{
+			Type:        LineItemSalary,
+			Description: description,
+			Quantity:    paidDays,
+			Rate:        dailySalary,
+			Amount:      segment.Amount,
+		})
+
+		for _, ld := range unpaidLeaveRequests {
+			days := decimal.NewFromInt(int64(unpaidLeaveDays[ld.LeaveRequestID]))
+			amount := RoundPayrollAmount(rules, dailySalary.Mul(days).Neg())
+			unpaidLeaveDeduction = unpaidLeaveDeduction.Sub(amount.Decimal)
+			unpaidLeaveLines = append(unpaidLeaveLines, PayrollLineItem{
+				Type:        LineItemUnpaidLeave,
+				Description: fmt.Sprintf("Unpaid leave: %s", ld.LeaveType),
+				SourceID:    ld.LeaveRequestID,
+				Quantity:    days,
+				Rate:        dailySalary,
+				Amount:      amount,
+			})
+		}
+	}
+	lineItems = append(lineItems, unpaidLeaveLines...)
+	proratedSalary := salaryPay.Sub(unpaidLeaveDeduction)
+
+	overtimeMinutes := decimal.Zero
+	overtimePay := decimal.Zero
+	for _, ot := range input.OvertimeRecords {
+		tiers := ot.Breakdown
+		if len(tiers) == 0 {
+			// Overtime submitted before overtime policies is paid at its flat multiplier
+			tiers = []models.OvertimeTierMinutes{{Minutes: ot.Minutes, Multiplier: ot.RateMultiplier}}
+		}
+		day := ot.Date.Format("2006-01-02")
+		if ot.DayType != "" {
+			day += ", " + strings.ReplaceAll(ot.DayType, "_", " ")
+		}
+		hourlySalary := salaryOn(segments, ot.Date).Div(workingDays).Div(hoursPerDay)
+		for _, tier := range tiers {
+			// Pay is computed on the exact minutes; only the hours shown are rounded
+			minutes := decimal.NewFromInt(int64(tier.Minutes))
+			multiplier := decimal.NewFromFloat(tier.Multiplier)
+			rate := hourlySalary.Mul(multiplier)
+			amount := RoundPayrollAmount(rules, rate.Mul(minutes).Div(decimal.NewFromInt(60)))
+			overtimeMinutes = overtimeMinutes.Add(minutes)
+			overtimePay = overtimePay.Add(amount.Decimal)
+			lineItems = append(lineItems, PayrollLineItem{
+				Type:        LineItemOvertime,
+				Description: fmt.Sprintf("Overtime on %s (x%s)", day, multiplier.String()),
+				SourceID:    ot.ID,
+				Quantity:    minutes.Div(decimal.NewFromInt(60)).Round(4),
+				Rate:        rate,
+				Amount:      amount,
+			})
+		}
+	}
+
+	reimbursementsTotal := decimal.Zero
+	for _, rr := range input.Reimbursements {
+		amount := rr.Amount.Decimal
+		reimbursementsTotal = reimbursementsTotal.Add(amount)
+		lineItems = append(lineItems, PayrollLineItem{
+			Type:        LineItemReimbursement,
+			Description: rr.Description,
+			SourceID:    rr.ID,
+			Quantity:    decimal.NewFromInt(1),
+			Rate:        amount,
+			Amount:      models.NewMoney(amount),
+		})
+	}
+
+	var warnings []PayrollWarning
+	for _, ot := range input.PendingOvertime {
+		warnings = append(warnings, PayrollWarning{
+			Type:       WarningUnapprovedOvertime,
+			EmployeeID: input.Employee.ID,
+			SourceID:   ot.ID,
+			Message:    fmt.Sprintf("%s of overtime on %s for %s is not approved and was not paid", formatHoursMinutes(time.Duration(ot.Minutes)*time.Minute), ot.Date.Format("2006-01-02"), input.Employee.Username),
+		})
+	}
+
+	var contributions []PayrollContribution
+	var ratesVersion string
+	employeeContributions, employerContributions := decimal.Zero, decimal.Zero
+	taxableBenefits, deductibleContributions := decimal.Zero, decimal.Zero
+	if input.NoSocialSecurity {
+		warnings = append(warnings, PayrollWarning{
+			Type:       WarningNoSocialSecurityRates,
+			EmployeeID: input.Employee.ID,
+			Message:    fmt.Sprintf("No social security rates were in effect on %s, so no contributions were made for %s", input.Period.EndDate.Format("2006-01-02"), input.Employee.Username),
+		})
+	}
+	if input.SocialSecurity != nil {
+		ratesVersion = input.SocialSecurity.Version
+		// An employee who attended part of the period contributes on what they earned, so deductions never exceed the pay
+		wage := decimal.Max(decimal.Min(proratedSalary, segments[len(segments)-1].Salary.Decimal), decimal.Zero)
+		contributions = CalculateContributions(*input.SocialSecurity, wage, rules)
+		for _, contribution := range contributions {
+			employeeContributions = employeeContributions.Add(contribution.EmployeeAmount.Decimal)
+			employerContributions = employerContributions.Add(contribution.EmployerAmount.Decimal)
+			if contribution.EmployerTaxable {
+				taxableBenefits = taxableBenefits.Add(contribution.EmployerAmount.Decimal)
+			}
+			if contribution.TaxDeductible {
+				deductibleContributions = deductibleContributions.Add(contribution.EmployeeAmount.Decimal)
+			}
+			if !contribution.EmployeeAmount.IsPositive() {
+				continue
+			}
+			lineItems = append(lineItems, PayrollLineItem{
+				Type:        LineItemSocialSecurity,
+				Description: fmt.Sprintf("%s (%s%% of %s)", contribution.Name, contribution.EmployeeRate.Shift(2).String(), contribution.Wage),
+				Quantity:    decimal.NewFromInt(1),
+				Rate:        contribution.EmployeeAmount.Neg().Decimal,
+				Amount:      contribution.EmployeeAmount.Neg(),
+			})
+		}
+	}
+
+	taxableIncome, incomeTax := decimal.Zero, decimal.Zero
+	var taxCalculation *models.TaxCalculation
+	if input.Tax != nil {
+		taxableIncome = proratedSalary.Add(overtimePay).Add(taxableBenefits)
+		calculation, withholding := CalculateIncomeTax(*input.Tax, taxableIncome, deductibleContributions)
+		taxCalculation = &calculation
+		amount := RoundPayrollAmount(rules, withholding.Neg())
+		// Tax owed beyond the pay left after contributions, such as a year-end true-up for a short final period, is not withheld
+		payable := decimal.Max(proratedSalary.Add(overtimePay).Add(reimbursementsTotal).Sub(employeeContributions), decimal.Zero)
+		if amount.Neg().GreaterThan(payable) {
+			warnings = append(warnings, PayrollWarning{
+				Type:       WarningIncomeTaxNotWithheld,
+				EmployeeID: input.Employee.ID,
+				Message:    fmt.Sprintf("%s of income tax for %s exceeds their pay and was not withheld", models.NewMoney(amount.Neg().Decimal.Sub(payable)), input.Employee.Username),
+			})
+			amount = models.NewMoney(payable.Neg())
+			withholding = payable
+		}
+		incomeTax = amount.Neg().Decimal
+		if !amount.IsZero() {
+			description := fmt.Sprintf("Income tax (PPh 21, %s)", calculation.PTKPStatus)
+			if calculation.YearEnd {
+				description = fmt.Sprintf("%s, year-end true-up for %d", description, input.Period.EndDate.Year())
+			}
+			lineItems = append(lineItems, PayrollLineItem{
+				Type:        LineItemIncomeTax,
+				Description: description,
+				Quantity:    decimal.NewFromInt(1),
+				Rate:        withholding.Neg(),
+				Amount:      amount,
+			})
+		}
+		// Without a profile the tax assumes no PTKP allowances or tax ID, which only matters once there is tax to withhold
+		if calculation.ProfileMissing && !amount.IsZero() {
+			warnings = append(warnings, PayrollWarning{
+				Type:       WarningMissingTaxProfile,
+				EmployeeID: input.Employee.ID,
+				Message:    fmt.Sprintf("%s has no tax profile, so income tax was withheld as %s without a tax ID", input.Employee.Username, calculation.PTKPStatus),
+			})
+		}
+	}
+
+	takeHomePay := proratedSalary.Add(overtimePay).Add(reimbursementsTotal).Sub(employeeContributions).Sub(incomeTax)
+
+	payslip := models.Payslip{
+		EmployeeID:              input.Employee.ID,
+		AttendancePeriodID:      input.Period.ID,
+		BaseSalary:              segments[len(segments)-1].Salary,
+		ProratedSalary:          models.NewMoney(proratedSalary),
+		AttendanceCount:         attendanceCount,
+		TotalWorkingDays:        input.TotalWorkingDays,
+		PaidLeaveDays:           paidLeaveDays,
+		UnpaidLeaveDays:         totalUnpaidLeaveDays,
+		UnpaidLeaveDeduction:    models.NewMoney(unpaidLeaveDeduction),
+		OvertimeHours:           overtimeMinutes.Div(decimal.NewFromInt(60)).InexactFloat64(),
+		OvertimePay:             models.NewMoney(overtimePay),
+		ReimbursementsTotal:     models.NewMoney(reimbursementsTotal),
+		SocialSecurityDeduction: models.NewMoney(employeeContributions),
+		EmployerContributions:   models.NewMoney(employerContributions),
+		TaxableIncome:           models.NewMoney(taxableIncome),
+		IncomeTax:               models.NewMoney(incomeTax),
+		TakeHomePay:             models.NewMoney(takeHomePay),
+		PayrollPolicyVersion:    policy.Version,
+		PayrollPolicy:           &rules,
+		SalarySegments:          segments,
+		TaxCalculation:          taxCalculation,
+	}
+
+	return &PayrollResult{Payslip: payslip, LineItems: lineItems, Contributions: contributions, RatesVersion: ratesVersion, Warnings: warnings}, nil
+}
+
+// countDates counts the dates, formatted as YYYY-MM-DD, that match
+func countDates(dates []string, match func(string) bool) int {
+	n := 0
+	for _, date := range dates {
+		if match(date) {
+			n++
+		}
+	}
+	return n
+}
+
+// salaryOn returns the salary of the segment that includes a date, or of the last segment
+func salaryOn(segments []models.SalarySegment, date time.Time) decimal.Decimal {
+	key := date.Format("2006-01-02")
+	for _, segment := range segments {
+		if key >= segment.From.Format("2006-01-02") && key <= segment.To.Format("2006-01-02") {
+			return segment.Salary.Decimal
+		}
+	}
+	return segments[len(segments)-1].Salary.Decimal
+}
+
+// PayslipLineItems converts the result's line items into the rows kept with its payslip, in payslip order
+func (r *PayrollResult) PayslipLineItems(payslipID uuid.UUID) []models.PayslipLineItem {
+	rows := make([]models.PayslipLineItem, 0, len(r.LineItems))
+	for i, item := range r.LineItems {
+		row := models.PayslipLineItem{
+			PayslipID:   payslipID,
+			Position:    i + 1,
+			Type:        item.Type,
+			Description: item.Description,
+			Quantity:    item.Quantity.Round(4),
+			Rate:        item.Rate.Round(4),
+			Amount:      item.Amount,
+		}
+		if item.SourceID != uuid.Nil {
+			sourceID := item.SourceID
+			row.SourceID = &sourceID
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// PayslipContributions converts the result's contributions into the rows kept with its payslip
+func (r *PayrollResult) PayslipContributions(payslip models.Payslip) []models.PayslipContribution {
+	rows := make([]models.PayslipContribution, 0, len(r.Contributions))
+	for i, c := range r.Contributions {
+		rows = append(rows, models.PayslipContribution{
+			PayslipID:          payslip.ID,
+			EmployeeID:         payslip.EmployeeID,
+			AttendancePeriodID: payslip.AttendancePeriodID,
+			Position:           i + 1,
+			Program:            c.Program,
+			ProgramName:        c.Name,
+			RatesVersion:       r.RatesVersion,
+			Wage:               c.Wage,
+			EmployeeRate:       c.EmployeeRate,
+			EmployerRate:       c.EmployerRate,
+			EmployeeAmount:     c.EmployeeAmount,
+			EmployerAmount:     c.EmployerAmount,
+			TaxDeductible:      c.TaxDeductible,
+			EmployerTaxable:    c.EmployerTaxable,
+		})
+	}
+	return rows
+}
+
+// LoadPayrollEmployees returns the employees to pay for a period: everyone still active,
+// plus employees deactivated on or after the period start who may have worked part of it.
+func LoadPayrollEmployees(db *gorm.DB, period models.AttendancePeriod) ([]models.Employee, error) {
+	var employees []models.Employee
+	if err := db.Where("deactivated_at IS NULL OR deactivated_at >= ?", period.StartDate).
+		Order("id").
+		Find(&employees).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch employees: %w", err)
+	}
+	return employees, nil
+}
+
+// LoadPayrollInput fetches the employee's work schedule, salary history, tax profile and year-to-date tax, the payroll policy, the social security rates and the attendance, approved
+// leave, approved and pending overtime, and approved reimbursement records within a period, and counts the period's working days under that schedule.
+// It only reads; nothing is modified.
+func LoadPayrollInput(db *gorm.DB, employee models.Employee, period models.AttendancePeriod, holidays utils.HolidaySet) (PayrollInput, error) {
+	input := PayrollInput{
+		Employee: employee,
+		Period:   period,
+	}
+
+	schedule, err := ResolveWorkSchedule(db, employee)
+	if err != nil {
+		return input, err
+	}
+	input.WorkSchedule = schedule
+	input.Holidays = holidays
+	// The policy in effect when the period starts applies to the whole period
+	input.Policy, err = NewPayrollPolicyService(db).PolicyFor(period.StartDate)
+	if err != nil {
+		return input, err
+	}
+	input.TotalWorkingDays = utils.CalculateWorkingDays(period.StartDate, period.EndDate, schedule.WorkingDays, holidays)
+	if input.TotalWorkingDays == 0 {
+		return input, fmt.Errorf("%w: employee %s, work schedule %q", ErrNoWorkingDays, employee.Username, schedule.Name)
+	}
+
+	if err := db.Where("employee_id = ?", employee.ID).Order("effective_from").Find(&input.SalaryHistory).Error; err != nil {
+		return input, fmt.Errorf("failed to fetch salary history for employee %s: %w", employee.ID, err)
+	}
+
+	if err := db.Where("employee_id = ? AND date BETWEEN ? AND ?", employee.ID, period.StartDate, period.EndDate).
+		Find(&input.AttendanceRecords).Error; err != nil {
+		return input, fmt.Errorf("failed to fetch attendance records for employee %s: %w", employee.ID, err)
+	}
+
+	var leaveRequests []models.LeaveRequest
+	if err := db.Preload("LeaveType").
+		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", employee.ID, models.LeaveApproved, period.EndDate, period.StartDate).
+		Order("start_date").
+		Find(&leaveRequests).Error; err != nil {
+		return input, fmt.Errorf("failed to fetch leave requests for employee %s: %w", employee.ID, err)
+	}
+	input.LeaveDays = ExpandLeaveDays(leaveRequests, period.StartDate, period.EndDate, schedule.WorkingDays, holidays)
+
+	var overtimeRecords []models.OvertimeRecord
+	if err := db.Where("employee_id = ? AND date BETWEEN ? AND ? AND status IN ?", employee.ID, period.StartDate, period.EndDate, []string{models.OvertimeApproved, models.OvertimePending}).
+		Order("date").
+		Find(&overtimeRecords).Error; err != nil {
+		return input, fmt.Errorf("failed to fetch overtime records for employee %s: %w", employee.ID, err)
+	}
+	for _, ot := range overtimeRecords {
+		if ot.Status == models.OvertimeApproved {
+			input.OvertimeRecords = append(input.OvertimeRecords, ot)
+		} else {
+			input.PendingOvertime = append(input.PendingOvertime, ot)
+		}
+	}
+
+	if err := db.Where("employee_id = ? AND status = ? AND (attendance_period_id IS NULL OR attendance_period_id = ?)", employee.ID, models.ReimbursementApproved, period.ID).
+		Find(&input.Reimbursements).Error; err != nil {
+		return input, fmt.Errorf("failed to fetch reimbursements for employee %s: %w", employee.ID, err)
+	}
+
+	if SocialSecurityTables != nil {
+		table, err := SocialSecurityTables.TableFor(period.EndDate)
+		if errors.Is(err, ErrNoSocialSecurityRates) {
+			// Periods before the first rates still pay; the run warns that nothing was deducted
+			input.NoSocialSecurity = true
+		} else if err != nil {
+			return input, err
+		} else {
+			input.SocialSecurity = &table
+		}
+	}
+
+	input.Tax, err = LoadTaxInput(db, employee, period, schedule.WorkingDays)
+	if err != nil {
+		return input, err
+	}
+
+	return input, nil
+}
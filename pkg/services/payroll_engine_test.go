@@ -0,0 +1,566 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPayrollInput(salary string, workingDays int) PayrollInput {
+	employee := models.Employee{Username: "engine-emp", Salary: models.MustParseMoney(salary)}
+	employee.ID = uuid.New()
+	period := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+	}
+	period.ID = uuid.New()
+	return PayrollInput{Employee: employee, Period: period, TotalWorkingDays: workingDays}
+}
+
+// workingDaysOfMarch2024 are the 21 weekdays of March 2024
+var workingDaysOfMarch2024 = []int{1, 4, 5, 6, 7, 8, 11, 12, 13, 14, 15, 18, 19, 20, 21, 22, 25, 26, 27, 28, 29}
+
+func attendanceOn(days ...int) []models.AttendanceRecord {
+	var records []models.AttendanceRecord
+	for _, d := range days {
+		records = append(records, models.AttendanceRecord{Date: time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC)})
+	}
+	return records
+}
+
+func leaveOn(requestID uuid.UUID, leaveType string, paid bool, days ...int) []LeaveDay {
+	var leaveDays []LeaveDay
+	for _, d := range days {
+		leaveDays = append(leaveDays, LeaveDay{Date: time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC), LeaveRequestID: requestID, LeaveType: leaveType, Paid: paid})
+	}
+	return leaveDays
+}
+
+func TestPayrollEngine_Calculate(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		input                PayrollInput
+		expectedAttendance   int
+		expectedProrated     float64
+		expectedOvertimeHrs  float64
+		expectedOvertimePay  float64
+		expectedReimburse    float64
+		expectedTakeHome     float64
+		expectedUnpaidLeave  int
+		expectedUnpaidDeduct float64
+		expectedLineItems    int
+	}{
+		{
+			name: "Full attendance, no extras",
+			input: func() PayrollInput {
+				in := testPayrollInput("2000", 4)
+				in.AttendanceRecords = attendanceOn(4, 5, 6, 7)
+				return in
+			}(),
+			expectedAttendance: 4,
+			expectedProrated:   2000,
+			expectedTakeHome:   2000,
+			expectedLineItems:  1,
+		},
+		{
+			name: "Partial attendance with duplicate dates counted once",
+			input: func() PayrollInput {
+				in := testPayrollInput("2000", 4)
+				in.AttendanceRecords = attendanceOn(4, 4, 5)
+				return in
+			}(),
+			expectedAttendance: 2,
+			expectedProrated:   1000,
+			expectedTakeHome:   1000,
+			expectedLineItems:  1,
+		},
+		{
+			name: "Overtime at hourly rate times multiplier",
+			input: func() PayrollInput {
+				in := testPayrollInput("1600", 20) // 80/day, 10/hour
+				in.AttendanceRecords = attendanceOn(4)
+				in.OvertimeRecords = []models.OvertimeRecord{
+					{Date: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), Minutes: 180, RateMultiplier: 2.0},
+					{Date: time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC), Minutes: 60, RateMultiplier: 1.5},
+				}
+				return in
+			}(),
+			expectedAttendance:  1,
+			expectedProrated:    80,
+			expectedOvertimeHrs: 4,
+			expectedOvertimePay: 75, // 3*10*2 + 1*10*1.5
+			expectedTakeHome:    155,
+			expectedLineItems:   3,
+		},
+		{
+			name: "Reimbursements added on top of salary",
+			input: func() PayrollInput {
+				in := testPayrollInput("1000", 10)
+				in.Reimbursements = []models.ReimbursementRequest{
+					{Description: "Taxi", Amount: models.MustParseMoney("25.50")},
+					{Description: "Lunch", Amount: models.MustParseMoney("10.25")},
+				}
+				return in
+			}(),
+			expectedAttendance: 0,
+			expectedProrated:   0,
+			expectedReimburse:  35.75,
+			expectedTakeHome:   35.75,
+			expectedLineItems:  3,
+		},
+		{
+			name: "Overtime hourly rate uses the work schedule's daily hours",
+			input: func() PayrollInput {
+				in := testPayrollInput("1200", 20) // 60/day, 10/hour over 6 hours
+				in.WorkSchedule = models.WorkSchedule{Name: "Part-time", HoursPerDay: 6}
+				in.AttendanceRecords = attendanceOn(4)
+				in.OvertimeRecords = []models.OvertimeRecord{
+					{Date: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), Minutes: 120, RateMultiplier: 2.0},
+				}
+				return in
+			}(),
+			expectedAttendance:  1,
+			expectedProrated:    60,
+			expectedOvertimeHrs: 2,
+			expectedOvertimePay: 40,
+			expectedTakeHome:    100,
+			expectedLineItems:   2,
+		},
+		{
+			name: "Paid leave counts as attended",
+			input: func() PayrollInput {
+				in := testPayrollInput("2000", 4)
+				in.AttendanceRecords = attendanceOn(4, 5)
+				in.LeaveDays = leaveOn(uuid.New(), "Annual", true, 6, 7)
+				return in
+			}(),
+			expectedAttendance: 4,
+			expectedProrated:   2000,
+			expectedTakeHome:   2000,
+			expectedLineItems:  1,
+		},
+		{
+			name: "Unpaid leave deducted on its own line",
+			input: func() PayrollInput {
+				in := testPayrollInput("2000", 4)
+				in.AttendanceRecords = attendanceOn(4, 5)
+				in.LeaveDays = leaveOn(uuid.New(), "Unpaid", false, 6, 7)
+				return in
+			}(),
+			expectedAttendance:   2,
+			expectedProrated:     1000,
+			expectedUnpaidLeave:  2,
+			expectedUnpaidDeduct: 1000,
+			expectedTakeHome:     1000,
+			expectedLineItems:    2,
+		},
+		{
+			name: "Leave on an attended day is not counted",
+			input: func() PayrollInput {
+				in := testPayrollInput("2000", 4)
+				in.AttendanceRecords = attendanceOn(4, 5)
+				in.LeaveDays = append(leaveOn(uuid.New(), "Annual", true, 5, 6), leaveOn(uuid.New(), "Unpaid", false, 4)...)
+				return in
+			}(),
+			expectedAttendance: 3,
+			expectedProrated:   1500,
+			expectedTakeHome:   1500,
+			expectedLineItems:  1,
+		},
+	}
+
+	engine := NewPayrollEngine()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.Calculate(tc.input)
+			require.NoError(t, err)
+
+			p := result.Payslip
+			assert.Equal(t, tc.input.Employee.ID, p.EmployeeID)
+			assert.Equal(t, tc.input.Period.ID, p.AttendancePeriodID)
+			assert.Equal(t, tc.input.Employee.Salary, p.BaseSalary)
+			assert.Equal(t, tc.input.TotalWorkingDays, p.TotalWorkingDays)
+			assert.Equal(t, tc.expectedAttendance, p.AttendanceCount)
+			assert.InDelta(t, tc.expectedProrated, p.ProratedSalary.InexactFloat64(), 0.001)
+			assert.InDelta(t, tc.expectedOvertimeHrs, p.OvertimeHours, 0.001)
+			assert.InDelta(t, tc.expectedOvertimePay, p.OvertimePay.InexactFloat64(), 0.001)
+			assert.InDelta(t, tc.expectedReimburse, p.ReimbursementsTotal.InexactFloat64(), 0.001)
+			assert.InDelta(t, tc.expectedTakeHome, p.TakeHomePay.InexactFloat64(), 0.001)
+			assert.Equal(t, tc.expectedUnpaidLeave, p.UnpaidLeaveDays)
+			assert.InDelta(t, tc.expectedUnpaidDeduct, p.UnpaidLeaveDeduction.InexactFloat64(), 0.001)
+			assert.Len(t, result.LineItems, tc.expectedLineItems)
+			assert.Equal(t, LineItemSalary, result.LineItems[0].Type)
+		})
+	}
+}
+
+func TestPayrollEngine_ZeroWorkingDays(t *testing.T) {
+	_, err := NewPayrollEngine().Calculate(testPayrollInput("1000", 0))
+	assert.Error(t, err, "Should refuse to prorate over zero working days")
+}
+
+func TestPayrollEngine_PendingOvertimeIsWarnedNotPaid(t *testing.T) {
+	in := testPayrollInput("2000", 4)
+	in.AttendanceRecords = attendanceOn(4, 5)
+	pending := models.OvertimeRecord{Date: time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC), Minutes: 120, RateMultiplier: 2.0, Status: models.OvertimePending}
+	pending.ID = uuid.New()
+	in.PendingOvertime = []models.OvertimeRecord{pending}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	assert.True(t, result.Payslip.OvertimePay.IsZero())
+	assert.InDelta(t, 1000, result.Payslip.TakeHomePay.InexactFloat64(), 0.001)
+	assert.Len(t, result.LineItems, 1)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, WarningUnapprovedOvertime, result.Warnings[0].Type)
+	assert.Equal(t, pending.ID, result.Warnings[0].SourceID)
+	assert.Equal(t, in.Employee.ID, result.Warnings[0].EmployeeID)
+}
+
+func TestPayrollEngine_OvertimePaidPerTier(t *testing.T) {
+	in := testPayrollInput("4000", 5) // 800 a day, 100 an hour
+	in.AttendanceRecords = attendanceOn(4, 5, 6, 7, 8)
+	tiered := models.OvertimeRecord{
+		Date:           time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC),
+		Minutes:        600,
+		RateMultiplier: 2.3,
+		DayType:        models.OvertimeDayRestDay,
+		Breakdown:      []models.OvertimeTierMinutes{{Minutes: 480, Multiplier: 2}, {Minutes: 60, Multiplier: 3}, {Minutes: 60, Multiplier: 4}},
+		Status:         models.OvertimeApproved,
+	}
+	tiered.ID = uuid.New()
+	in.OvertimeRecords = []models.OvertimeRecord{tiered}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	assert.InDelta(t, 10, result.Payslip.OvertimeHours, 0.001)
+	assert.InDelta(t, 2300, result.Payslip.OvertimePay.InexactFloat64(), 0.001, "1600 + 300 + 400, not 10 hours at the average")
+
+	var overtimeLines []PayrollLineItem
+	for _, item := range result.LineItems {
+		if item.Type == LineItemOvertime {
+			overtimeLines = append(overtimeLines, item)
+		}
+	}
+	require.Len(t, overtimeLines, 3)
+	assert.Equal(t, "Overtime on 2024-03-09, rest day (x3)", overtimeLines[1].Description)
+	assert.True(t, overtimeLines[2].Rate.Equal(decimal.NewFromInt(400)))
+	assert.Equal(t, tiered.ID, overtimeLines[2].SourceID)
+}
+
+func TestPayrollEngine_OvertimePaidToTheMinute(t *testing.T) {
+	in := testPayrollInput("4000", 5) // 800 a day, 100 an hour
+	in.AttendanceRecords = attendanceOn(4, 5, 6, 7, 8)
+	ot := models.OvertimeRecord{
+		Date:      time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		Minutes:   100,
+		DayType:   models.OvertimeDayWorkday,
+		Breakdown: []models.OvertimeTierMinutes{{Minutes: 60, Multiplier: 1.5}, {Minutes: 40, Multiplier: 2}},
+		Status:    models.OvertimeApproved,
+	}
+	ot.ID = uuid.New()
+	in.OvertimeRecords = []models.OvertimeRecord{ot}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.67, result.Payslip.OvertimeHours, 0.005)
+	assert.Equal(t, "283.33", result.Payslip.OvertimePay.String(), "150 for the first hour plus 40 minutes at 200 an hour, rounded to the cent")
+	assert.Equal(t, "4283.33", result.Payslip.TakeHomePay.String())
+}
+
+func TestPayrollResult_PayslipLineItems(t *testing.T) {
+	in := testPayrollInput("4000", 3) // 1333.333... a day
+	in.AttendanceRecords = attendanceOn(4, 5, 6)
+	rr := models.ReimbursementRequest{Description: "Taxi", Amount: models.MustParseMoney("25.5")}
+	rr.ID = uuid.New()
+	in.Reimbursements = []models.ReimbursementRequest{rr}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	payslipID := uuid.New()
+	rows := result.PayslipLineItems(payslipID)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 1, rows[0].Position)
+	assert.Equal(t, LineItemSalary, rows[0].Type)
+	assert.Nil(t, rows[0].SourceID, "The salary line has no source record")
+	assert.Equal(t, "1333.3333", rows[0].Rate.String())
+	assert.Equal(t, "4000.00", rows[0].Amount.String())
+
+	assert.Equal(t, 2, rows[1].Position)
+	assert.Equal(t, payslipID, rows[1].PayslipID)
+	require.NotNil(t, rows[1].SourceID)
+	assert.Equal(t, rr.ID, *rows[1].SourceID)
+	assert.Equal(t, "25.50", rows[1].Amount.String())
+}
+
+func TestPayrollEngine_ProrationMethods(t *testing.T) {
+	// 21 working days in March 2024 (31 calendar days); one day absent without leave
+	attended := []int{1, 4, 5, 6, 7, 8, 11, 12, 13, 14, 15, 18, 19, 20, 21, 22, 25, 26, 27, 28}
+	testCases := []struct {
+		method   string
+		expected string
+	}{
+		{method: models.ProrationWorkingDays, expected: "2952.38"},  // 3100 / 21 * 20
+		{method: models.ProrationCalendarDays, expected: "3000.00"}, // 3100 / 31 * 30
+		{method: models.ProrationDivisor2175, expected: "2957.47"},  // 3100 / 21.75 * 20.75
+		{method: models.ProrationDivisor22, expected: "2959.09"},    // 3100 / 22 * 21
+		{method: models.ProrationNone, expected: "2952.38"},         // Not full attendance, so by working days
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method, func(t *testing.T) {
+			in := testPayrollInput("3100", 21)
+			in.AttendanceRecords = attendanceOn(attended...)
+			in.Policy = models.PayrollPolicy{Version: 3, PayrollPolicyRules: models.PayrollPolicyRules{ProrationMethod: tc.method, RoundingMode: models.PayrollRoundHalfUp, RoundingPrecision: 2}}
+			result, err := NewPayrollEngine().Calculate(in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Payslip.ProratedSalary.String())
+			assert.Equal(t, tc.expected, result.Payslip.TakeHomePay.String())
+			assert.Equal(t, 3, result.Payslip.PayrollPolicyVersion)
+			require.NotNil(t, result.Payslip.PayrollPolicy)
+			assert.Equal(t, tc.method, result.Payslip.PayrollPolicy.ProrationMethod, "The payslip records the rules it was calculated with")
+		})
+	}
+}
+
+func TestPayrollEngine_NoProrationNeedsFullAttendance(t *testing.T) {
+	policy := models.PayrollPolicy{PayrollPolicyRules: models.PayrollPolicyRules{ProrationMethod: models.ProrationNone, RoundingMode: models.PayrollRoundHalfUp, RoundingPrecision: 2}}
+	testCases := []struct {
+		name       string
+		attendance []models.AttendanceRecord
+		leave      []LeaveDay
+		expected   string
+	}{
+		{name: "Full attendance", attendance: attendanceOn(workingDaysOfMarch2024...), expected: "3100.00"},
+		{name: "Full attendance with paid leave", attendance: attendanceOn(workingDaysOfMarch2024[2:]...), leave: leaveOn(uuid.New(), "Annual", true, 1, 4), expected: "3100.00"},
+		{name: "Partial attendance", attendance: attendanceOn(workingDaysOfMarch2024[:14]...), expected: "2066.67"}, // 3100 / 21 * 14
+		{name: "No attendance", expected: "0.00"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := testPayrollInput("3100", 21)
+			in.Policy = policy
+			in.AttendanceRecords = tc.attendance
+			in.LeaveDays = tc.leave
+			result, err := NewPayrollEngine().Calculate(in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Payslip.ProratedSalary.String())
+		})
+	}
+}
+
+func TestPayrollEngine_PolicyRoundsEachLine(t *testing.T) {
+	in := testPayrollInput("3100", 21)
+	in.AttendanceRecords = attendanceOn(4, 5, 6, 7, 8, 11, 12, 13, 14, 15, 18, 19, 20, 21, 22, 25, 26, 27, 28)
+	in.LeaveDays = leaveOn(uuid.New(), "Unpaid", false, 1, 29)
+	in.OvertimeRecords = []models.OvertimeRecord{{Date: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), Minutes: 70, RateMultiplier: 1.5}}
+	in.Reimbursements = []models.ReimbursementRequest{{Description: "Taxi", Amount: models.MustParseMoney("25.55")}}
+	in.Policy = models.PayrollPolicy{PayrollPolicyRules: models.PayrollPolicyRules{ProrationMethod: models.ProrationCalendarDays, RoundingMode: models.PayrollRoundDown, RoundingPrecision: 0}}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	require.Len(t, result.LineItems, 4)
+	assert.Equal(t, "3100.00", result.LineItems[0].Amount.String(), "All 31 calendar days are paid before the unpaid leave is deducted")
+	assert.Equal(t, "-200.00", result.LineItems[1].Amount.String(), "Two days at 3100 / 31")
+	assert.Equal(t, "32.00", result.LineItems[2].Amount.String(), "3100 / 21 / 8 * 1.5 for 70 minutes is 32.29, rounded down")
+	assert.Equal(t, "25.55", result.LineItems[3].Amount.String(), "Reimbursements are never rounded")
+
+	p := result.Payslip
+	assert.Equal(t, "2900.00", p.ProratedSalary.String())
+	assert.Equal(t, "200.00", p.UnpaidLeaveDeduction.String())
+	assert.Equal(t, "32.00", p.OvertimePay.String())
+	assert.Equal(t, "2957.55", p.TakeHomePay.String(), "Totals are the sums of the rounded lines")
+	assert.Equal(t, 0, p.PayrollPolicyVersion)
+}
+
+func TestPayrollEngine_MidPeriodSalaryChange(t *testing.T) {
+	newInput := func(method string) PayrollInput {
+		in := testPayrollInput("5000", 21) // The employee's latest salary only takes effect in April
+		in.WorkSchedule = models.StandardWorkSchedule()
+		in.SalaryHistory = []models.SalaryHistory{salaryFrom(2023, time.January, 1, "3100"), salaryFrom(2024, time.March, 15, "4200"), salaryFrom(2024, time.April, 1, "5000")}
+		for d := 1; d <= 31; d++ {
+			if wd := time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC).Weekday(); wd != time.Saturday && wd != time.Sunday {
+				in.AttendanceRecords = append(in.AttendanceRecords, attendanceOn(d)...)
+			}
+		}
+		in.OvertimeRecords = []models.OvertimeRecord{{Date: time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), Minutes: 60, RateMultiplier: 2}}
+		in.Policy = models.PayrollPolicy{PayrollPolicyRules: models.PayrollPolicyRules{ProrationMethod: method, RoundingMode: models.PayrollRoundHalfUp, RoundingPrecision: 2}}
+		return in
+	}
+
+	result, err := NewPayrollEngine().Calculate(newInput(models.ProrationWorkingDays))
+	require.NoError(t, err)
+	p := result.Payslip
+	require.Len(t, p.SalarySegments, 2)
+	assert.Equal(t, 10, p.SalarySegments[0].WorkingDays)
+	assert.Equal(t, "1476.19", p.SalarySegments[0].Amount.String(), "3100 / 21 * 10")
+	assert.Equal(t, 11, p.SalarySegments[1].WorkingDays)
+	assert.Equal(t, "2200.00", p.SalarySegments[1].Amount.String(), "4200 / 21 * 11")
+	assert.Equal(t, "3676.19", p.ProratedSalary.String())
+	assert.Equal(t, "4200.00", p.BaseSalary.String(), "The salary in force at the end of the period")
+	assert.Equal(t, "50.00", p.OvertimePay.String(), "Overtime after the raise is paid at 4200 / 21 / 8 * 2")
+	require.Len(t, result.LineItems, 3)
+	assert.Equal(t, "Salary for 10 of 10 working days at 3100.00, 2024-03-01 to 2024-03-14", result.LineItems[0].Description)
+	assert.Equal(t, "Salary for 11 of 11 working days at 4200.00, 2024-03-15 to 2024-03-31", result.LineItems[1].Description)
+
+	result, err = NewPayrollEngine().Calculate(newInput(models.ProrationCalendarDays))
+	require.NoError(t, err)
+	p = result.Payslip
+	assert.Equal(t, "1400.00", p.SalarySegments[0].Amount.String(), "3100 / 31 * 14")
+	assert.Equal(t, "2303.23", p.SalarySegments[1].Amount.String(), "4200 / 31 * 17")
+	assert.Equal(t, "3703.23", p.ProratedSalary.String())
+}
+
+func TestPayrollEngine_UnpaidLeaveAtTheSalaryInForce(t *testing.T) {
+	in := testPayrollInput("4200", 21)
+	in.WorkSchedule = models.StandardWorkSchedule()
+	in.SalaryHistory = []models.SalaryHistory{salaryFrom(2023, time.January, 1, "3100"), salaryFrom(2024, time.March, 15, "4200")}
+	in.AttendanceRecords = attendanceOn(1, 4, 5, 6, 7, 8, 11, 12, 15, 18, 19, 20, 21, 22, 25, 26, 27, 28, 29)
+	requestID := uuid.New()
+	in.LeaveDays = leaveOn(requestID, "Unpaid", false, 13, 14) // Before the raise
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	require.Len(t, result.LineItems, 3)
+	assert.Equal(t, LineItemUnpaidLeave, result.LineItems[2].Type)
+	assert.Equal(t, "-295.24", result.LineItems[2].Amount.String(), "3100 / 21 * 2")
+	assert.Equal(t, "1476.19", result.LineItems[0].Amount.String(), "Unpaid leave is paid like attendance, then deducted")
+	assert.Equal(t, "3380.95", result.Payslip.ProratedSalary.String())
+}
+
+func TestPayrollEngine_WithholdsIncomeTax(t *testing.T) {
+	in := testPayrollInput("10000000", 21)
+	in.Policy = models.PayrollPolicy{PayrollPolicyRules: models.PayrollPolicyRules{ProrationMethod: models.ProrationNone, RoundingMode: models.PayrollRoundHalfUp, RoundingPrecision: 2}}
+	in.AttendanceRecords = attendanceOn(workingDaysOfMarch2024...)
+	in.Reimbursements = []models.ReimbursementRequest{{Amount: models.MustParseMoney("150000"), Description: "Taxi"}}
+	in.Tax = &TaxInput{Table: shippedTaxTable(t, in.Period.EndDate), Profile: taxProfile(models.MaritalSingle, 0, "092542943407000")}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	last := result.LineItems[len(result.LineItems)-1]
+	assert.Equal(t, LineItemIncomeTax, last.Type)
+	assert.Equal(t, "Income tax (PPh 21, TK/0)", last.Description)
+	assert.Equal(t, "-250000.00", last.Amount.String())
+	assert.Equal(t, "10000000.00", result.Payslip.TaxableIncome.String(), "Reimbursements are not taxed")
+	assert.Equal(t, "250000.00", result.Payslip.IncomeTax.String())
+	assert.Equal(t, "9900000.00", result.Payslip.TakeHomePay.String())
+	require.NotNil(t, result.Payslip.TaxCalculation)
+	assert.Equal(t, "60000000.00", result.Payslip.TaxCalculation.TaxableIncome.String())
+	assert.Empty(t, result.Warnings)
+}
+
+func TestPayrollEngine_YearEndTaxRefund(t *testing.T) {
+	in := testPayrollInput("10000000", 21)
+	in.Policy = models.PayrollPolicy{PayrollPolicyRules: models.PayrollPolicyRules{ProrationMethod: models.ProrationNone, RoundingMode: models.PayrollRoundHalfUp, RoundingPrecision: 2}}
+	in.AttendanceRecords = attendanceOn(workingDaysOfMarch2024...)
+	in.Tax = &TaxInput{
+		Table:      shippedTaxTable(t, in.Period.EndDate),
+		YearEnd:    true,
+		YearToDate: TaxYearToDate{GrossIncome: models.MustParseMoney("110000000"), Withheld: models.MustParseMoney("3800000"), Months: 11},
+	}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	last := result.LineItems[len(result.LineItems)-1]
+	assert.Equal(t, LineItemIncomeTax, last.Type)
+	assert.Equal(t, "Income tax (PPh 21, TK/0), year-end true-up for 2024", last.Description)
+	assert.Equal(t, "200000.00", last.Amount.String(), "3,600,000 due without a tax ID, 3,800,000 withheld")
+	assert.Equal(t, "-200000.00", result.Payslip.IncomeTax.String())
+	assert.Equal(t, "10200000.00", result.Payslip.TakeHomePay.String())
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, WarningMissingTaxProfile, result.Warnings[0].Type)
+}
+
+func TestPayrollEngine_SocialSecurityContributions(t *testing.T) {
+	in := testPayrollInput("10000000", 21)
+	in.Policy = models.PayrollPolicy{PayrollPolicyRules: models.PayrollPolicyRules{ProrationMethod: models.ProrationNone, RoundingMode: models.PayrollRoundHalfUp, RoundingPrecision: 2}}
+	in.AttendanceRecords = attendanceOn(workingDaysOfMarch2024...)
+	table := shippedSocialSecurityTable(t, in.Period.EndDate)
+	in.SocialSecurity = &table
+	in.Tax = &TaxInput{Table: shippedTaxTable(t, in.Period.EndDate), Profile: taxProfile(models.MaritalSingle, 0, "092542943407000")}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	var deductions []string
+	for _, item := range result.LineItems {
+		if item.Type == LineItemSocialSecurity {
+			deductions = append(deductions, item.Description+": "+item.Amount.String())
+		}
+	}
+	assert.Equal(t, []string{
+		"BPJS Kesehatan (1% of 10000000.00): -100000.00",
+		"BPJS Ketenagakerjaan JHT (2% of 10000000.00): -200000.00",
+		"BPJS Ketenagakerjaan JP (1% of 10000000.00): -100000.00",
+	}, deductions, "Programs without an employee share have no line")
+	require.Len(t, result.Contributions, 5)
+	assert.Equal(t, "400000.00", result.Payslip.SocialSecurityDeduction.String())
+	assert.Equal(t, "1024000.00", result.Payslip.EmployerContributions.String(), "400,000 + 370,000 + 200,000 + 24,000 + 30,000")
+
+	// Employer health, JKK and JKM count as income; JHT and JP are deducted before tax
+	assert.Equal(t, "10454000.00", result.Payslip.TaxableIncome.String())
+	require.NotNil(t, result.Payslip.TaxCalculation)
+	assert.Equal(t, "300000.00", result.Payslip.TaxCalculation.Contributions.String())
+	assert.Equal(t, "273100.00", result.Payslip.IncomeTax.String(), "5% of 60,000,000 and 15% of 1,848,000, a twelfth a month")
+	assert.Equal(t, "9326900.00", result.Payslip.TakeHomePay.String())
+
+	rows := result.PayslipContributions(result.Payslip)
+	require.Len(t, rows, 5)
+	assert.Equal(t, 1, rows[0].Position)
+	assert.Equal(t, "2024-03", rows[0].RatesVersion)
+	assert.Equal(t, in.Employee.ID, rows[4].EmployeeID)
+}
+
+func TestPayrollEngine_SocialSecurityOnEarnedWage(t *testing.T) {
+	table := shippedSocialSecurityTable(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC))
+
+	// Two thirds of the month attended: contributions are on the 6,666,666.67 earned
+	in := testPayrollInput("10000000", 21)
+	in.AttendanceRecords = attendanceOn(workingDaysOfMarch2024[:14]...)
+	in.SocialSecurity = &table
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	assert.Equal(t, "6666666.67", result.Payslip.ProratedSalary.String())
+	assert.Equal(t, "6666666.67", result.Contributions[0].Wage.String())
+	assert.Equal(t, "266666.67", result.Payslip.SocialSecurityDeduction.String())
+	assert.Equal(t, "6400000.00", result.Payslip.TakeHomePay.String())
+
+	// Nothing earned, nothing deducted
+	in.AttendanceRecords = nil
+	in.Tax = &TaxInput{Table: shippedTaxTable(t, in.Period.EndDate), Profile: taxProfile(models.MaritalSingle, 0, "092542943407000")}
+	result, err = NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	assert.Equal(t, "0.00", result.Payslip.ProratedSalary.String())
+	assert.Equal(t, "0.00", result.Payslip.SocialSecurityDeduction.String())
+	assert.Equal(t, "0.00", result.Payslip.EmployerContributions.String())
+	assert.Equal(t, "0.00", result.Payslip.IncomeTax.String())
+	assert.Equal(t, "0.00", result.Payslip.TakeHomePay.String())
+	for _, item := range result.LineItems {
+		assert.NotEqual(t, LineItemSocialSecurity, item.Type)
+	}
+}
+
+func TestPayrollEngine_TaxNotWithheldBeyondPay(t *testing.T) {
+	in := testPayrollInput("10000000", 21)
+	in.NoSocialSecurity = true
+	// 110,000,000 earned earlier in the year with nothing withheld: 2,525,000 is due at year end, but nothing was earned to withhold it from
+	in.Tax = &TaxInput{
+		Table:      shippedTaxTable(t, in.Period.EndDate),
+		Profile:    taxProfile(models.MaritalSingle, 0, "092542943407000"),
+		YearEnd:    true,
+		YearToDate: TaxYearToDate{GrossIncome: models.MustParseMoney("110000000"), Months: 11},
+	}
+
+	result, err := NewPayrollEngine().Calculate(in)
+	require.NoError(t, err)
+	assert.Equal(t, "0.00", result.Payslip.IncomeTax.String())
+	assert.Equal(t, "0.00", result.Payslip.TakeHomePay.String())
+	require.Len(t, result.Warnings, 2)
+	assert.Equal(t, WarningNoSocialSecurityRates, result.Warnings[0].Type)
+	assert.Equal(t, WarningIncomeTaxNotWithheld, result.Warnings[1].Type)
+	assert.Contains(t, result.Warnings[1].Message, "2525000.00 of income tax")
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/models"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Payroll policies may round each line to between MinPayrollRoundingPrecision and MaxPayrollRoundingPrecision decimal places
+const (
+	MinPayrollRoundingPrecision = -3 // Thousands
+	MaxPayrollRoundingPrecision = models.MoneyPlaces
+)
+
+// DefaultPayrollPolicy is the built-in policy used when no version is in effect: salary is prorated by the
+// working days in the period and each line is rounded half up to the cent. It is not stored and has version 0.
+func DefaultPayrollPolicy() models.PayrollPolicy {
+	return models.PayrollPolicy{
+		Name: "Working days, rounded to the cent",
+		PayrollPolicyRules: models.PayrollPolicyRules{
+			ProrationMethod:   models.ProrationWorkingDays,
+			RoundingMode:      models.PayrollRoundHalfUp,
+			RoundingPrecision: models.MoneyPlaces,
+		},
+	}
+}
+
+// ValidatePayrollPolicy checks a policy's name, proration method and rounding
+func ValidatePayrollPolicy(policy models.PayrollPolicy) error {
+	if strings.TrimSpace(policy.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(policy.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	switch policy.ProrationMethod {
+	case models.ProrationWorkingDays, models.ProrationCalendarDays, models.ProrationDivisor2175, models.ProrationDivisor22, models.ProrationNone:
+	default:
+		return fmt.Errorf("proration method must be one of %q, %q, %q, %q or %q", models.ProrationWorkingDays, models.ProrationCalendarDays,
+			models.ProrationDivisor2175, models.ProrationDivisor22, models.ProrationNone)
+	}
+	switch policy.RoundingMode {
+	case models.PayrollRoundHalfUp, models.PayrollRoundHalfEven, models.PayrollRoundDown, models.PayrollRoundUp:
+	default:
+		return fmt.Errorf("rounding mode must be %q, %q, %q or %q", models.PayrollRoundHalfUp, models.PayrollRoundHalfEven, models.PayrollRoundDown, models.PayrollRoundUp)
+	}
+	if policy.RoundingPrecision < MinPayrollRoundingPrecision || policy.RoundingPrecision > MaxPayrollRoundingPrecision {
+		return fmt.Errorf("rounding precision must be between %d and %d", MinPayrollRoundingPrecision, MaxPayrollRoundingPrecision)
+	}
+	return nil
+}
+
+// RoundPayrollAmount rounds an amount to the rules' precision in the rules' mode. Down and up round towards
+// and away from zero, so a deduction rounded down is never larger than calculated.
+func RoundPayrollAmount(rules models.PayrollPolicyRules, amount decimal.Decimal) models.Money {
+	places := int32(rules.RoundingPrecision)
+	switch rules.RoundingMode {
+	case models.PayrollRoundHalfEven:
+		return models.NewMoney(amount.RoundBank(places))
+	case models.PayrollRoundDown:
+		return models.NewMoney(amount.RoundDown(places))
+	case models.PayrollRoundUp:
+		return models.NewMoney(amount.RoundUp(places))
+	default:
+		return models.NewMoney(amount.Round(places))
+	}
+}
+
+// ProrationDivisor returns the number of days the monthly salary is divided by to get the daily rate. The working
+// days method and no proration use the working days in the period, the calendar days method its calendar days.
+func ProrationDivisor(method string, workingDays, calendarDays int) decimal.Decimal {
+	switch method {
+	case models.ProrationCalendarDays:
+		return decimal.NewFromInt(int64(calendarDays))
+	case models.ProrationDivisor2175:
+		return decimal.RequireFromString("21.75")
+	case models.ProrationDivisor22:
+		return decimal.NewFromInt(22)
+	default:
+		return decimal.NewFromInt(int64(workingDays))
+	}
+}
+
+// PayrollPolicyService resolves payroll policies
+type PayrollPolicyService struct {
+	DB *gorm.DB
+}
+
+// NewPayrollPolicyService creates a new PayrollPolicyService
+func NewPayrollPolicyService(db *gorm.DB) *PayrollPolicyService {
+	return &PayrollPolicyService{DB: db}
+}
+
+// PolicyFor returns the latest policy version in effect on a date, or the default policy if none is
+func (s *PayrollPolicyService) PolicyFor(date time.Time) (models.PayrollPolicy, error) {
+	var policy models.PayrollPolicy
+	err := s.DB.Where("effective_from <= ?", date.Format("2006-01-02")).Order("effective_from DESC, version DESC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultPayrollPolicy(), nil
+	}
+	if err != nil {
+		return models.PayrollPolicy{}, fmt.Errorf("failed to load payroll policy: %w", err)
+	}
+	return policy, nil
+}
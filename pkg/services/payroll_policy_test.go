@@ -0,0 +1,54 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundPayrollAmount(t *testing.T) {
+	testCases := []struct {
+		mode      string
+		precision int
+		amount    string
+		expected  string
+	}{
+		{mode: models.PayrollRoundHalfUp, precision: 2, amount: "2952.380952", expected: "2952.38"},
+		{mode: models.PayrollRoundHalfUp, precision: 0, amount: "2.5", expected: "3.00"},
+		{mode: models.PayrollRoundHalfUp, precision: 0, amount: "-2.5", expected: "-3.00"},
+		{mode: models.PayrollRoundHalfEven, precision: 0, amount: "2.5", expected: "2.00"},
+		{mode: models.PayrollRoundHalfEven, precision: 0, amount: "3.5", expected: "4.00"},
+		{mode: models.PayrollRoundDown, precision: 0, amount: "2952.99", expected: "2952.00"},
+		{mode: models.PayrollRoundDown, precision: 0, amount: "-95.99", expected: "-95.00"},
+		{mode: models.PayrollRoundUp, precision: 1, amount: "10.01", expected: "10.10"},
+		{mode: models.PayrollRoundUp, precision: 0, amount: "-95.01", expected: "-96.00"},
+		{mode: models.PayrollRoundHalfUp, precision: -2, amount: "2952.38", expected: "3000.00"},
+		{mode: models.PayrollRoundDown, precision: -3, amount: "2952.38", expected: "2000.00"},
+	}
+	for _, tc := range testCases {
+		rules := models.PayrollPolicyRules{RoundingMode: tc.mode, RoundingPrecision: tc.precision}
+		assert.Equal(t, tc.expected, RoundPayrollAmount(rules, decimal.RequireFromString(tc.amount)).String(), "%s, %s to %d places", tc.amount, tc.mode, tc.precision)
+	}
+}
+
+func TestValidatePayrollPolicy(t *testing.T) {
+	assert.NoError(t, ValidatePayrollPolicy(DefaultPayrollPolicy()))
+
+	testCases := []struct {
+		name   string
+		modify func(*models.PayrollPolicy)
+	}{
+		{name: "Blank name", modify: func(p *models.PayrollPolicy) { p.Name = " " }},
+		{name: "Unknown proration method", modify: func(p *models.PayrollPolicy) { p.ProrationMethod = "weekly" }},
+		{name: "Unknown rounding mode", modify: func(p *models.PayrollPolicy) { p.RoundingMode = "nearest" }},
+		{name: "Below the cent", modify: func(p *models.PayrollPolicy) { p.RoundingPrecision = 3 }},
+		{name: "Above thousands", modify: func(p *models.PayrollPolicy) { p.RoundingPrecision = -4 }},
+	}
+	for _, tc := range testCases {
+		policy := DefaultPayrollPolicy()
+		tc.modify(&policy)
+		assert.Error(t, ValidatePayrollPolicy(policy), tc.name)
+	}
+}
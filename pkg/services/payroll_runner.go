@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils" // For logger
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PayrollQueue is the process-wide payroll runner, set by StartPayrollWorkers
+var PayrollQueue *PayrollRunner
+
+// PayrollRunner processes persisted PayrollRun jobs on background workers.
+type PayrollRunner struct {
+	DB     *gorm.DB
+	Engine *PayrollEngine
+	jobs   chan uuid.UUID
+}
+
+// NewPayrollRunner creates a new instance of PayrollRunner with a buffered job queue.
+func NewPayrollRunner(db *gorm.DB, queueSize int) *PayrollRunner {
+	return &PayrollRunner{
+		DB:     db,
+		Engine: NewPayrollEngine(),
+		jobs:   make(chan uuid.UUID, queueSize),
+	}
+}
+
+// StartPayrollWorkers creates the global PayrollQueue and starts its workers.
+func StartPayrollWorkers(ctx context.Context, db *gorm.DB, workers int) *PayrollRunner {
+	PayrollQueue = NewPayrollRunner(db, 100)
+	PayrollQueue.Start(ctx, workers)
+	return PayrollQueue
+}
+
+// Start launches the worker goroutines and re-queues runs left unfinished by a previous process.
+func (r *PayrollRunner) Start(ctx context.Context, workers int) {
+	for i := 0; i < workers; i++ {
+		go r.work(ctx)
+	}
+
+	var pending []uuid.UUID
+	if err := r.DB.Model(&models.PayrollRun{}).
+		Where("status IN ?", []string{models.PayrollRunQueued, models.PayrollRunRunning}).
+		Order("created_at").
+		Pluck("id", &pending).Error; err != nil {
+		utils.Logger.Error("Failed to load pending payroll runs", zap.Error(err))
+		return
+	}
+	for _, runID := range pending {
+		if err := r.Enqueue(runID); err != nil {
+			utils.Logger.Error("Failed to re-queue payroll run", zap.Error(err), zap.String("run_id", runID.String()))
+		}
+	}
+}
+
+// Enqueue schedules a run for processing. It does not block; a full queue is reported as an error.
+func (r *PayrollRunner) Enqueue(runID uuid.UUID) error {
+	select {
+	case r.jobs <- runID:
+		return nil
+	default:
+		return fmt.Errorf("payroll queue is full")
+	}
+}
+
+func (r *PayrollRunner) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case runID := <-r.jobs:
+			if err := r.Process(runID); err != nil {
+				utils.Logger.Error("Payroll run failed", zap.Error(err), zap.String("run_id", runID.String()))
+			}
+		}
+	}
+}
+
+// Process executes a payroll run synchronously. Each employee is handled in its own transaction. An employee whose payslip
+// cannot be calculated, e.g. because their schedule has no working days in the period, is counted as failed and listed under
+// the run's warnings while the others are still paid. The period is only closed when every employee has a payslip; otherwise
+// the run fails and payroll can be run again for the period, skipping the employees that already have an active payslip, or the
+// partial run can be voided.
+func (r *PayrollRunner) Process(runID uuid.UUID) (err error) {
+	var run models.PayrollRun
+	if err := r.DB.First(&run, "id = ?", runID).Error; err != nil {
+		return fmt.Errorf("failed to load payroll run %s: %w", runID, err)
+	}
+	if run.Status == models.PayrollRunCompleted || run.Status == models.PayrollRunFailed {
+		return nil
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("payroll run panicked: %v", rec)
+		}
+		if err != nil {
+			r.fail(&run, err)
+		}
+	}()
+
+	now := time.Now()
+	run.Status = models.PayrollRunRunning
+	run.StartedAt = &now
+	run.ProcessedEmployees, run.PayslipsGenerated, run.SkippedEmployees, run.FailedEmployees = 0, 0, 0, 0
+	if err := r.DB.Save(&run).Error; err != nil {
+		return fmt.Errorf("failed to mark payroll run as running: %w", err)
+	}
+
+	var period models.AttendancePeriod
+	if err := r.DB.First(&period, "id = ?", run.AttendancePeriodID).Error; err != nil {
+		return fmt.Errorf("failed to load attendance period: %w", err)
+	}
+	if period.PayrollRunAt != nil {
+		return fmt.Errorf("payroll already run for this period")
+	}
+
+	// Working days are counted per employee from their work schedule
+	holidays, err := NewHolidayService(r.DB).LoadHolidaySet(period.StartDate, period.EndDate)
+	if err != nil {
+		return err
+	}
+
+	employees, err := LoadPayrollEmployees(r.DB, period)
+	if err != nil {
+		return err
+	}
+	run.TotalEmployees = len(employees)
+	if err := r.DB.Model(&run).Update("total_employees", run.TotalEmployees).Error; err != nil {
+		return fmt.Errorf("failed to update payroll run progress: %w", err)
+	}
+
+	warnings := []PayrollWarning{}
+	for _, emp := range employees {
+		created, employeeWarnings, err := r.processEmployee(run, period, emp, holidays)
+		warnings = append(warnings, employeeWarnings...)
+		run.ProcessedEmployees++
+		switch {
+		case err != nil:
+			utils.Logger.Warn("Payslip failed", zap.Error(err), zap.String("run_id", run.ID.String()), zap.String("employee_id", emp.ID.String()))
+			run.FailedEmployees++
+			warnings = append(warnings, PayslipFailedWarning(emp, err))
+		case created:
+			run.PayslipsGenerated++
+		default:
+			run.SkippedEmployees++
+		}
+		if err := r.DB.Model(&run).Updates(map[string]interface{}{
+			"processed_employees": run.ProcessedEmployees,
+			"payslips_generated":  run.PayslipsGenerated,
+			"skipped_employees":   run.SkippedEmployees,
+			"failed_employees":    run.FailedEmployees,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update payroll run progress: %w", err)
+		}
+	}
+
+	warningsJSON, err := json.Marshal(warnings)
+	if err != nil {
+		return fmt.Errorf("failed to encode payroll warnings: %w", err)
+	}
+	run.Warnings = datatypes.JSON(warningsJSON)
+	if run.FailedEmployees > 0 {
+		// The period stays open so the failed employees can be paid by running payroll again once they are fixed
+		return fmt.Errorf("no payslip was generated for %d of %d employees; see the warnings, then run payroll for the period again", run.FailedEmployees, run.TotalEmployees)
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		finishedAt := time.Now()
+		period.PayrollRunAt = &finishedAt
+		period.UpdatedBy = run.CreatedBy
+		period.IPAddress = run.IPAddress
+		if err := tx.Save(&period).Error; err != nil {
+			return fmt.Errorf("failed to update attendance period: %w", err)
+		}
+
+		run.Status = models.PayrollRunCompleted
+		run.FinishedAt = &finishedAt
+		if err := tx.Save(&run).Error; err != nil {
+			return fmt.Errorf("failed to complete payroll run: %w", err)
+		}
+
+		NewAuditService(tx).CreateAuditLog(AuditLogEntryParams{
+			UserID:           derefUUID(run.CreatedBy),
+			UserType:         "admin",
+			Action:           "run_payroll",
+			TargetResource:   "attendance_period",
+			TargetResourceID: period.ID,
+			Changes:          map[string]interface{}{"payslips_generated": run.PayslipsGenerated, "period_id": period.ID, "payroll_run_id": run.ID, "warnings": len(warnings)},
+			IPAddress:        derefString(run.IPAddress),
+			RequestID:        run.RequestID,
+			PerformedBy:      derefUUID(run.CreatedBy),
+		})
+		return nil
+	})
+}
+
+// processEmployee writes one employee's payslip and marks their reimbursements as paid, returning the payslip's warnings.
+// It returns false if the employee already had an active payslip for the period, with the warnings kept on that payslip.
+func (r *PayrollRunner) processEmployee(run models.PayrollRun, period models.AttendancePeriod, emp models.Employee, holidays utils.HolidaySet) (bool, []PayrollWarning, error) {
+	created := false
+	var warnings []PayrollWarning
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var existing []models.Payslip
+		if err := tx.Select("id", "warnings").
+			Where("employee_id = ? AND attendance_period_id = ? AND voided_at IS NULL", emp.ID, period.ID).
+			Limit(1).
+			Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check existing payslip for employee %s: %w", emp.ID, err)
+		}
+		if len(existing) > 0 {
+			if len(existing[0].Warnings) > 0 {
+				if err := json.Unmarshal(existing[0].Warnings, &warnings); err != nil {
+					return fmt.Errorf("failed to decode warnings of payslip %s: %w", existing[0].ID, err)
+				}
+			}
+			return nil
+		}
+
+		input, err := LoadPayrollInput(tx, emp, period, holidays)
+		if err != nil {
+			return err
+		}
+		result, err := r.Engine.Calculate(input)
+		if err != nil {
+			return fmt.Errorf("failed to calculate payslip for employee %s: %w", emp.ID, err)
+		}
+
+		for i := range input.Reimbursements {
+			rr := &input.Reimbursements[i]
+			rr.AttendancePeriodID = period.ID
+			rr.Status = models.ReimbursementPaid
+			rr.UpdatedBy = run.CreatedBy
+			rr.IPAddress = run.IPAddress
+			if err := tx.Save(rr).Error; err != nil {
+				return fmt.Errorf("failed to update reimbursement request %s: %w", rr.ID, err)
+			}
+		}
+
+		payslip := result.Payslip
+		warningsJSON, err := json.Marshal(result.Warnings)
+		if err != nil {
+			return fmt.Errorf("failed to encode warnings for employee %s: %w", emp.ID, err)
+		}
+		payslip.Warnings = datatypes.JSON(warningsJSON)
+		payslip.CreatedBy = run.CreatedBy
+		payslip.UpdatedBy = run.CreatedBy
+		payslip.IPAddress = run.IPAddress
+		if err := tx.Create(&payslip).Error; err != nil {
+			return fmt.Errorf("failed to create payslip for employee %s: %w", emp.ID, err)
+		}
+		lineItems := result.PayslipLineItems(payslip.ID)
+		for i := range lineItems {
+			lineItems[i].CreatedBy = run.CreatedBy
+			lineItems[i].UpdatedBy = run.CreatedBy
+			lineItems[i].IPAddress = run.IPAddress
+		}
+		if len(lineItems) > 0 {
+			if err := tx.Create(&lineItems).Error; err != nil {
+				return fmt.Errorf("failed to create payslip line items for employee %s: %w", emp.ID, err)
+			}
+		}
+		contributions := result.PayslipContributions(payslip)
+		for i := range contributions {
+			contributions[i].CreatedBy = run.CreatedBy
+			contributions[i].UpdatedBy = run.CreatedBy
+			contributions[i].IPAddress = run.IPAddress
+		}
+		if len(contributions) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&contributions).Error; err != nil {
+				return fmt.Errorf("failed to create payslip contributions for employee %s: %w", emp.ID, err)
+			}
+		}
+		created = true
+		warnings = result.Warnings
+		return nil
+	})
+	return created, warnings, err
+}
+
+func (r *PayrollRunner) fail(run *models.PayrollRun, cause error) {
+	finishedAt := time.Now()
+	message := cause.Error()
+	updates := map[string]interface{}{
+		"status":      models.PayrollRunFailed,
+		"finished_at": finishedAt,
+		"error":       message,
+	}
+	if len(run.Warnings) > 0 {
+		updates["warnings"] = run.Warnings
+	}
+	if err := r.DB.Model(run).Updates(updates).Error; err != nil {
+		utils.Logger.Error("Failed to mark payroll run as failed", zap.Error(err), zap.String("run_id", run.ID.String()))
+	}
+}
+
+// HasActivePayslip reports whether the employee already has a payslip that is not voided for a period overlapping the dates.
+// A run that failed for other employees leaves the period open, but changes to these days would not be paid until it is voided.
+func HasActivePayslip(db *gorm.DB, employeeID uuid.UUID, from, to time.Time) (bool, error) {
+	var count int64
+	if err := db.Model(&models.Payslip{}).
+		Joins("JOIN attendance_periods ON attendance_periods.id = payslips.attendance_period_id").
+		Where("payslips.employee_id = ? AND payslips.voided_at IS NULL AND attendance_periods.start_date <= ? AND attendance_periods.end_date >= ?",
+			employeeID, to.Format("2006-01-02"), from.Format("2006-01-02")).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check payslips of employee %s: %w", employeeID, err)
+	}
+	return count > 0, nil
+}
+
+func derefUUID(id *uuid.UUID) uuid.UUID {
+	if id == nil {
+		return uuid.Nil
+	}
+	return *id
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
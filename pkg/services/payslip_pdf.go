@@ -0,0 +1,297 @@
+package services
+
+import (
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/pdf"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// PayslipBranding is the company identity printed at the top of payslip PDFs
+type PayslipBranding struct {
+	CompanyName    string
+	CompanyAddress string
+	BrandColor     pdf.Color // Header band and take-home pay band
+}
+
+// PayslipStatement is one payslip with the lines printed on its PDF
+type PayslipStatement struct {
+	Payslip   models.Payslip // With its Employee and AttendancePeriod loaded
+	LineItems []models.PayslipLineItem
+}
+
+// LoadPayslipStatement loads the line items of a payslip, which must have its Employee and AttendancePeriod loaded.
+// Payslips from before line items were kept get lines rebuilt from their totals and paid reimbursements.
+func LoadPayslipStatement(db *gorm.DB, payslip models.Payslip) (PayslipStatement, error) {
+	statement := PayslipStatement{Payslip: payslip}
+	if err := db.Where("payslip_id = ?", payslip.ID).Order("position").Find(&statement.LineItems).Error; err != nil {
+		return statement, fmt.Errorf("failed to fetch payslip line items: %w", err)
+	}
+	if len(statement.LineItems) > 0 {
+		return statement, nil
+	}
+
+	var reimbursements []models.ReimbursementRequest
+	if err := db.Where("employee_id = ? AND attendance_period_id = ? AND status = ?", payslip.EmployeeID, payslip.AttendancePeriodID, models.ReimbursementPaid).
+		Order("created_at").
+		Find(&reimbursements).Error; err != nil {
+		return statement, fmt.Errorf("failed to fetch paid reimbursements: %w", err)
+	}
+	statement.LineItems = summaryPayslipLineItems(payslip, reimbursements)
+	return statement, nil
+}
+
+// summaryPayslipLineItems rebuilds lines for a payslip without stored line items. Overtime is one line, as its tiers are not known.
+func summaryPayslipLineItems(payslip models.Payslip, reimbursements []models.ReimbursementRequest) []models.PayslipLineItem {
+	salaryDays := payslip.AttendanceCount + payslip.UnpaidLeaveDays
+	dailySalary := decimal.Zero
+	if payslip.TotalWorkingDays > 0 {
+		dailySalary = payslip.BaseSalary.Div(decimal.NewFromInt(int64(payslip.TotalWorkingDays)))
+	}
+	lines := []models.PayslipLineItem{{
+		Type:        LineItemSalary,
+		Description: fmt.Sprintf("Salary for %d of %d working days", salaryDays, payslip.TotalWorkingDays),
+		Quantity:    decimal.NewFromInt(int64(salaryDays)),
+		Rate:        dailySalary.Round(4),
+		Amount:      payslip.ProratedSalary.Add(payslip.UnpaidLeaveDeduction),
+	}}
+	if payslip.UnpaidLeaveDeduction.IsPositive() {
+		lines = append(lines, models.PayslipLineItem{
+			Type:        LineItemUnpaidLeave,
+			Description: "Unpaid leave",
+			Quantity:    decimal.NewFromInt(int64(payslip.UnpaidLeaveDays)),
+			Rate:        dailySalary.Round(4),
+			Amount:      payslip.UnpaidLeaveDeduction.Neg(),
+		})
+	}
+	if payslip.OvertimePay.IsPositive() {
+		lines = append(lines, models.PayslipLineItem{
+			Type:        LineItemOvertime,
+			Description: "Overtime",
+			Quantity:    decimal.NewFromFloat(payslip.OvertimeHours),
+			Amount:      payslip.OvertimePay,
+		})
+	}
+	for _, rr := range reimbursements {
+		sourceID := rr.ID
+		lines = append(lines, models.PayslipLineItem{
+			Type:        LineItemReimbursement,
+			Description: rr.Description,
+			SourceID:    &sourceID,
+			Quantity:    decimal.NewFromInt(1),
+			Rate:        rr.Amount.Decimal,
+			Amount:      rr.Amount,
+		})
+	}
+	if !payslip.IncomeTax.IsZero() {
+		lines = append(lines, models.PayslipLineItem{
+			Type:        LineItemIncomeTax,
+			Description: "Income tax (PPh 21)",
+			Quantity:    decimal.NewFromInt(1),
+			Rate:        payslip.IncomeTax.Neg().Decimal,
+			Amount:      payslip.IncomeTax.Neg(),
+		})
+	}
+	for i := range lines {
+		lines[i].PayslipID = payslip.ID
+		lines[i].Position = i + 1
+	}
+	return lines
+}
+
+// Layout of payslip pages, in points
+const (
+	payslipMargin      = 40.0
+	payslipRight       = pdf.A4Width - payslipMargin
+	payslipBottom      = pdf.A4Height - 70 // Rows below this start a new page
+	payslipRowHeight   = 16.0
+	payslipQuantityEnd = 390.0
+	payslipRateEnd     = 470.0
+)
+
+var (
+	payslipGray      = pdf.Color{R: 110, G: 110, B: 110}
+	payslipLightGray = pdf.Color{R: 238, G: 238, B: 238}
+	payslipRed       = pdf.Color{R: 190, G: 30, B: 45}
+)
+
+// payslipSection is a titled group of lines on a payslip
+type payslipSection struct {
+	title      string
+	totalLabel string
+	lines      []models.PayslipLineItem
+	deduction  bool // Amounts are printed as positive values and subtracted from the gross pay
+}
+
+// RenderPayslipPDF renders payslips into one PDF, each payslip starting on a new page. The output depends only on
+// its arguments, so the same payslips always render to the same bytes.
+func RenderPayslipPDF(branding PayslipBranding, statements []PayslipStatement) []byte {
+	title := "Payslips"
+	if len(statements) == 1 {
+		p := statements[0].Payslip
+		title = fmt.Sprintf("Payslip %s %s to %s", p.Employee.Username, p.AttendancePeriod.StartDate.Format("2006-01-02"), p.AttendancePeriod.EndDate.Format("2006-01-02"))
+	}
+	doc := pdf.New(title)
+	for _, statement := range statements {
+		renderPayslip(doc, branding, statement)
+	}
+	return doc.Bytes()
+}
+
+func renderPayslip(doc *pdf.Document, branding PayslipBranding, statement PayslipStatement) {
+	payslip := statement.Payslip
+	period := payslip.AttendancePeriod
+	periodText := fmt.Sprintf("%s to %s", period.StartDate.Format("2 Jan 2006"), period.EndDate.Format("2 Jan 2006"))
+
+	pages := []*pdf.Page{doc.AddPage()}
+	page := pages[0]
+	page.FillRect(0, 0, pdf.A4Width, 78, branding.BrandColor)
+	page.Text(payslipMargin, 36, pdf.HelveticaBold, 18, pdf.White, pdf.Truncate(pdf.HelveticaBold, 18, branding.CompanyName, 330))
+	page.Text(payslipMargin, 54, pdf.Helvetica, 9, pdf.White, pdf.Truncate(pdf.Helvetica, 9, branding.CompanyAddress, 330))
+	page.TextRight(payslipRight, 36, pdf.HelveticaBold, 16, pdf.White, "PAYSLIP")
+	page.TextRight(payslipRight, 54, pdf.Helvetica, 9, pdf.White, periodText)
+
+	// Employee details on the left, period details on the right
+	details := [2][][2]string{
+		{{"Employee", payslip.Employee.Username}, {"Employee ID", payslip.EmployeeID.String()}, {"Payslip ID", payslip.ID.String()}},
+		{
+			{"Period", fmt.Sprintf("%s to %s", period.StartDate.Format("2006-01-02"), period.EndDate.Format("2006-01-02"))},
+			{"Issued", payslip.CreatedAt.UTC().Format("2006-01-02")},
+			{"Attendance", fmt.Sprintf("%d of %d working days", payslip.AttendanceCount, payslip.TotalWorkingDays)},
+		},
+	}
+	if payslip.PaidLeaveDays > 0 || payslip.UnpaidLeaveDays > 0 {
+		details[1] = append(details[1], [2]string{"Leave days", fmt.Sprintf("%d paid, %d unpaid", payslip.PaidLeaveDays, payslip.UnpaidLeaveDays)})
+	}
+	top, rows := 108.0, 0
+	for col, fields := range details {
+		x := payslipMargin + float64(col)*290
+		for row, field := range fields {
+			page.Text(x, top+float64(row)*15, pdf.Helvetica, 8, payslipGray, field[0])
+			page.Text(x+62, top+float64(row)*15, pdf.Helvetica, 9, pdf.Black, pdf.Truncate(pdf.Helvetica, 9, field[1], 220-float64(col)*60))
+		}
+		rows = max(rows, len(fields))
+	}
+	y := top + float64(rows)*15
+	if payslip.VoidedAt != nil {
+		voided := "VOIDED on " + payslip.VoidedAt.UTC().Format("2006-01-02")
+		if payslip.VoidReason != nil && *payslip.VoidReason != "" {
+			voided += ": " + *payslip.VoidReason
+		}
+		page.Text(payslipMargin, y+4, pdf.HelveticaBold, 10, payslipRed, pdf.Truncate(pdf.HelveticaBold, 10, voided, payslipRight-payslipMargin))
+		y += 18
+	}
+	y += 12
+
+	sections := []*payslipSection{
+		{title: "Earnings", totalLabel: "Total earnings"},
+		{title: "Overtime", totalLabel: "Total overtime"},
+		{title: "Reimbursements", totalLabel: "Total reimbursements"},
+		{title: "Deductions", totalLabel: "Total deductions", deduction: true},
+	}
+	for _, line := range statement.LineItems {
+		switch {
+		case line.Type == LineItemOvertime:
+			sections[1].lines = append(sections[1].lines, line)
+		case line.Type == LineItemReimbursement:
+			sections[2].lines = append(sections[2].lines, line)
+		case line.Type == LineItemUnpaidLeave || line.Type == LineItemSocialSecurity || line.Type == LineItemIncomeTax || line.Amount.IsNegative():
+			sections[3].lines = append(sections[3].lines, line)
+		default:
+			sections[0].lines = append(sections[0].lines, line)
+		}
+	}
+
+	// newRow moves to the next row, continuing on a new page when this one is full
+	newRow := func(height float64) {
+		if y+height > payslipBottom {
+			page = doc.AddPage()
+			pages = append(pages, page)
+			page.FillRect(0, 0, pdf.A4Width, 40, branding.BrandColor)
+			page.Text(payslipMargin, 25, pdf.HelveticaBold, 12, pdf.White, pdf.Truncate(pdf.HelveticaBold, 12, branding.CompanyName, 330))
+			page.TextRight(payslipRight, 25, pdf.Helvetica, 9, pdf.White, "Payslip (continued) "+periodText)
+			y = 70
+		}
+	}
+
+	gross, deductions := decimal.Zero, decimal.Zero
+	for _, section := range sections {
+		if len(section.lines) == 0 && section.title != "Earnings" {
+			continue
+		}
+		newRow(payslipRowHeight * 3)
+		page.FillRect(payslipMargin, y, payslipRight-payslipMargin, payslipRowHeight+2, payslipLightGray)
+		page.Text(payslipMargin+6, y+12, pdf.HelveticaBold, 10, pdf.Black, section.title)
+		page.TextRight(payslipQuantityEnd, y+12, pdf.Helvetica, 8, payslipGray, "Quantity")
+		page.TextRight(payslipRateEnd, y+12, pdf.Helvetica, 8, payslipGray, "Rate")
+		page.TextRight(payslipRight-6, y+12, pdf.Helvetica, 8, payslipGray, "Amount")
+		y += payslipRowHeight + 2
+
+		total := decimal.Zero
+		for _, line := range section.lines {
+			newRow(payslipRowHeight)
+			amount := line.Amount.Decimal
+			if section.deduction {
+				amount = amount.Abs()
+			}
+			total = total.Add(amount)
+			page.Text(payslipMargin+6, y+12, pdf.Helvetica, 9, pdf.Black, pdf.Truncate(pdf.Helvetica, 9, line.Description, payslipQuantityEnd-payslipMargin-50))
+			if !line.Quantity.IsZero() {
+				page.TextRight(payslipQuantityEnd, y+12, pdf.Helvetica, 9, pdf.Black, line.Quantity.Round(2).String())
+			}
+			if !line.Rate.IsZero() {
+				page.TextRight(payslipRateEnd, y+12, pdf.Helvetica, 9, pdf.Black, formatMoney(line.Rate))
+			}
+			page.TextRight(payslipRight-6, y+12, pdf.Helvetica, 9, pdf.Black, formatMoney(amount))
+			y += payslipRowHeight
+		}
+		if section.deduction {
+			deductions = deductions.Add(total)
+		} else {
+			gross = gross.Add(total)
+		}
+
+		newRow(payslipRowHeight + 10)
+		page.Line(payslipRateEnd-60, y+2, payslipRight, y+2, 0.5, payslipGray)
+		page.TextRight(payslipRateEnd, y+14, pdf.HelveticaBold, 9, pdf.Black, section.totalLabel)
+		page.TextRight(payslipRight-6, y+14, pdf.HelveticaBold, 9, pdf.Black, formatMoney(total))
+		y += payslipRowHeight + 14
+	}
+
+	newRow(payslipRowHeight*2 + 30)
+	page.Text(payslipMargin+6, y+12, pdf.Helvetica, 9, pdf.Black, "Gross pay")
+	page.TextRight(payslipRight-6, y+12, pdf.Helvetica, 9, pdf.Black, formatMoney(gross))
+	y += payslipRowHeight
+	page.Text(payslipMargin+6, y+12, pdf.Helvetica, 9, pdf.Black, "Deductions")
+	page.TextRight(payslipRight-6, y+12, pdf.Helvetica, 9, pdf.Black, formatMoney(deductions.Neg()))
+	y += payslipRowHeight + 6
+	page.FillRect(payslipMargin, y, payslipRight-payslipMargin, 24, branding.BrandColor)
+	page.Text(payslipMargin+6, y+16, pdf.HelveticaBold, 11, pdf.White, "Take-home pay")
+	page.TextRight(payslipRight-6, y+16, pdf.HelveticaBold, 11, pdf.White, formatMoney(payslip.TakeHomePay.Decimal))
+
+	for i, p := range pages {
+		p.Line(payslipMargin, pdf.A4Height-42, payslipRight, pdf.A4Height-42, 0.5, payslipLightGray)
+		p.Text(payslipMargin, pdf.A4Height-30, pdf.Helvetica, 7, payslipGray, "Payslip "+payslip.ID.String()+". Issued electronically; valid without signature.")
+		p.TextRight(payslipRight, pdf.A4Height-30, pdf.Helvetica, 7, payslipGray, fmt.Sprintf("Page %d of %d", i+1, len(pages)))
+	}
+}
+
+// formatMoney formats an amount with two decimals and thousands separators, e.g. -1,234.50
+func formatMoney(amount decimal.Decimal) string {
+	s := amount.Abs().StringFixed(2)
+	whole, fraction := s[:len(s)-3], s[len(s)-3:]
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	if amount.Round(2).IsNegative() {
+		return "-" + grouped.String() + fraction
+	}
+	return grouped.String() + fraction
+}
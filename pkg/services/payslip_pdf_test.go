@@ -0,0 +1,154 @@
+package services
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/pdf"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+var testBranding = PayslipBranding{
+	CompanyName:    "Acme Logistics",
+	CompanyAddress: "Jl. Sudirman 1, Jakarta",
+	BrandColor:     pdf.Color{R: 31, G: 78, B: 121},
+}
+
+func testStatement() PayslipStatement {
+	employee := models.Employee{Username: "budi"}
+	employee.ID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	period := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	period.ID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	payslip := models.Payslip{
+		EmployeeID:           employee.ID,
+		AttendancePeriodID:   period.ID,
+		BaseSalary:           money(4000),
+		ProratedSalary:       money(3200),
+		AttendanceCount:      4,
+		TotalWorkingDays:     5,
+		UnpaidLeaveDays:      1,
+		UnpaidLeaveDeduction: money(800),
+		OvertimeHours:        2.5,
+		OvertimePay:          money(550),
+		ReimbursementsTotal:  money(175.5),
+		TakeHomePay:          money(3925.5),
+		Employee:             employee,
+		AttendancePeriod:     period,
+	}
+	payslip.ID = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	payslip.CreatedAt = time.Date(2024, time.March, 9, 10, 0, 0, 0, time.UTC)
+
+	lines := []models.PayslipLineItem{
+		{Type: LineItemSalary, Description: "Salary for 5 of 5 working days (4 attended, 0 paid leave, 1 unpaid leave)", Quantity: decimal.NewFromFloat(5), Rate: decimal.NewFromFloat(800), Amount: money(4000)},
+		{Type: LineItemUnpaidLeave, Description: "Unpaid leave: Personal", Quantity: decimal.NewFromFloat(1), Rate: decimal.NewFromFloat(800), Amount: money(-800)},
+		{Type: LineItemOvertime, Description: "Overtime on 2024-03-04, workday (x1.5)", Quantity: decimal.NewFromFloat(1), Rate: decimal.NewFromFloat(150), Amount: money(150)},
+		{Type: LineItemOvertime, Description: "Overtime on 2024-03-09, rest day (x2)", Quantity: decimal.NewFromFloat(1.5), Rate: decimal.NewFromFloat(200), Amount: money(300)},
+		{Type: LineItemOvertime, Description: "Overtime on 2024-03-09, rest day (x4)", Quantity: decimal.NewFromFloat(0.25), Rate: decimal.NewFromFloat(400), Amount: money(100)},
+		{Type: LineItemReimbursement, Description: "Taxi to client (café)", Quantity: decimal.NewFromFloat(1), Rate: decimal.NewFromFloat(25.5), Amount: money(25.5)},
+		{Type: LineItemReimbursement, Description: "Hotel", Quantity: decimal.NewFromFloat(1), Rate: decimal.NewFromFloat(150), Amount: money(150)},
+	}
+	for i := range lines {
+		lines[i].Position = i + 1
+	}
+	return PayslipStatement{Payslip: payslip, LineItems: lines}
+}
+
+func TestRenderPayslipPDF_Golden(t *testing.T) {
+	out := RenderPayslipPDF(testBranding, []PayslipStatement{testStatement()})
+	golden := filepath.Join("testdata", "payslip.golden.pdf")
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(golden, out, 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "run go test ./pkg/services -run Golden -update to create the golden file")
+	assert.True(t, bytes.Equal(want, out), "rendered payslip differs from %s; rerun with -update if the change is intended", golden)
+}
+
+func TestRenderPayslipPDF_Content(t *testing.T) {
+	out := string(RenderPayslipPDF(testBranding, []PayslipStatement{testStatement()}))
+
+	for _, text := range []string{"Acme Logistics", "PAYSLIP", "4 Mar 2024 to 8 Mar 2024", "budi", "2024-03-09", "4 of 5 working days", "0 paid, 1 unpaid",
+		"Earnings", "Overtime", "Reimbursements", "Deductions", "Unpaid leave: Personal", "Taxi to client \\(caf\xe9\\)",
+		"4,000.00", "550.00", "175.50", "Total deductions", "-800.00", "Take-home pay", "3,925.50", "Page 1 of 1"} {
+		assert.Contains(t, out, "("+text, "missing %q", text)
+	}
+	assert.NotContains(t, out, "VOIDED")
+	assert.Contains(t, out, "/Title (Payslip budi 2024-03-04 to 2024-03-08)")
+}
+
+func TestRenderPayslipPDF_VoidedAndBulk(t *testing.T) {
+	voided := testStatement()
+	voidedAt := time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)
+	reason := "Wrong overtime"
+	voided.Payslip.VoidedAt = &voidedAt
+	voided.Payslip.VoidReason = &reason
+
+	out := string(RenderPayslipPDF(testBranding, []PayslipStatement{testStatement(), voided}))
+	assert.Contains(t, out, "/Count 2", "Each payslip starts on a new page")
+	assert.Contains(t, out, "(VOIDED on 2024-03-12: Wrong overtime)")
+	assert.Contains(t, out, "/Title (Payslips)")
+}
+
+func TestRenderPayslipPDF_ContinuesOnNewPages(t *testing.T) {
+	statement := testStatement()
+	for i := 0; i < 40; i++ {
+		statement.LineItems = append(statement.LineItems, models.PayslipLineItem{Type: LineItemReimbursement, Description: fmt.Sprintf("Receipt %d", i+1), Quantity: decimal.NewFromFloat(1), Rate: decimal.NewFromFloat(1), Amount: money(1)})
+	}
+	out := string(RenderPayslipPDF(testBranding, []PayslipStatement{statement}))
+	assert.Contains(t, out, "/Count 2")
+	assert.Contains(t, out, "(Page 2 of 2)")
+	assert.Contains(t, out, "(Payslip \\(continued\\) 4 Mar 2024 to 8 Mar 2024)")
+	assert.Len(t, regexp.MustCompile(`\(Receipt \d+\)`).FindAllString(out, -1), 40)
+}
+
+func TestSummaryPayslipLineItems(t *testing.T) {
+	payslip := testStatement().Payslip
+	taxi := models.ReimbursementRequest{Description: "Taxi", Amount: money(25.5)}
+	taxi.ID = uuid.New()
+
+	lines := summaryPayslipLineItems(payslip, []models.ReimbursementRequest{taxi})
+	require.Len(t, lines, 4)
+	assert.Equal(t, LineItemSalary, lines[0].Type)
+	assert.Equal(t, "5", lines[0].Quantity.String(), "Attended and unpaid leave days")
+	assert.Equal(t, "4000.00", lines[0].Amount.String(), "Before the unpaid leave deduction")
+	assert.Equal(t, LineItemUnpaidLeave, lines[1].Type)
+	assert.Equal(t, "-800.00", lines[1].Amount.String())
+	assert.Equal(t, LineItemOvertime, lines[2].Type)
+	assert.Equal(t, "550.00", lines[2].Amount.String())
+	assert.Equal(t, taxi.ID, *lines[3].SourceID)
+	for i, line := range lines {
+		assert.Equal(t, i+1, line.Position)
+		assert.Equal(t, payslip.ID, line.PayslipID)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[string]string{
+		"0":          "0.00",
+		"5.5":        "5.50",
+		"999.999":    "1,000.00",
+		"1234567.89": "1,234,567.89",
+		"-800":       "-800.00",
+		"-0.001":     "0.00",
+		"100000":     "100,000.00",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, formatMoney(decimal.RequireFromString(in)), in)
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/storage"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxReceiptFileSize caps the size of one receipt file
+	MaxReceiptFileSize = 5 * 1024 * 1024
+	// MaxReceiptsPerRequest caps the number of receipts attached to one reimbursement request
+	MaxReceiptsPerRequest = 5
+	// MaxReceiptUploadSize is the largest request body needed to upload receipts, with room for the form fields
+	MaxReceiptUploadSize = MaxReceiptsPerRequest*MaxReceiptFileSize + 1024*1024
+)
+
+var (
+	// ErrInvalidReceipt is returned when a receipt file is empty, too large, of an unsupported type or one too many
+	ErrInvalidReceipt = errors.New("invalid receipt")
+	// ErrReceiptChecksumMismatch is returned when a stored receipt no longer matches the checksum taken at upload
+	ErrReceiptChecksumMismatch = errors.New("receipt checksum mismatch")
+)
+
+// receiptExtensions maps the accepted receipt content types to the extension of their storage key
+var receiptExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// ReceiptFile is an uploaded receipt before it is stored
+type ReceiptFile struct {
+	FileName string
+	Data     []byte
+}
+
+// InspectReceipt checks a receipt's size and type and returns its content type and hex SHA-256 checksum.
+// The type is detected from the content; only JPEG, PNG, WebP images and PDFs are accepted.
+func InspectReceipt(file ReceiptFile) (contentType string, checksum string, err error) {
+	if len(file.Data) == 0 {
+		return "", "", fmt.Errorf("%w: %s is empty", ErrInvalidReceipt, file.FileName)
+	}
+	if len(file.Data) > MaxReceiptFileSize {
+		return "", "", fmt.Errorf("%w: %s is larger than %d MB", ErrInvalidReceipt, file.FileName, MaxReceiptFileSize/(1024*1024))
+	}
+	contentType = http.DetectContentType(file.Data)
+	if _, ok := receiptExtensions[contentType]; !ok {
+		return "", "", fmt.Errorf("%w: %s is %s; receipts must be JPEG, PNG or WebP images or PDFs", ErrInvalidReceipt, file.FileName, contentType)
+	}
+	sum := sha256.Sum256(file.Data)
+	return contentType, hex.EncodeToString(sum[:]), nil
+}
+
+// ReceiptService stores reimbursement receipts and reads them back
+type ReceiptService struct {
+	DB    *gorm.DB
+	Store storage.Store
+}
+
+// NewReceiptService creates a new ReceiptService
+func NewReceiptService(db *gorm.DB, store storage.Store) *ReceiptService {
+	return &ReceiptService{DB: db, Store: store}
+}
+
+// Attach checks and stores receipt files and records them against a reimbursement request. Either all
+// files are attached or none are: files already stored are deleted again if a later one fails.
+func (s *ReceiptService) Attach(ctx context.Context, request models.ReimbursementRequest, files []ReceiptFile, actorID uuid.UUID, ipAddress string) ([]models.ReimbursementReceipt, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: no receipt files", ErrInvalidReceipt)
+	}
+	var existing int64
+	if err := s.DB.Model(&models.ReimbursementReceipt{}).Where("reimbursement_request_id = ?", request.ID).Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to count receipts: %w", err)
+	}
+	if int(existing)+len(files) > MaxReceiptsPerRequest {
+		return nil, fmt.Errorf("%w: a reimbursement request can have at most %d receipts, it has %d", ErrInvalidReceipt, MaxReceiptsPerRequest, existing)
+	}
+
+	receipts := make([]models.ReimbursementReceipt, 0, len(files))
+	for _, file := range files {
+		contentType, checksum, err := InspectReceipt(file)
+		if err != nil {
+			return nil, err
+		}
+		receipt := models.ReimbursementReceipt{
+			ReimbursementRequestID: request.ID,
+			FileName:               receiptFileName(file.FileName),
+			ContentType:            contentType,
+			Size:                   int64(len(file.Data)),
+			Checksum:               checksum,
+			Backend:                s.Store.Backend(),
+		}
+		receipt.ID = uuid.New()
+		receipt.StorageKey = fmt.Sprintf("reimbursements/%s/%s%s", request.ID, receipt.ID, receiptExtensions[contentType])
+		receipt.CreatedBy = &actorID
+		receipt.UpdatedBy = &actorID
+		receipt.IPAddress = &ipAddress
+		receipts = append(receipts, receipt)
+	}
+
+	for i, receipt := range receipts {
+		if err := s.Store.Put(ctx, receipt.StorageKey, files[i].Data, receipt.ContentType); err != nil {
+			s.deleteStored(receipts[:i])
+			return nil, fmt.Errorf("failed to store receipt %s: %w", receipt.FileName, err)
+		}
+	}
+	if err := s.DB.Create(&receipts).Error; err != nil {
+		s.deleteStored(receipts)
+		return nil, fmt.Errorf("failed to record receipts: %w", err)
+	}
+	return receipts, nil
+}
+
+// Read returns a receipt's file after checking it against the checksum taken at upload
+func (s *ReceiptService) Read(ctx context.Context, receipt models.ReimbursementReceipt) ([]byte, error) {
+	if receipt.Backend != s.Store.Backend() {
+		return nil, fmt.Errorf("receipt is kept in the %s store but %s storage is configured", receipt.Backend, s.Store.Backend())
+	}
+	body, err := s.Store.Get(ctx, receipt.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, MaxReceiptFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read receipt: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	if int64(len(data)) != receipt.Size || hex.EncodeToString(sum[:]) != receipt.Checksum {
+		return nil, ErrReceiptChecksumMismatch
+	}
+	return data, nil
+}
+
+// deleteStored removes stored receipt files on a best-effort basis, after a failed upload
+func (s *ReceiptService) deleteStored(receipts []models.ReimbursementReceipt) {
+	for _, receipt := range receipts {
+		s.Store.Delete(context.Background(), receipt.StorageKey)
+	}
+}
+
+// receiptFileName keeps the base name of an uploaded file, without any client path, in at most 255 bytes
+func receiptFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "." || name == "/" || name == "" {
+		name = "receipt"
+	}
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		name = strings.ToValidUTF8(name[:255-len(ext)], "") + ext
+	}
+	return name
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/storage"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPNG = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+	testPDF = []byte("%PDF-1.4\n1 0 obj << >> endobj\n%%EOF\n")
+)
+
+func TestInspectReceipt(t *testing.T) {
+	pdfSum := sha256.Sum256(testPDF)
+	tests := []struct {
+		name        string
+		file        ReceiptFile
+		contentType string
+		checksum    string
+		wantErr     string
+	}{
+		{name: "PDF", file: ReceiptFile{FileName: "taxi.pdf", Data: testPDF}, contentType: "application/pdf", checksum: hex.EncodeToString(pdfSum[:])},
+		{name: "PNG", file: ReceiptFile{FileName: "taxi.png", Data: testPNG}, contentType: "image/png"},
+		{name: "JPEG", file: ReceiptFile{FileName: "taxi.jpg", Data: append([]byte("\xff\xd8\xff\xe0"), make([]byte, 16)...)}, contentType: "image/jpeg"},
+		{name: "Type comes from the content, not the name", file: ReceiptFile{FileName: "taxi.pdf", Data: []byte("just some text")}, wantErr: "text/plain"},
+		{name: "Empty", file: ReceiptFile{FileName: "empty.pdf"}, wantErr: "empty"},
+		{name: "Too large", file: ReceiptFile{FileName: "big.pdf", Data: append(testPDF, make([]byte, MaxReceiptFileSize)...)}, wantErr: "larger than 5 MB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, checksum, err := InspectReceipt(tt.file)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrInvalidReceipt))
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, contentType)
+			assert.Len(t, checksum, 64)
+			if tt.checksum != "" {
+				assert.Equal(t, tt.checksum, checksum)
+			}
+		})
+	}
+}
+
+func TestReceiptFileName(t *testing.T) {
+	assert.Equal(t, "taxi.pdf", receiptFileName(`C:\Users\me\taxi.pdf`))
+	assert.Equal(t, "taxi.pdf", receiptFileName("../../taxi.pdf"))
+	assert.Equal(t, "receipt", receiptFileName(""))
+	long := receiptFileName(strings.Repeat("a", 300) + ".pdf")
+	assert.Len(t, long, 255)
+	assert.True(t, strings.HasSuffix(long, ".pdf"))
+}
+
+func TestReceiptServiceRead(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, checksum, err := InspectReceipt(ReceiptFile{FileName: "taxi.pdf", Data: testPDF})
+	require.NoError(t, err)
+	receipt := models.ReimbursementReceipt{StorageKey: "reimbursements/r/1.pdf", Size: int64(len(testPDF)), Checksum: checksum, Backend: storage.BackendLocal}
+	service := NewReceiptService(nil, store)
+
+	require.NoError(t, store.Put(ctx, receipt.StorageKey, testPDF, "application/pdf"))
+	data, err := service.Read(ctx, receipt)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(testPDF, data))
+
+	// A file changed in the store no longer matches its checksum
+	require.NoError(t, store.Put(ctx, receipt.StorageKey, bytes.ToUpper(testPDF), "application/pdf"))
+	_, err = service.Read(ctx, receipt)
+	assert.True(t, errors.Is(err, ErrReceiptChecksumMismatch))
+
+	require.NoError(t, store.Delete(ctx, receipt.StorageKey))
+	_, err = service.Read(ctx, receipt)
+	assert.True(t, errors.Is(err, storage.ErrObjectNotFound))
+
+	receipt.Backend = storage.BackendS3
+	_, err = service.Read(ctx, receipt)
+	assert.Error(t, err, "Receipts in another backend cannot be read")
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"fmt"
+	"payslip-generator/pkg/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxReimbursementAmount is the largest amount a reimbursement request or per-claim maximum may have; it fits decimal(10,2)
+var MaxReimbursementAmount = models.MustParseMoney("99999999.99")
+
+// Codes of reimbursement policy violations
+const (
+	ViolationExceedsMaxPerClaim = "exceeds_max_per_claim"
+	ViolationExceedsPeriodCap   = "exceeds_period_cap"
+	ViolationExceedsYearlyCap   = "exceeds_yearly_cap"
+	ViolationReceiptRequired    = "receipt_required"
+)
+
+// ReimbursementViolation explains why a claim breaks its category's limits
+type ReimbursementViolation struct {
+	Code      string        `json:"code"`
+	Message   string        `json:"message"`
+	Limit     *models.Money `json:"limit,omitempty"`     // The maximum or cap that was exceeded
+	Claimed   *models.Money `json:"claimed,omitempty"`   // Already claimed in the cap window, excluding this claim
+	Available *models.Money `json:"available,omitempty"` // What is left to claim in the cap window
+}
+
+// ReimbursementClaim is a claim being checked against its category
+type ReimbursementClaim struct {
+	EmployeeID         uuid.UUID
+	AttendancePeriodID uuid.UUID // uuid.Nil when the claim is not in an attendance period
+	Amount             models.Money
+	Receipts           int
+	SubmittedAt        time.Time
+}
+
+// ValidateReimbursementCategory checks a category's name, limits and cap window
+func ValidateReimbursementCategory(category models.ReimbursementCategory) error {
+	if strings.TrimSpace(category.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(category.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if err := validateAmount("max per claim", category.MaxPerClaim); err != nil {
+		return err
+	}
+	if err := validateAmount("cap amount", category.CapAmount); err != nil {
+		return err
+	}
+	switch category.CapPeriod {
+	case models.ReimbursementCapNone:
+		if !category.CapAmount.IsZero() {
+			return fmt.Errorf("cap period must be %q or %q when a cap amount is set", models.ReimbursementCapPerPeriod, models.ReimbursementCapPerYear)
+		}
+	case models.ReimbursementCapPerPeriod, models.ReimbursementCapPerYear:
+		if category.CapAmount.IsZero() {
+			return fmt.Errorf("cap amount is required when the cap period is %q", category.CapPeriod)
+		}
+	default:
+		return fmt.Errorf("cap period must be %q, %q or %q", models.ReimbursementCapNone, models.ReimbursementCapPerPeriod, models.ReimbursementCapPerYear)
+	}
+	if category.MaxPerClaim.IsPositive() && category.CapAmount.IsPositive() && category.MaxPerClaim.GreaterThan(category.CapAmount.Decimal) {
+		return fmt.Errorf("max per claim must not be more than the cap amount")
+	}
+	return nil
+}
+
+func validateAmount(field string, amount models.Money) error {
+	if amount.IsNegative() || amount.GreaterThan(MaxReimbursementAmount.Decimal) {
+		return fmt.Errorf("%s must be between 0 and %s", field, MaxReimbursementAmount)
+	}
+	if !amount.HasWholeCents() {
+		return fmt.Errorf("%s must have at most 2 decimal places", field)
+	}
+	return nil
+}
+
+// EvaluateReimbursementClaim returns every limit of the category the claim breaks. claimed is what the
+// employee has already claimed in the category's cap window; it is ignored for categories without a cap.
+func EvaluateReimbursementClaim(category models.ReimbursementCategory, amount models.Money, receipts int, claimed models.Money) []ReimbursementViolation {
+	var violations []ReimbursementViolation
+	if category.MaxPerClaim.IsPositive() && amount.GreaterThan(category.MaxPerClaim.Decimal) {
+		violations = append(violations, ReimbursementViolation{
+			Code:    ViolationExceedsMaxPerClaim,
+			Message: fmt.Sprintf("%s claims are limited to %s each.", category.Name, category.MaxPerClaim),
+			Limit:   moneyPtr(category.MaxPerClaim),
+		})
+	}
+	if category.CapAmount.IsPositive() && category.CapPeriod != models.ReimbursementCapNone {
+		total := claimed.Add(amount)
+		if total.GreaterThan(category.CapAmount.Decimal) {
+			available := category.CapAmount.Sub(claimed)
+			if available.IsNegative() {
+				available = models.Money{}
+			}
+			code, window := ViolationExceedsPeriodCap, "this period"
+			if category.CapPeriod == models.ReimbursementCapPerYear {
+				code, window = ViolationExceedsYearlyCap, "this year"
+			}
+			violations = append(violations, ReimbursementViolation{
+				Code:      code,
+				Message:   fmt.Sprintf("%s claims are capped at %s %s; %s is already claimed and %s is left.", category.Name, category.CapAmount, window, claimed, available),
+				Limit:     moneyPtr(category.CapAmount),
+				Claimed:   moneyPtr(claimed),
+				Available: moneyPtr(available),
+			})
+		}
+	}
+	if category.ReceiptRequired && receipts == 0 {
+		violations = append(violations, ReimbursementViolation{
+			Code:    ViolationReceiptRequired,
+			Message: fmt.Sprintf("%s claims must be submitted with a receipt.", category.Name),
+		})
+	}
+	return violations
+}
+
+func moneyPtr(m models.Money) *models.Money {
+	return &m
+}
+
+// ReimbursementPolicyService checks reimbursement claims against their category's limits
+type ReimbursementPolicyService struct {
+	DB *gorm.DB
+}
+
+// NewReimbursementPolicyService creates a new ReimbursementPolicyService
+func NewReimbursementPolicyService(db *gorm.DB) *ReimbursementPolicyService {
+	return &ReimbursementPolicyService{DB: db}
+}
+
+// Check returns the violations of a claim against the category, counting the employee's pending,
+// approved and paid claims in the category's cap window. Callers that go on to create the claim
+// should hold a lock on the employee so concurrent claims cannot both fit under a cap.
+func (s *ReimbursementPolicyService) Check(category models.ReimbursementCategory, claim ReimbursementClaim) ([]ReimbursementViolation, error) {
+	var claimed models.Money
+	if category.CapAmount.IsPositive() && category.CapPeriod != models.ReimbursementCapNone {
+		query := s.DB.Model(&models.ReimbursementRequest{}).
+			Where("employee_id = ? AND category_id = ? AND status IN ?", claim.EmployeeID, category.ID,
+				[]string{models.ReimbursementPending, models.ReimbursementApproved, models.ReimbursementPaid})
+		switch {
+		case category.CapPeriod == models.ReimbursementCapPerYear:
+			start := time.Date(claim.SubmittedAt.Year(), time.January, 1, 0, 0, 0, 0, claim.SubmittedAt.Location())
+			query = query.Where("created_at >= ? AND created_at < ?", start, start.AddDate(1, 0, 0))
+		case claim.AttendancePeriodID != uuid.Nil:
+			query = query.Where("attendance_period_id = ?", claim.AttendancePeriodID)
+		default:
+			// Outside any attendance period the calendar month stands in for the period
+			start := time.Date(claim.SubmittedAt.Year(), claim.SubmittedAt.Month(), 1, 0, 0, 0, 0, claim.SubmittedAt.Location())
+			query = query.Where("(attendance_period_id IS NULL OR attendance_period_id = ?) AND created_at >= ? AND created_at < ?", uuid.Nil, start, start.AddDate(0, 1, 0))
+		}
+		if err := query.Select("COALESCE(SUM(amount), 0)").Scan(&claimed).Error; err != nil {
+			return nil, fmt.Errorf("failed to sum reimbursement claims: %w", err)
+		}
+	}
+	return EvaluateReimbursementClaim(category, claim.Amount, claim.Receipts, claimed), nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReimbursementCategory(t *testing.T) {
+	valid := models.ReimbursementCategory{Name: "Medical", MaxPerClaim: models.MustParseMoney("500"), CapAmount: models.MustParseMoney("2000"), CapPeriod: models.ReimbursementCapPerYear, ReceiptRequired: true}
+	tests := []struct {
+		name    string
+		modify  func(*models.ReimbursementCategory)
+		wantErr string
+	}{
+		{name: "Valid", modify: func(*models.ReimbursementCategory) {}},
+		{name: "No limits", modify: func(c *models.ReimbursementCategory) {
+			c.MaxPerClaim, c.CapAmount, c.CapPeriod = models.Money{}, models.Money{}, models.ReimbursementCapNone
+		}},
+		{name: "Missing name", modify: func(c *models.ReimbursementCategory) { c.Name = " " }, wantErr: "name is required"},
+		{name: "Negative maximum", modify: func(c *models.ReimbursementCategory) { c.MaxPerClaim = models.MustParseMoney("-1") }, wantErr: "max per claim must be between"},
+		{name: "Fractional cents", modify: func(c *models.ReimbursementCategory) { c.CapAmount = models.MustParseMoney("100.005") }, wantErr: "at most 2 decimal places"},
+		{name: "Cap without window", modify: func(c *models.ReimbursementCategory) { c.CapPeriod = models.ReimbursementCapNone }, wantErr: "cap period must be"},
+		{name: "Window without cap", modify: func(c *models.ReimbursementCategory) { c.MaxPerClaim, c.CapAmount = models.Money{}, models.Money{} }, wantErr: "cap amount is required"},
+		{name: "Unknown window", modify: func(c *models.ReimbursementCategory) { c.CapPeriod = "month" }, wantErr: "cap period must be"},
+		{name: "Maximum above cap", modify: func(c *models.ReimbursementCategory) { c.MaxPerClaim = models.MustParseMoney("3000") }, wantErr: "must not be more than the cap"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category := valid
+			tt.modify(&category)
+			err := ValidateReimbursementCategory(category)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestEvaluateReimbursementClaim(t *testing.T) {
+	medical := models.ReimbursementCategory{Name: "Medical", MaxPerClaim: models.MustParseMoney("500"), CapAmount: models.MustParseMoney("2000"), CapPeriod: models.ReimbursementCapPerYear, ReceiptRequired: true}
+	internet := models.ReimbursementCategory{Name: "Internet", CapAmount: models.MustParseMoney("300"), CapPeriod: models.ReimbursementCapPerPeriod}
+
+	tests := []struct {
+		name      string
+		category  models.ReimbursementCategory
+		amount    float64
+		receipts  int
+		claimed   float64
+		wantCodes []string
+	}{
+		{name: "Within every limit", category: medical, amount: 500, receipts: 1, claimed: 1500},
+		{name: "Over the per-claim maximum", category: medical, amount: 500.01, receipts: 1, wantCodes: []string{ViolationExceedsMaxPerClaim}},
+		{name: "Over the yearly cap", category: medical, amount: 400, receipts: 1, claimed: 1700, wantCodes: []string{ViolationExceedsYearlyCap}},
+		{name: "Missing receipt", category: medical, amount: 100, wantCodes: []string{ViolationReceiptRequired}},
+		{name: "Every violation at once", category: medical, amount: 600, claimed: 1900, wantCodes: []string{ViolationExceedsMaxPerClaim, ViolationExceedsYearlyCap, ViolationReceiptRequired}},
+		{name: "Over the period cap", category: internet, amount: 100.1, claimed: 200, wantCodes: []string{ViolationExceedsPeriodCap}},
+		{name: "Exactly at the period cap", category: internet, amount: 100.1, claimed: 199.9},
+		{name: "No limits", category: models.ReimbursementCategory{Name: "Meals", CapPeriod: models.ReimbursementCapNone}, amount: 10000, claimed: 50000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := EvaluateReimbursementClaim(tt.category, money(tt.amount), tt.receipts, money(tt.claimed))
+			var codes []string
+			for _, v := range violations {
+				codes = append(codes, v.Code)
+				assert.NotEmpty(t, v.Message)
+			}
+			assert.Equal(t, tt.wantCodes, codes)
+		})
+	}
+
+	violations := EvaluateReimbursementClaim(medical, money(400), 1, money(1700))
+	require.Len(t, violations, 1)
+	require.NotNil(t, violations[0].Available)
+	assert.Equal(t, "300.00", violations[0].Available.String())
+	assert.Equal(t, "1700.00", violations[0].Claimed.String())
+	assert.Equal(t, "2000.00", violations[0].Limit.String())
+	assert.Equal(t, "Medical claims are capped at 2000.00 this year; 1700.00 is already claimed and 300.00 is left.", violations[0].Message)
+}
+
+func money(amount float64) models.Money {
+	return models.NewMoney(decimal.NewFromFloat(amount))
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSalaryPeriodPaid is returned when a salary change would take effect in a period whose payroll has already been run
+var ErrSalaryPeriodPaid = errors.New("payroll has already been run for a period the salary change applies to")
+
+// SalarySegments splits a period where salary changes take effect within it. The first segment is paid at the latest
+// salary in force on the first day, or at the earliest salary in the history if none is in force yet, so employees who
+// joined part-way through a period are paid as before. Without any history, the whole period is paid at fallback.
+// Working days, paid days and amounts are left for the payroll engine to fill in.
+func SalarySegments(history []models.SalaryHistory, fallback models.Money, start, end time.Time) []models.SalarySegment {
+	sorted := append([]models.SalaryHistory(nil), history...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].EffectiveFrom.Before(sorted[j].EffectiveFrom) })
+
+	current := fallback
+	if len(sorted) > 0 {
+		current = sorted[0].Salary
+	}
+	startKey, endKey := start.Format("2006-01-02"), end.Format("2006-01-02")
+	from := start
+	var segments []models.SalarySegment
+	for _, entry := range sorted {
+		key := entry.EffectiveFrom.Format("2006-01-02")
+		if key <= startKey {
+			current = entry.Salary
+			continue
+		}
+		if key > endKey {
+			break
+		}
+		if entry.Salary.Equal(current.Decimal) {
+			continue
+		}
+		segments = append(segments, models.SalarySegment{From: from, To: entry.EffectiveFrom.AddDate(0, 0, -1), Salary: current})
+		from = entry.EffectiveFrom
+		current = entry.Salary
+	}
+	return append(segments, models.SalarySegment{From: from, To: end, Salary: current})
+}
+
+// SalaryChange is a new salary for an employee from a date
+type SalaryChange struct {
+	Salary        models.Money
+	EffectiveFrom time.Time
+	Reason        string
+	PerformedBy   *uuid.UUID
+	IPAddress     string
+}
+
+// SalaryHistoryService records salary changes
+type SalaryHistoryService struct {
+	DB *gorm.DB
+}
+
+// NewSalaryHistoryService creates a new SalaryHistoryService
+func NewSalaryHistoryService(db *gorm.DB) *SalaryHistoryService {
+	return &SalaryHistoryService{DB: db}
+}
+
+// RecordSalaryChange adds a salary history entry, or replaces the entry already effective on the same date, and sets the
+// employee's Salary to the entry in force today. A change from a later date leaves Salary alone until ApplyDueSalaryChanges
+// runs on or after that date. Changes may be backdated, but not into a period the employee already has a payslip for,
+// unless that payroll run is voided.
+// Call it inside a transaction.
+func (s *SalaryHistoryService) RecordSalaryChange(employee *models.Employee, change SalaryChange) (*models.SalaryHistory, error) {
+	if err := ValidateSalary(change.Salary); err != nil {
+		return nil, err
+	}
+	effectiveFrom := time.Date(change.EffectiveFrom.Year(), change.EffectiveFrom.Month(), change.EffectiveFrom.Day(), 0, 0, 0, 0, time.UTC)
+
+	var paidPayslips int64
+	if err := s.DB.Model(&models.Payslip{}).
+		Joins("JOIN attendance_periods ON attendance_periods.id = payslips.attendance_period_id").
+		Where("payslips.employee_id = ? AND payslips.voided_at IS NULL AND attendance_periods.end_date >= ?", employee.ID, effectiveFrom.Format("2006-01-02")).
+		Count(&paidPayslips).Error; err != nil {
+		return nil, fmt.Errorf("failed to check payslips: %w", err)
+	}
+	if paidPayslips > 0 {
+		return nil, fmt.Errorf("%w: void it first or choose a later date", ErrSalaryPeriodPaid)
+	}
+
+	var entry models.SalaryHistory
+	err := s.DB.Where("employee_id = ? AND effective_from = ?", employee.ID, effectiveFrom.Format("2006-01-02")).First(&entry).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch salary history: %w", err)
+	}
+	entry.EmployeeID = employee.ID
+	entry.Salary = change.Salary
+	entry.EffectiveFrom = effectiveFrom
+	entry.Reason = nil
+	if reason := strings.TrimSpace(change.Reason); reason != "" {
+		entry.Reason = &reason
+	}
+	if entry.ID == uuid.Nil {
+		entry.CreatedBy = change.PerformedBy
+	}
+	entry.UpdatedBy = change.PerformedBy
+	if change.IPAddress != "" {
+		entry.IPAddress = &change.IPAddress
+	}
+	if err := s.DB.Omit(clause.Associations).Save(&entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to save salary history: %w", err)
+	}
+
+	inForce, err := s.salaryInForce(employee.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !inForce.Salary.Equal(employee.Salary.Decimal) {
+		updates := map[string]interface{}{"salary": inForce.Salary}
+		if change.PerformedBy != nil {
+			updates["updated_by"] = *change.PerformedBy
+		}
+		if err := s.DB.Model(employee).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("failed to update employee salary: %w", err)
+		}
+		employee.Salary = inForce.Salary
+	}
+	return &entry, nil
+}
+
+// salaryInForce returns the latest entry effective on or before the date, or the earliest entry when none is effective yet
+func (s *SalaryHistoryService) salaryInForce(employeeID uuid.UUID, date time.Time) (models.SalaryHistory, error) {
+	var entry models.SalaryHistory
+	err := s.DB.Where("employee_id = ? AND effective_from <= ?", employeeID, date.Format("2006-01-02")).Order("effective_from DESC").First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = s.DB.Where("employee_id = ?", employeeID).Order("effective_from").First(&entry).Error
+	}
+	if err != nil {
+		return entry, fmt.Errorf("failed to fetch salary history: %w", err)
+	}
+	return entry, nil
+}
+
+// ApplyDueSalaryChanges sets each employee's Salary to the salary history entry in force on the date, for changes recorded
+// ahead of time that have since taken effect. It returns how many employees were updated.
+func ApplyDueSalaryChanges(db *gorm.DB, now time.Time) (int, error) {
+	result := db.Exec(`UPDATE employees SET salary = h.salary, updated_at = ?
+		FROM salary_history h
+		WHERE h.employee_id = employees.id AND employees.salary <> h.salary AND h.effective_from = (
+			SELECT MAX(effective_from) FROM salary_history WHERE employee_id = employees.id AND effective_from <= ?)`,
+		now, now.Format("2006-01-02"))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to apply salary changes: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// StartSalaryUpdater applies due salary changes now and then on every interval until ctx is done.
+func StartSalaryUpdater(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	run := func() {
+		updated, err := ApplyDueSalaryChanges(db, time.Now())
+		if err != nil {
+			utils.Logger.Error("Failed to apply salary changes", zap.Error(err))
+			return
+		}
+		if updated > 0 {
+			utils.Logger.Info("Applied salary changes", zap.Int("employees", updated))
+		}
+	}
+
+	go func() {
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func salaryFrom(year int, month time.Month, day int, salary string) models.SalaryHistory {
+	return models.SalaryHistory{EffectiveFrom: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Salary: models.MustParseMoney(salary)}
+}
+
+func TestSalarySegments(t *testing.T) {
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	fallback := models.MustParseMoney("1000")
+
+	type segment struct{ from, to, salary string }
+	testCases := []struct {
+		name     string
+		history  []models.SalaryHistory
+		expected []segment
+	}{
+		{name: "No history uses the fallback", expected: []segment{{"2024-03-01", "2024-03-31", "1000.00"}}},
+		{
+			name:     "Salary in force before the period",
+			history:  []models.SalaryHistory{salaryFrom(2023, time.January, 1, "2000"), salaryFrom(2024, time.February, 1, "2500")},
+			expected: []segment{{"2024-03-01", "2024-03-31", "2500.00"}},
+		},
+		{
+			name:     "Raise mid-period, later raise ignored, entries out of order",
+			history:  []models.SalaryHistory{salaryFrom(2024, time.April, 1, "4000"), salaryFrom(2024, time.March, 15, "3000"), salaryFrom(2023, time.January, 1, "2000")},
+			expected: []segment{{"2024-03-01", "2024-03-14", "2000.00"}, {"2024-03-15", "2024-03-31", "3000.00"}},
+		},
+		{
+			name:     "Change on the first day",
+			history:  []models.SalaryHistory{salaryFrom(2023, time.January, 1, "2000"), salaryFrom(2024, time.March, 1, "3000")},
+			expected: []segment{{"2024-03-01", "2024-03-31", "3000.00"}},
+		},
+		{
+			name:     "Joined part-way through is paid at the starting salary",
+			history:  []models.SalaryHistory{salaryFrom(2024, time.March, 10, "2000"), salaryFrom(2024, time.March, 20, "2200")},
+			expected: []segment{{"2024-03-01", "2024-03-19", "2000.00"}, {"2024-03-20", "2024-03-31", "2200.00"}},
+		},
+		{
+			name:     "An unchanged salary does not split the period",
+			history:  []models.SalaryHistory{salaryFrom(2023, time.January, 1, "2000"), salaryFrom(2024, time.March, 10, "2000")},
+			expected: []segment{{"2024-03-01", "2024-03-31", "2000.00"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			segments := SalarySegments(tc.history, fallback, start, end)
+			require.Len(t, segments, len(tc.expected))
+			for i, expected := range tc.expected {
+				assert.Equal(t, expected.from, segments[i].From.Format("2006-01-02"))
+				assert.Equal(t, expected.to, segments[i].To.Format("2006-01-02"))
+				assert.Equal(t, expected.salary, segments[i].Salary.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"payslip-generator/pkg/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// ErrNoSocialSecurityRates is returned when no social security rates are in effect on a date
+var ErrNoSocialSecurityRates = errors.New("no social security rates in effect")
+
+// SocialSecurityProgram is the contribution rates of one program, as shares of the monthly salary up to its cap
+type SocialSecurityProgram struct {
+	Code                  string          `json:"code"` // kesehatan, jht, jp, jkk or jkm
+	Name                  string          `json:"name"`
+	EmployeeRate          decimal.Decimal `json:"employee_rate"` // 0.01 is 1%
+	EmployerRate          decimal.Decimal `json:"employer_rate"`
+	WageCap               *models.Money   `json:"wage_cap,omitempty"`      // Contributions are on at most this monthly salary; none without it
+	EmployeeTaxDeductible bool            `json:"employee_tax_deductible"` // The employee's share is deducted from income for PPh 21, as JHT and JP are
+	EmployerTaxable       bool            `json:"employer_taxable"`        // The employer's share counts as the employee's income for PPh 21, as health, JKK and JKM do
+}
+
+// SocialSecurityTable is one version of the social security rates, effective from a date until the next version.
+// Tables are data loaded from SOCIAL_SECURITY_RATES_FILE, like the income tax tables.
+type SocialSecurityTable struct {
+	Version       string                  `json:"version"`
+	Name          string                  `json:"name"`
+	EffectiveFrom string                  `json:"effective_from"` // YYYY-MM-DD; compared with the last day of the period
+	Programs      []SocialSecurityProgram `json:"programs"`
+}
+
+// SocialSecurityTableSet holds every version of the social security rates
+type SocialSecurityTableSet struct {
+	Tables []SocialSecurityTable `json:"tables"` // Sorted by effective date
+}
+
+// SocialSecurityTables are the rates payroll deducts contributions by, set up by LoadSocialSecurityTables. Without them no contributions are made.
+var SocialSecurityTables *SocialSecurityTableSet
+
+// LoadSocialSecurityTables reads SocialSecurityTables from a JSON file
+func LoadSocialSecurityTables(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read social security rates: %w", err)
+	}
+	tables, err := ParseSocialSecurityTables(data)
+	if err != nil {
+		return fmt.Errorf("invalid social security rates in %s: %w", path, err)
+	}
+	SocialSecurityTables = tables
+	return nil
+}
+
+// ParseSocialSecurityTables decodes and validates social security rates. Unknown fields are rejected so a misspelt rate is not silently ignored.
+func ParseSocialSecurityTables(data []byte) (*SocialSecurityTableSet, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var set SocialSecurityTableSet
+	if err := decoder.Decode(&set); err != nil {
+		return nil, err
+	}
+	if len(set.Tables) == 0 {
+		return nil, fmt.Errorf("at least one table is required")
+	}
+	versions := make(map[string]bool)
+	dates := make(map[string]bool)
+	for _, table := range set.Tables {
+		if err := ValidateSocialSecurityTable(table); err != nil {
+			return nil, fmt.Errorf("table %q: %w", table.Version, err)
+		}
+		if versions[table.Version] {
+			return nil, fmt.Errorf("version %q is listed more than once", table.Version)
+		}
+		if dates[table.EffectiveFrom] {
+			return nil, fmt.Errorf("more than one table is effective from %s", table.EffectiveFrom)
+		}
+		versions[table.Version], dates[table.EffectiveFrom] = true, true
+	}
+	sort.SliceStable(set.Tables, func(i, j int) bool { return set.Tables[i].EffectiveFrom < set.Tables[j].EffectiveFrom })
+	return &set, nil
+}
+
+// ValidateSocialSecurityTable checks a table's date and each program's code, rates and cap
+func ValidateSocialSecurityTable(table SocialSecurityTable) error {
+	if strings.TrimSpace(table.Version) == "" {
+		return fmt.Errorf("version is required")
+	}
+	if len(table.Version) > 30 {
+		return fmt.Errorf("version must be at most 30 characters")
+	}
+	if _, err := time.Parse("2006-01-02", table.EffectiveFrom); err != nil {
+		return fmt.Errorf("effective_from must be a date in YYYY-MM-DD format")
+	}
+	codes := make(map[string]bool)
+	for i, program := range table.Programs {
+		if strings.TrimSpace(program.Code) == "" || len(program.Code) > 30 {
+			return fmt.Errorf("program %d: code is required and must be at most 30 characters", i+1)
+		}
+		if codes[program.Code] {
+			return fmt.Errorf("program %q is listed more than once", program.Code)
+		}
+		codes[program.Code] = true
+		if strings.TrimSpace(program.Name) == "" || len(program.Name) > 100 {
+			return fmt.Errorf("program %q: name is required and must be at most 100 characters", program.Code)
+		}
+		for _, rate := range []decimal.Decimal{program.EmployeeRate, program.EmployerRate} {
+			if rate.IsNegative() || rate.GreaterThanOrEqual(decimal.NewFromInt(1)) || rate.Exponent() < -6 {
+				return fmt.Errorf("program %q: rates must be at least 0 and below 1, with at most 6 decimal places", program.Code)
+			}
+		}
+		if program.WageCap != nil && !program.WageCap.IsPositive() {
+			return fmt.Errorf("program %q: wage_cap must be greater than zero", program.Code)
+		}
+	}
+	return nil
+}
+
+// TableFor returns the latest table effective on or before a date
+func (s *SocialSecurityTableSet) TableFor(date time.Time) (SocialSecurityTable, error) {
+	key := date.Format("2006-01-02")
+	for i := len(s.Tables) - 1; i >= 0; i-- {
+		if s.Tables[i].EffectiveFrom <= key {
+			return s.Tables[i], nil
+		}
+	}
+	return SocialSecurityTable{}, fmt.Errorf("%w on %s", ErrNoSocialSecurityRates, key)
+}
+
+// PayrollContribution is one program's contributions for a payslip
+type PayrollContribution struct {
+	Program         string          `json:"program"`
+	Name            string          `json:"name"`
+	Wage            models.Money    `json:"wage"` // Monthly salary after the program's cap
+	EmployeeRate    decimal.Decimal `json:"employee_rate"`
+	EmployerRate    decimal.Decimal `json:"employer_rate"`
+	EmployeeAmount  models.Money    `json:"employee_amount"` // Deducted from pay
+	EmployerAmount  models.Money    `json:"employer_amount"` // Paid by the employer on top of pay
+	TaxDeductible   bool            `json:"tax_deductible"`
+	EmployerTaxable bool            `json:"employer_taxable"`
+}
+
+// CalculateContributions works out each program's contributions on a wage, the salary earned in the period, capped by
+// each program's wage cap. Each amount is rounded by the payroll policy.
+func CalculateContributions(table SocialSecurityTable, salary decimal.Decimal, rules models.PayrollPolicyRules) []PayrollContribution {
+	contributions := make([]PayrollContribution, 0, len(table.Programs))
+	for _, program := range table.Programs {
+		wage := salary
+		if program.WageCap != nil && program.WageCap.LessThan(wage) {
+			wage = program.WageCap.Decimal
+		}
+		contributions = append(contributions, PayrollContribution{
+			Program:         program.Code,
+			Name:            program.Name,
+			Wage:            models.NewMoney(wage),
+			EmployeeRate:    program.EmployeeRate,
+			EmployerRate:    program.EmployerRate,
+			EmployeeAmount:  RoundPayrollAmount(rules, wage.Mul(program.EmployeeRate)),
+			EmployerAmount:  RoundPayrollAmount(rules, wage.Mul(program.EmployerRate)),
+			TaxDeductible:   program.EmployeeTaxDeductible,
+			EmployerTaxable: program.EmployerTaxable,
+		})
+	}
+	return contributions
+}
+
+// ContributionReportProgram totals one program's contributions for a period
+type ContributionReportProgram struct {
+	Program        string       `json:"program"`
+	Name           string       `json:"name"`
+	Employees      int          `json:"employees"`
+	Wages          models.Money `json:"wages"`
+	EmployeeAmount models.Money `json:"employee_amount"`
+	EmployerAmount models.Money `json:"employer_amount"`
+	Total          models.Money `json:"total"`
+}
+
+// ContributionReportEmployee lists one employee's contributions for a period
+type ContributionReportEmployee struct {
+	EmployeeID     uuid.UUID                    `json:"employee_id"`
+	Username       string                       `json:"username"`
+	Contributions  []models.PayslipContribution `json:"contributions"`
+	EmployeeAmount models.Money                 `json:"employee_amount"`
+	EmployerAmount models.Money                 `json:"employer_amount"`
+}
+
+// ContributionReport is the social security contributions of an attendance period's active payslips, for filing
+type ContributionReport struct {
+	PeriodID        uuid.UUID                    `json:"period_id"`
+	PeriodStartDate string                       `json:"period_start_date"`
+	PeriodEndDate   string                       `json:"period_end_date"`
+	Programs        []ContributionReportProgram  `json:"programs"`
+	Employees       []ContributionReportEmployee `json:"employees"`
+	EmployeeAmount  models.Money                 `json:"employee_amount"`
+	EmployerAmount  models.Money                 `json:"employer_amount"` // The employer's cost
+	Total           models.Money                 `json:"total"`           // Due to BPJS for the period
+}
+
+// LoadContributionReport totals the contributions of a period's payslips, leaving out voided payroll runs.
+// Employees are listed by username and programs in the order of their rates table.
+func LoadContributionReport(db *gorm.DB, period models.AttendancePeriod) (ContributionReport, error) {
+	report := ContributionReport{
+		PeriodID:        period.ID,
+		PeriodStartDate: period.StartDate.Format("2006-01-02"),
+		PeriodEndDate:   period.EndDate.Format("2006-01-02"),
+		Programs:        []ContributionReportProgram{},
+		Employees:       []ContributionReportEmployee{},
+	}
+	var contributions []models.PayslipContribution
+	if err := db.Preload("Employee").
+		Joins("JOIN payslips ON payslips.id = payslip_contributions.payslip_id").
+		Joins("JOIN employees ON employees.id = payslip_contributions.employee_id").
+		Where("payslip_contributions.attendance_period_id = ? AND payslips.voided_at IS NULL", period.ID).
+		Order("employees.username, payslip_contributions.position").
+		Find(&contributions).Error; err != nil {
+		return report, fmt.Errorf("failed to fetch contributions: %w", err)
+	}
+
+	programs := make(map[string]*ContributionReportProgram)
+	var programOrder []string
+	for _, contribution := range contributions {
+		if len(report.Employees) == 0 || report.Employees[len(report.Employees)-1].EmployeeID != contribution.EmployeeID {
+			report.Employees = append(report.Employees, ContributionReportEmployee{EmployeeID: contribution.EmployeeID, Username: contribution.Employee.Username})
+		}
+		employee := &report.Employees[len(report.Employees)-1]
+		employee.Contributions = append(employee.Contributions, contribution)
+		employee.EmployeeAmount = employee.EmployeeAmount.Add(contribution.EmployeeAmount)
+		employee.EmployerAmount = employee.EmployerAmount.Add(contribution.EmployerAmount)
+
+		program, ok := programs[contribution.Program]
+		if !ok {
+			program = &ContributionReportProgram{Program: contribution.Program, Name: contribution.ProgramName}
+			programs[contribution.Program] = program
+			programOrder = append(programOrder, contribution.Program)
+		}
+		program.Employees++
+		program.Wages = program.Wages.Add(contribution.Wage)
+		program.EmployeeAmount = program.EmployeeAmount.Add(contribution.EmployeeAmount)
+		program.EmployerAmount = program.EmployerAmount.Add(contribution.EmployerAmount)
+		program.Total = program.EmployeeAmount.Add(program.EmployerAmount)
+
+		report.EmployeeAmount = report.EmployeeAmount.Add(contribution.EmployeeAmount)
+		report.EmployerAmount = report.EmployerAmount.Add(contribution.EmployerAmount)
+	}
+	for _, code := range programOrder {
+		report.Programs = append(report.Programs, *programs[code])
+	}
+	report.Total = report.EmployeeAmount.Add(report.EmployerAmount)
+	return report, nil
+}
+
+// WriteCSV writes the report as one row per employee and program, followed by one total row per program
+func (r ContributionReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"period_start", "period_end", "employee_id", "username", "program", "program_name", "wage", "employee_rate", "employer_rate", "employee_amount", "employer_amount", "total"}}
+	for _, employee := range r.Employees {
+		for _, c := range employee.Contributions {
+			rows = append(rows, []string{
+				r.PeriodStartDate, r.PeriodEndDate, employee.EmployeeID.String(), employee.Username, c.Program, c.ProgramName, c.Wage.String(),
+				c.EmployeeRate.String(), c.EmployerRate.String(), c.EmployeeAmount.String(), c.EmployerAmount.String(), c.EmployeeAmount.Add(c.EmployerAmount).String(),
+			})
+		}
+	}
+	for _, program := range r.Programs {
+		rows = append(rows, []string{
+			r.PeriodStartDate, r.PeriodEndDate, "", "TOTAL", program.Program, program.Name, program.Wages.String(),
+			"", "", program.EmployeeAmount.String(), program.EmployerAmount.String(), program.Total.String(),
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write contribution report: %w", err)
+	}
+	return nil
+}
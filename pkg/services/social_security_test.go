@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"payslip-generator/pkg/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shippedSocialSecurityTable returns the rates in effect on a date from the rates shipped with the repository
+func shippedSocialSecurityTable(t *testing.T, date time.Time) SocialSecurityTable {
+	t.Helper()
+	data, err := os.ReadFile("../../config/social_security_rates.json")
+	require.NoError(t, err)
+	tables, err := ParseSocialSecurityTables(data)
+	require.NoError(t, err)
+	table, err := tables.TableFor(date)
+	require.NoError(t, err)
+	return table
+}
+
+func TestParseSocialSecurityTables_ShippedRates(t *testing.T) {
+	assert.Equal(t, "2023-03", shippedSocialSecurityTable(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)).Version)
+	table := shippedSocialSecurityTable(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2024-03", table.Version)
+	var codes []string
+	for _, program := range table.Programs {
+		codes = append(codes, program.Code)
+	}
+	assert.Equal(t, []string{models.ProgramHealth, models.ProgramOldAge, models.ProgramPension, models.ProgramWorkAccident, models.ProgramDeath}, codes)
+}
+
+func TestParseSocialSecurityTables_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "No tables", data: `{"tables": []}`},
+		{name: "Misspelt field", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "programs": [{"code": "jht", "name": "JHT", "employee_rate": "0.02", "employer_rate": "0.037", "wagecap": "100"}]}]}`},
+		{name: "Bad date", data: `{"tables": [{"version": "1", "effective_from": "2024-13-01", "programs": []}]}`},
+		{name: "Duplicate program", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "programs": [{"code": "jht", "name": "JHT"}, {"code": "jht", "name": "JHT"}]}]}`},
+		{name: "Rate given as a percentage", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "programs": [{"code": "jht", "name": "JHT", "employee_rate": "2"}]}]}`},
+		{name: "Zero cap", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "programs": [{"code": "jp", "name": "JP", "wage_cap": "0"}]}]}`},
+		{name: "Same version", data: `{"tables": [{"version": "1", "effective_from": "2024-01-01", "programs": []}, {"version": "1", "effective_from": "2024-03-01", "programs": []}]}`},
+	}
+	for _, tc := range testCases {
+		_, err := ParseSocialSecurityTables([]byte(tc.data))
+		assert.Error(t, err, tc.name)
+	}
+}
+
+func TestCalculateContributions(t *testing.T) {
+	table := shippedSocialSecurityTable(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC))
+	rules := DefaultPayrollPolicy().PayrollPolicyRules
+
+	testCases := []struct {
+		salary   string
+		expected map[string][3]string // Program: wage, employee amount, employer amount
+	}{
+		{salary: "5000000", expected: map[string][3]string{
+			models.ProgramHealth:       {"5000000.00", "50000.00", "200000.00"},
+			models.ProgramOldAge:       {"5000000.00", "100000.00", "185000.00"},
+			models.ProgramPension:      {"5000000.00", "50000.00", "100000.00"},
+			models.ProgramWorkAccident: {"5000000.00", "0.00", "12000.00"},
+			models.ProgramDeath:        {"5000000.00", "0.00", "15000.00"},
+		}},
+		{salary: "15000000", expected: map[string][3]string{
+			models.ProgramHealth:       {"12000000.00", "120000.00", "480000.00"},
+			models.ProgramOldAge:       {"15000000.00", "300000.00", "555000.00"},
+			models.ProgramPension:      {"10042300.00", "100423.00", "200846.00"},
+			models.ProgramWorkAccident: {"15000000.00", "0.00", "36000.00"},
+			models.ProgramDeath:        {"15000000.00", "0.00", "45000.00"},
+		}},
+	}
+	for _, tc := range testCases {
+		contributions := CalculateContributions(table, decimal.RequireFromString(tc.salary), rules)
+		require.Len(t, contributions, len(tc.expected))
+		for _, c := range contributions {
+			expected := tc.expected[c.Program]
+			assert.Equal(t, expected[0], c.Wage.String(), "%s wage on %s", c.Program, tc.salary)
+			assert.Equal(t, expected[1], c.EmployeeAmount.String(), "%s employee share on %s", c.Program, tc.salary)
+			assert.Equal(t, expected[2], c.EmployerAmount.String(), "%s employer share on %s", c.Program, tc.salary)
+		}
+	}
+}
+
+func TestContributionReport_WriteCSV(t *testing.T) {
+	employeeID := uuid.MustParse("6f1c7c52-8a47-4a8e-9b0d-1f0e4f6d2a11")
+	report := ContributionReport{
+		PeriodStartDate: "2024-03-01",
+		PeriodEndDate:   "2024-03-31",
+		Employees: []ContributionReportEmployee{{
+			EmployeeID: employeeID,
+			Username:   "budi",
+			Contributions: []models.PayslipContribution{{
+				Program: models.ProgramOldAge, ProgramName: "BPJS Ketenagakerjaan JHT", Wage: models.MustParseMoney("5000000"),
+				EmployeeRate: decimal.RequireFromString("0.02"), EmployerRate: decimal.RequireFromString("0.037"),
+				EmployeeAmount: models.MustParseMoney("100000"), EmployerAmount: models.MustParseMoney("185000"),
+			}},
+		}},
+		Programs: []ContributionReportProgram{{
+			Program: models.ProgramOldAge, Name: "BPJS Ketenagakerjaan JHT", Employees: 1, Wages: models.MustParseMoney("5000000"),
+			EmployeeAmount: models.MustParseMoney("100000"), EmployerAmount: models.MustParseMoney("185000"), Total: models.MustParseMoney("285000"),
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	assert.Equal(t, strings.Join([]string{
+		"period_start,period_end,employee_id,username,program,program_name,wage,employee_rate,employer_rate,employee_amount,employer_amount,total",
+		"2024-03-01,2024-03-31,6f1c7c52-8a47-4a8e-9b0d-1f0e4f6d2a11,budi,jht,BPJS Ketenagakerjaan JHT,5000000.00,0.02,0.037,100000.00,185000.00,285000.00",
+		"2024-03-01,2024-03-31,,TOTAL,jht,BPJS Ketenagakerjaan JHT,5000000.00,,,100000.00,185000.00,285000.00",
+		"",
+	}, "\n"), buf.String())
+}
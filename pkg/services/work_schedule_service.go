@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// MaxHoursPerDay is the upper bound for a work schedule's daily hours
+const MaxHoursPerDay = 24
+
+// ValidateWorkSchedule checks a schedule's name, working days and daily hours
+func ValidateWorkSchedule(schedule models.WorkSchedule) error {
+	if strings.TrimSpace(schedule.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(schedule.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if schedule.WorkingDays == 0 {
+		return fmt.Errorf("at least one working day is required")
+	}
+	if schedule.WorkingDays&^utils.EveryDayWorkWeek != 0 {
+		return fmt.Errorf("working days contain an invalid weekday")
+	}
+	if schedule.HoursPerDay <= 0 || schedule.HoursPerDay > MaxHoursPerDay {
+		return fmt.Errorf("hours per day must be greater than 0 and at most %d", MaxHoursPerDay)
+	}
+	if !decimal.NewFromFloat(schedule.HoursPerDay).Equal(decimal.NewFromFloat(schedule.HoursPerDay).Round(2)) {
+		return fmt.Errorf("hours per day must have at most 2 decimal places")
+	}
+	return nil
+}
+
+// ResolveWorkSchedule returns the employee's assigned work schedule, or the standard schedule if none is assigned
+func ResolveWorkSchedule(db *gorm.DB, employee models.Employee) (models.WorkSchedule, error) {
+	if employee.WorkScheduleID == nil {
+		return models.StandardWorkSchedule(), nil
+	}
+	if employee.WorkSchedule != nil && employee.WorkSchedule.ID == *employee.WorkScheduleID {
+		return *employee.WorkSchedule, nil
+	}
+	return FindWorkSchedule(db, *employee.WorkScheduleID)
+}
+
+// FindWorkSchedule loads a work schedule by ID
+func FindWorkSchedule(db *gorm.DB, id uuid.UUID) (models.WorkSchedule, error) {
+	var schedule models.WorkSchedule
+	if err := db.First(&schedule, "id = ?", id).Error; err != nil {
+		return schedule, fmt.Errorf("failed to load work schedule %s: %w", id, err)
+	}
+	return schedule, nil
+}
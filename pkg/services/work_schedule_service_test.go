@@ -0,0 +1,40 @@
+package services
+
+import (
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWorkSchedule(t *testing.T) {
+	retail := utils.NewWorkWeek(time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	testCases := []struct {
+		name        string
+		schedule    models.WorkSchedule
+		expectError bool
+	}{
+		{name: "Retail week", schedule: models.WorkSchedule{Name: "Retail", WorkingDays: retail, HoursPerDay: 8}, expectError: false},
+		{name: "Half hours", schedule: models.WorkSchedule{Name: "Short days", WorkingDays: retail, HoursPerDay: 7.5}, expectError: false},
+		{name: "Standard schedule is valid", schedule: models.StandardWorkSchedule(), expectError: false},
+		{name: "Missing name", schedule: models.WorkSchedule{Name: " ", WorkingDays: retail, HoursPerDay: 8}, expectError: true},
+		{name: "No working days", schedule: models.WorkSchedule{Name: "Nothing", HoursPerDay: 8}, expectError: true},
+		{name: "Invalid weekday bit", schedule: models.WorkSchedule{Name: "Odd", WorkingDays: 1 << 7, HoursPerDay: 8}, expectError: true},
+		{name: "Zero hours", schedule: models.WorkSchedule{Name: "Zero", WorkingDays: retail, HoursPerDay: 0}, expectError: true},
+		{name: "More than a day", schedule: models.WorkSchedule{Name: "Long", WorkingDays: retail, HoursPerDay: 25}, expectError: true},
+		{name: "Too precise", schedule: models.WorkSchedule{Name: "Precise", WorkingDays: retail, HoursPerDay: 7.333}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWorkSchedule(tc.schedule)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(filepath.Join(root, "receipts"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "reimbursements/abc/1.png", []byte("png"), "image/png"))
+	body, err := store.Get(ctx, "reimbursements/abc/1.png")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "png", string(data))
+
+	entries, err := os.ReadDir(filepath.Join(root, "receipts", "reimbursements", "abc"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "No temporary files are left behind")
+
+	require.NoError(t, store.Delete(ctx, "reimbursements/abc/1.png"))
+	require.NoError(t, store.Delete(ctx, "reimbursements/abc/1.png"), "Deleting twice is fine")
+	_, err = store.Get(ctx, "reimbursements/abc/1.png")
+	assert.True(t, errors.Is(err, ErrObjectNotFound))
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "a/../../b", "a//b", `a\b`} {
+		assert.Error(t, store.Put(ctx, key, []byte("x"), ""), "key %q", key)
+	}
+}
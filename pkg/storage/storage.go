@@ -0,0 +1,66 @@
+// Package storage keeps uploaded files, such as reimbursement receipts, behind a common interface
+// with a local filesystem backend and an S3-compatible backend.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"payslip-generator/pkg/config"
+	"strings"
+)
+
+// Storage backends, selected with RECEIPT_STORAGE
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrObjectNotFound is returned when a key has no stored object
+var ErrObjectNotFound = errors.New("object not found")
+
+// Store saves, reads and deletes objects by key. Keys are slash-separated relative paths.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	Backend() string
+}
+
+// Receipts is the store for reimbursement receipts, set up by ConnectReceiptStore
+var Receipts Store
+
+// ConnectReceiptStore sets up Receipts from the application configuration
+func ConnectReceiptStore() error {
+	var err error
+	switch config.AppConfig.ReceiptStorage {
+	case BackendS3:
+		Receipts, err = NewS3Store(S3Config{
+			Endpoint:        config.AppConfig.S3Endpoint,
+			Region:          config.AppConfig.S3Region,
+			Bucket:          config.AppConfig.S3Bucket,
+			AccessKeyID:     config.AppConfig.S3AccessKeyID,
+			SecretAccessKey: config.AppConfig.S3SecretAccessKey,
+		})
+	default:
+		Receipts, err = NewLocalStore(config.AppConfig.ReceiptStorageDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set up receipt storage: %w", err)
+	}
+	return nil
+}
+
+// validateKey rejects keys that are empty, absolute or climb out of the store
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	return nil
+}
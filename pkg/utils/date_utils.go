@@ -2,9 +2,32 @@ package utils
 
 import "time"
 
+// HolidaySet is a set of non-working dates keyed by YYYY-MM-DD. A nil set contains no holidays.
+type HolidaySet map[string]struct{}
+
+// NewHolidaySet builds a HolidaySet from the given dates, ignoring their time components
+func NewHolidaySet(dates ...time.Time) HolidaySet {
+	set := make(HolidaySet, len(dates))
+	for _, d := range dates {
+		set[d.Format("2006-01-02")] = struct{}{}
+	}
+	return set
+}
+
+// Contains reports whether the calendar date of t is a holiday
+func (s HolidaySet) Contains(t time.Time) bool {
+	_, ok := s[t.Format("2006-01-02")]
+	return ok
+}
+
+// IsWorkingDay reports whether t falls on a working day of the week and is not a holiday
+func IsWorkingDay(t time.Time, week WorkWeek, holidays HolidaySet) bool {
+	return week.Includes(t.Weekday()) && !holidays.Contains(t)
+}
+
 // CalculateWorkingDays iterates from start to end date (inclusive) and
-// counts days that are not Saturday or Sunday.
-func CalculateWorkingDays(start time.Time, end time.Time) int {
+// counts days that are working days of the week and not holidays.
+func CalculateWorkingDays(start time.Time, end time.Time, week WorkWeek, holidays HolidaySet) int {
 	// Normalize dates to the beginning of the day to avoid issues with time components
 	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
 	endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
@@ -16,8 +39,7 @@ func CalculateWorkingDays(start time.Time, end time.Time) int {
 	workingDays := 0
 	current := startDate
 	for !current.After(endDate) {
-		weekday := current.Weekday()
-		if weekday != time.Saturday && weekday != time.Sunday {
+		if IsWorkingDay(current, week, holidays) {
 			workingDays++
 		}
 		current = current.AddDate(0, 0, 1) // Move to the next day
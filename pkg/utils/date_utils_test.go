@@ -14,6 +14,8 @@ func TestCalculateWorkingDays(t *testing.T) {
 		name          string
 		startDate     time.Time
 		endDate       time.Time
+		week          *WorkWeek // nil means StandardWorkWeek
+		holidays      []time.Time
 		expectedDays  int
 		expectError   bool // For future use if validation is added
 	}{
@@ -83,12 +85,56 @@ func TestCalculateWorkingDays(t *testing.T) {
             endDate:      time.Date(2024, time.February, 29, 0, 0, 0, 0, loc),
             expectedDays: 21, // 29 days, 8 weekend days -> 21 working days
         },
+		{
+			name:         "Week with a midweek holiday",
+			startDate:    time.Date(2023, time.October, 2, 0, 0, 0, 0, loc), // Monday
+			endDate:      time.Date(2023, time.October, 8, 0, 0, 0, 0, loc), // Sunday
+			holidays:     []time.Time{time.Date(2023, time.October, 4, 0, 0, 0, 0, loc)},
+			expectedDays: 4,
+		},
+		{
+			name:         "Holiday on a weekend is not double counted",
+			startDate:    time.Date(2023, time.October, 2, 0, 0, 0, 0, loc),
+			endDate:      time.Date(2023, time.October, 8, 0, 0, 0, 0, loc),
+			holidays:     []time.Time{time.Date(2023, time.October, 7, 0, 0, 0, 0, loc)},
+			expectedDays: 5,
+		},
+		{
+			name:         "Holiday outside the range is ignored",
+			startDate:    time.Date(2023, time.October, 2, 0, 0, 0, 0, loc),
+			endDate:      time.Date(2023, time.October, 6, 0, 0, 0, 0, loc),
+			holidays:     []time.Time{time.Date(2023, time.December, 25, 0, 0, 0, 0, loc)},
+			expectedDays: 5,
+		},
+		{
+			name:         "Tuesday to Saturday week",
+			startDate:    time.Date(2023, time.October, 2, 0, 0, 0, 0, loc), // Monday
+			endDate:      time.Date(2023, time.October, 8, 0, 0, 0, 0, loc), // Sunday
+			week:         workWeekPtr(NewWorkWeek(time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)),
+			expectedDays: 5,
+		},
+		{
+			name:         "Six-day week with a holiday",
+			startDate:    time.Date(2023, time.October, 2, 0, 0, 0, 0, loc),
+			endDate:      time.Date(2023, time.October, 15, 0, 0, 0, 0, loc),
+			week:         workWeekPtr(NewWorkWeek(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)),
+			holidays:     []time.Time{time.Date(2023, time.October, 14, 0, 0, 0, 0, loc)}, // Saturday
+			expectedDays: 11,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualDays := CalculateWorkingDays(tc.startDate, tc.endDate)
+			week := StandardWorkWeek
+			if tc.week != nil {
+				week = *tc.week
+			}
+			actualDays := CalculateWorkingDays(tc.startDate, tc.endDate, week, NewHolidaySet(tc.holidays...))
 			assert.Equal(t, tc.expectedDays, actualDays)
 		})
 	}
 }
+
+func workWeekPtr(w WorkWeek) *WorkWeek {
+	return &w
+}
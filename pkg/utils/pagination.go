@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPageSize is used when no page_size query parameter is given
+	DefaultPageSize = 20
+	// MaxPageSize caps page_size to keep list queries bounded
+	MaxPageSize = 100
+)
+
+// Pagination describes the page returned by a list endpoint
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalItems int64 `json:"total_items"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// GetPagination reads page and page_size query parameters, applying defaults and bounds.
+func GetPagination(c *fiber.Ctx) Pagination {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := c.QueryInt("page_size", DefaultPageSize)
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return Pagination{Page: page, PageSize: pageSize}
+}
+
+// Paginate counts the rows matched by query, fills in the totals and returns the query limited to the requested page.
+func (p *Pagination) Paginate(query *gorm.DB) (*gorm.DB, error) {
+	if err := query.Session(&gorm.Session{}).Count(&p.TotalItems).Error; err != nil {
+		return nil, err
+	}
+	p.TotalPages = int((p.TotalItems + int64(p.PageSize) - 1) / int64(p.PageSize))
+	return query.Session(&gorm.Session{}).Offset((p.Page - 1) * p.PageSize).Limit(p.PageSize), nil
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WorkWeek is a set of working weekdays stored as a bitmask, bit n standing for time.Weekday(n).
+// It marshals to JSON as a list of lower-case weekday names, e.g. ["monday","tuesday"].
+type WorkWeek uint8
+
+// StandardWorkWeek is Monday to Friday
+var StandardWorkWeek = NewWorkWeek(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+
+// EveryDayWorkWeek contains all seven days
+var EveryDayWorkWeek = NewWorkWeek(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+
+// NewWorkWeek builds a WorkWeek from the given weekdays
+func NewWorkWeek(days ...time.Weekday) WorkWeek {
+	var w WorkWeek
+	for _, d := range days {
+		w |= 1 << uint(d)
+	}
+	return w
+}
+
+// Includes reports whether d is a working day
+func (w WorkWeek) Includes(d time.Weekday) bool {
+	return w&(1<<uint(d)) != 0
+}
+
+// Days returns the working weekdays starting from Monday
+func (w WorkWeek) Days() []time.Weekday {
+	var days []time.Weekday
+	for i := 1; i <= 7; i++ {
+		d := time.Weekday(i % 7)
+		if w.Includes(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// String returns the working weekdays as a comma-separated list of short names, e.g. "Mon,Tue"
+func (w WorkWeek) String() string {
+	var names []string
+	for _, d := range w.Days() {
+		names = append(names, d.String()[:3])
+	}
+	return strings.Join(names, ",")
+}
+
+// ParseWeekday parses a full or three-letter English weekday name, case-insensitively
+func ParseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday %q", s)
+}
+
+// MarshalJSON encodes the work week as a list of weekday names
+func (w WorkWeek) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, 7)
+	for _, d := range w.Days() {
+		names = append(names, strings.ToLower(d.String()))
+	}
+	return json.Marshal(names)
+}
+
+// UnmarshalJSON decodes a list of weekday names
+func (w *WorkWeek) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("working days must be a list of weekday names: %w", err)
+	}
+	var week WorkWeek
+	for _, name := range names {
+		d, err := ParseWeekday(name)
+		if err != nil {
+			return err
+		}
+		week |= NewWorkWeek(d)
+	}
+	*w = week
+	return nil
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkWeek(t *testing.T) {
+	assert.True(t, StandardWorkWeek.Includes(time.Monday))
+	assert.False(t, StandardWorkWeek.Includes(time.Saturday))
+	assert.Equal(t, "Mon,Tue,Wed,Thu,Fri", StandardWorkWeek.String())
+	assert.Equal(t, "Mon,Sat,Sun", NewWorkWeek(time.Sunday, time.Saturday, time.Monday).String())
+	assert.Len(t, EveryDayWorkWeek.Days(), 7)
+}
+
+func TestWorkWeekJSON(t *testing.T) {
+	retail := NewWorkWeek(time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	data, err := json.Marshal(retail)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["tuesday","wednesday","thursday","friday","saturday"]`, string(data))
+
+	var decoded WorkWeek
+	require.NoError(t, json.Unmarshal([]byte(`["Tue","wednesday","THU","fri","sat","sat"]`), &decoded))
+	assert.Equal(t, retail, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`["funday"]`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`"monday"`), &decoded))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
 	"payslip-generator/pkg/utils"
 	"testing"
 	"time"
@@ -125,75 +126,272 @@ func TestCreateAttendancePeriod_Unauthorized(t *testing.T) {
 	// This part is covered in TestProtectedAdminRoute_AccessControl in auth_integration_test.go
 }
 
-// TODO: Add tests for RunPayroll and GetPayslipsSummary
-// TestRunPayroll will be complex due to its dependencies (employees, attendance, overtime, reimbursements)
-// It would require significant setup for each test case.
-// Example structure for TestRunPayroll:
-/*
-func TestRunPayroll_Success(t *testing.T) {
-    adminToken := getAdminToken(t, "payrolladmin", "payrollpass")
-    clearTestData() // Ensure clean slate
-
-    // 1. Create Admin (done by getAdminToken if it clears)
-    var adminUser models.Admin
-    testDB.Where("username = ?", "payrolladmin").First(&adminUser)
-
-
-    // 2. Create Attendance Period
-    startDate := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
-    endDate := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
-    ipAddr := "127.0.0.1"
-    attPeriod := models.AttendancePeriod{
-        StartDate: startDate,
-        EndDate:   endDate,
-        BaseModel: models.BaseModel{CreatedBy: &adminUser.ID, IPAddress: &ipAddr},
-    }
-    testDB.Create(&attPeriod)
-
-    // 3. Create Employees
-    emp1Salary := 60000.0
-    emp1 := models.Employee{Username: "emp1payroll", Password: "pw", Salary: emp1Salary, BaseModel: models.BaseModel{CreatedBy: &adminUser.ID}}
-    testDB.Create(&emp1)
-
-    // 4. Create Attendance Records for emp1
-    // Example: emp1 attended all working days
-    totalWorkingDays := utils.CalculateWorkingDays(startDate, endDate)
-    currentDate := startDate
-    for i := 0; i < int(totalWorkingDays); {
-        if currentDate.Weekday() != time.Saturday && currentDate.Weekday() != time.Sunday {
-            attRec := models.AttendanceRecord{
-                EmployeeID: emp1.ID,
-                AttendancePeriodID: attPeriod.ID,
-                Date: currentDate,
-                CheckInTime: currentDate.Add(9 * time.Hour), // 9 AM check-in
-                BaseModel: models.BaseModel{CreatedBy: &emp1.ID},
-            }
-            testDB.Create(&attRec)
-            i++
-        }
-        currentDate = currentDate.AddDate(0,0,1)
-    }
-
-    // 5. (Optional) Create Overtime, Reimbursements
-
-    // 6. Run Payroll
-    payrollPayload := fiber.Map{"attendance_period_id": attPeriod.ID.String()}
-    resp, err := makeRequest("POST", "/api/v1/admin/payroll", createJSONBody(payrollPayload), adminToken)
-    require.NoError(t, err)
-    assert.Equal(t, http.StatusOK, resp.Code)
-
-    var body map[string]interface{}
-    json.Unmarshal(resp.Body.Bytes(), &body)
-    assert.Equal(t, "success", body["status"])
-
-    // 7. Verify Payslip created for emp1
-    var payslip models.Payslip
-    err = testDB.Where("employee_id = ? AND attendance_period_id = ?", emp1.ID, attPeriod.ID).First(&payslip).Error
-    require.NoError(t, err)
-
-    expectedProratedSalary := emp1Salary // Since attended all days
-    assert.Equal(t, expectedProratedSalary, payslip.ProratedSalary)
-    assert.Equal(t, totalWorkingDays, payslip.AttendanceCount)
-    // ... other assertions for overtime, reimbursement, take home pay
+// runPayrollAndWait queues a payroll run and polls its status until the background worker finishes
+func runPayrollAndWait(t *testing.T, adminToken string, periodID string) map[string]interface{} {
+	payload := fiber.Map{"attendance_period_id": periodID}
+	resp, err := makeRequest("POST", "/api/v1/admin/payroll", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	var body map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	runID := body["data"].(map[string]interface{})["ID"].(string)
+
+	for i := 0; i < 50; i++ {
+		resp, err = makeRequest("GET", "/api/v1/admin/payroll/runs/"+runID, nil, adminToken)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.Code)
+		json.Unmarshal(resp.Body.Bytes(), &body)
+		run := body["data"].(map[string]interface{})
+		if run["Status"] == models.PayrollRunCompleted || run["Status"] == models.PayrollRunFailed {
+			return run
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("Payroll run %s did not finish in time", runID)
+	return nil
+}
+
+func TestRunPayroll_ReportsProgress(t *testing.T) {
+	adminToken := getAdminToken(t, "runadmin", "runpass")
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	for _, username := range []string{"runemp1", "runemp2", "runemp3"} {
+		require.NoError(t, testDB.Create(&models.Employee{Username: username, Password: "pw", Salary: models.MustParseMoney("5000")}).Error)
+	}
+
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	assert.Equal(t, models.PayrollRunCompleted, run["Status"])
+	assert.EqualValues(t, 3, run["TotalEmployees"])
+	assert.EqualValues(t, 3, run["ProcessedEmployees"])
+	assert.EqualValues(t, 3, run["PayslipsGenerated"])
+	assert.NotNil(t, run["FinishedAt"])
+
+	var reloadedPeriod models.AttendancePeriod
+	require.NoError(t, testDB.First(&reloadedPeriod, "id = ?", attPeriod.ID).Error)
+	assert.NotNil(t, reloadedPeriod.PayrollRunAt)
+
+	// A second run for the same period is rejected
+	payload := fiber.Map{"attendance_period_id": attPeriod.ID.String()}
+	resp, err := makeRequest("POST", "/api/v1/admin/payroll", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestRunPayroll_FailedEmployeeIsPaidOnRetry(t *testing.T) {
+	adminToken := getAdminToken(t, "retryadmin", "retrypass")
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC), // Friday
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	weekend := models.WorkSchedule{Name: "Weekend", WorkingDays: utils.NewWorkWeek(time.Saturday, time.Sunday), HoursPerDay: 8}
+	require.NoError(t, testDB.Create(&weekend).Error)
+	paidEmp := models.Employee{Username: "retryemp1", Password: "pw", Salary: models.MustParseMoney("5000")}
+	weekendEmp := models.Employee{Username: "retryemp2", Password: "pw", Salary: models.MustParseMoney("5000"), WorkScheduleID: &weekend.ID}
+	require.NoError(t, testDB.Create(&paidEmp).Error)
+	require.NoError(t, testDB.Create(&weekendEmp).Error)
+
+	// The preview shows the run's outcome: the other employee paid and the weekend worker left out with a warning
+	resp, err := makeRequest("POST", "/api/v1/admin/payroll/preview", createJSONBody(fiber.Map{"attendance_period_id": attPeriod.ID.String()}), adminToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var preview struct {
+		Data struct {
+			Employees []struct {
+				EmployeeID uuid.UUID `json:"employee_id"`
+			} `json:"employees"`
+			Warnings []services.PayrollWarning `json:"warnings"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &preview))
+	require.Len(t, preview.Data.Employees, 1)
+	assert.Equal(t, paidEmp.ID, preview.Data.Employees[0].EmployeeID)
+	require.Len(t, preview.Data.Warnings, 1)
+	assert.Equal(t, services.WarningPayslipFailed, preview.Data.Warnings[0].Type)
+	assert.Equal(t, weekendEmp.ID, preview.Data.Warnings[0].EmployeeID)
+
+	// The weekend schedule has no working days in the period, which fails that employee's payslip only
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	assert.Equal(t, models.PayrollRunFailed, run["Status"])
+	assert.EqualValues(t, 2, run["ProcessedEmployees"])
+	assert.EqualValues(t, 1, run["PayslipsGenerated"])
+	assert.EqualValues(t, 1, run["FailedEmployees"])
+	warnings := run["Warnings"].([]interface{})
+	require.Len(t, warnings, 1)
+	assert.Equal(t, services.WarningPayslipFailed, warnings[0].(map[string]interface{})["type"])
+	assert.Equal(t, weekendEmp.ID.String(), warnings[0].(map[string]interface{})["employee_id"])
+
+	var reloadedPeriod models.AttendancePeriod
+	require.NoError(t, testDB.First(&reloadedPeriod, "id = ?", attPeriod.ID).Error)
+	assert.Nil(t, reloadedPeriod.PayrollRunAt, "The period stays open for the failed employee")
+
+	// Once the schedule is fixed, running payroll again pays only the employee without a payslip
+	require.NoError(t, testDB.Model(&weekendEmp).Update("work_schedule_id", nil).Error)
+	run = runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	assert.Equal(t, models.PayrollRunCompleted, run["Status"])
+	assert.EqualValues(t, 1, run["PayslipsGenerated"])
+	assert.EqualValues(t, 1, run["SkippedEmployees"])
+	assert.EqualValues(t, 0, run["FailedEmployees"])
+
+	var payslipCount int64
+	testDB.Model(&models.Payslip{}).Where("attendance_period_id = ? AND voided_at IS NULL", attPeriod.ID).Count(&payslipCount)
+	assert.EqualValues(t, 2, payslipCount)
+	require.NoError(t, testDB.First(&reloadedPeriod, "id = ?", attPeriod.ID).Error)
+	assert.NotNil(t, reloadedPeriod.PayrollRunAt)
+}
+
+func TestPreviewPayroll_DoesNotPersist(t *testing.T) {
+	adminToken := getAdminToken(t, "previewadmin", "previewpass")
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC), // Friday
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+
+	emp := models.Employee{Username: "previewemp", Password: "pw", Salary: models.MustParseMoney("5000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	for _, day := range []int{4, 5} {
+		attRec := models.AttendanceRecord{
+			EmployeeID:         emp.ID,
+			AttendancePeriodID: attPeriod.ID,
+			Date:               time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC),
+			CheckInTime:        time.Date(2024, time.March, day, 9, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, testDB.Create(&attRec).Error)
+	}
+	reimbursement := models.ReimbursementRequest{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Description: "Taxi", Amount: models.MustParseMoney("100"), Status: "approved"}
+	require.NoError(t, testDB.Create(&reimbursement).Error)
+
+	payload := fiber.Map{"attendance_period_id": attPeriod.ID.String()}
+	resp, err := makeRequest("POST", "/api/v1/admin/payroll/preview", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var body map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	assert.Equal(t, "success", body["status"])
+	data := body["data"].(map[string]interface{})
+	employees := data["employees"].([]interface{})
+	require.Len(t, employees, 1)
+	entry := employees[0].(map[string]interface{})
+	assert.Equal(t, "2000.00", entry["prorated_salary"]) // 5000 / 5 days * 2 attended
+	assert.Equal(t, "100.00", entry["reimbursements_total"])
+	assert.Equal(t, "80.00", entry["social_security_deduction"]) // 4% BPJS on the 2000 earned
+	assert.Equal(t, "2020.00", entry["take_home_pay"])
+
+	// Nothing should have been written
+	var payslipCount int64
+	testDB.Model(&models.Payslip{}).Where("attendance_period_id = ?", attPeriod.ID).Count(&payslipCount)
+	assert.Zero(t, payslipCount)
+
+	var reloadedPeriod models.AttendancePeriod
+	require.NoError(t, testDB.First(&reloadedPeriod, "id = ?", attPeriod.ID).Error)
+	assert.Nil(t, reloadedPeriod.PayrollRunAt)
+
+	var reloadedReimbursement models.ReimbursementRequest
+	require.NoError(t, testDB.First(&reloadedReimbursement, "id = ?", reimbursement.ID).Error)
+	assert.Equal(t, "approved", reloadedReimbursement.Status)
+}
+
+func TestVoidPayroll_AllowsRerun(t *testing.T) {
+	adminToken := getAdminToken(t, "voidadmin", "voidpass")
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	emp := models.Employee{Username: "voidemp", Password: "pw", Salary: models.MustParseMoney("5000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	reimbursement := models.ReimbursementRequest{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Description: "Taxi", Amount: models.MustParseMoney("100"), Status: "approved"}
+	require.NoError(t, testDB.Create(&reimbursement).Error)
+
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	// Reason is mandatory
+	payload := fiber.Map{"attendance_period_id": attPeriod.ID.String()}
+	resp, err := makeRequest("POST", "/api/v1/admin/payroll/void", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	voidPayload := fiber.Map{"attendance_period_id": attPeriod.ID.String(), "reason": "Wrong attendance data"}
+	resp, err = makeRequest("POST", "/api/v1/admin/payroll/void", createJSONBody(voidPayload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var reloadedPeriod models.AttendancePeriod
+	require.NoError(t, testDB.First(&reloadedPeriod, "id = ?", attPeriod.ID).Error)
+	assert.Nil(t, reloadedPeriod.PayrollRunAt)
+
+	var reloadedReimbursement models.ReimbursementRequest
+	require.NoError(t, testDB.First(&reloadedReimbursement, "id = ?", reimbursement.ID).Error)
+	assert.Equal(t, "approved", reloadedReimbursement.Status)
+
+	var voidedPayslip models.Payslip
+	require.NoError(t, testDB.Where("employee_id = ? AND attendance_period_id = ?", emp.ID, attPeriod.ID).First(&voidedPayslip).Error)
+	assert.NotNil(t, voidedPayslip.VoidedAt)
+
+	// Re-run produces a fresh payslip and keeps the voided one
+	run = runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	assert.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	var payslipCount, activeCount int64
+	testDB.Model(&models.Payslip{}).Where("attendance_period_id = ?", attPeriod.ID).Count(&payslipCount)
+	testDB.Model(&models.Payslip{}).Where("attendance_period_id = ? AND voided_at IS NULL", attPeriod.ID).Count(&activeCount)
+	assert.Equal(t, int64(2), payslipCount)
+	assert.Equal(t, int64(1), activeCount)
+}
+
+func TestVoidPayroll_PartialRun(t *testing.T) {
+	adminToken := getAdminToken(t, "partialvoidadmin", "adminpass")
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC), // Friday
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	weekend := models.WorkSchedule{Name: "Weekend", WorkingDays: utils.NewWorkWeek(time.Saturday, time.Sunday), HoursPerDay: 8}
+	require.NoError(t, testDB.Create(&weekend).Error)
+	paidEmp := models.Employee{Username: "partialemp1", Password: "pw", Salary: models.MustParseMoney("5000")}
+	weekendEmp := models.Employee{Username: "partialemp2", Password: "pw", Salary: models.MustParseMoney("5000"), WorkScheduleID: &weekend.ID}
+	require.NoError(t, testDB.Create(&paidEmp).Error)
+	require.NoError(t, testDB.Create(&weekendEmp).Error)
+	overtime := models.OvertimeRecord{EmployeeID: paidEmp.ID, Date: attPeriod.StartDate, Minutes: 60, RateMultiplier: 2.0, Status: models.OvertimePending}
+	require.NoError(t, testDB.Create(&overtime).Error)
+
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	require.Equal(t, models.PayrollRunFailed, run["Status"])
+	require.EqualValues(t, 1, run["PayslipsGenerated"])
+
+	// The period is still open, but the paid employee's overtime would never be paid
+	resp, err := makeRequest("POST", "/api/v1/admin/overtime/"+overtime.ID.String()+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/payroll/void", createJSONBody(fiber.Map{"attendance_period_id": attPeriod.ID.String(), "reason": "Fix schedules first"}), adminToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var body struct {
+		Data struct {
+			PayslipsVoided int `json:"payslips_voided"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.Data.PayslipsVoided)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/overtime/"+overtime.ID.String()+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code, "Once voided, the overtime is paid by the next run")
+
+	resp, err = makeRequest("POST", "/api/v1/admin/payroll/void", createJSONBody(fiber.Map{"attendance_period_id": attPeriod.ID.String(), "reason": "Again"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code, "Nothing left to void")
 }
-*/
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOut_RecordsWorkedMinutes(t *testing.T) {
+	adminToken := getAdminToken(t, "checkoutadmin", "adminpass")
+	empToken := getEmployeeToken(t, "checkoutemp", "emppass", "50000")
+
+	today := time.Now()
+	if today.Weekday() == time.Saturday || today.Weekday() == time.Sunday {
+		t.Skip("Skipping TestCheckOut_RecordsWorkedMinutes on weekend.")
+	}
+	periodPayload := fiber.Map{
+		"start_date": today.Format("2006-01-02"),
+		"end_date":   today.AddDate(0, 0, 5).Format("2006-01-02"),
+	}
+	makeRequest("POST", "/api/v1/admin/attendance-periods", createJSONBody(periodPayload), adminToken)
+
+	// Checking out before checking in fails
+	resp, err := makeRequest("POST", "/api/v1/employee/attendance/check-out", nil, empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/employee/attendance", nil, empToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/employee/attendance/check-out", nil, empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var body map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, models.CheckOutRecorded, data["CheckOutStatus"])
+	assert.NotNil(t, data["CheckOutTime"])
+	assert.NotNil(t, data["WorkedMinutes"])
+
+	resp, err = makeRequest("POST", "/api/v1/employee/attendance/check-out", nil, empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "check_out").Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestSubmitOvertime_ValidatedAgainstWorkedHours(t *testing.T) {
+	getAdminToken(t, "overtimeadmin", "adminpass")
+	empToken := getEmployeeToken(t, "overtimeemp", "emppass", "50000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "overtimeemp").Error)
+
+	now := time.Now()
+	day := func(offset int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, time.UTC)
+	}
+	attPeriod := models.AttendancePeriod{StartDate: day(7), EndDate: day(0)}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+
+	// Three days ago: 10 hours worked; two days ago: 8.5 hours; yesterday: no check-out
+	checkOuts := map[int]time.Duration{3: 10 * time.Hour, 2: 8*time.Hour + 30*time.Minute, 1: 0}
+	for offset, worked := range checkOuts {
+		checkIn := day(offset).Add(9 * time.Hour)
+		attRec := models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: day(offset), CheckInTime: checkIn, CheckOutStatus: models.CheckOutOpen}
+		if worked > 0 {
+			services.ApplyCheckOut(&attRec, checkIn.Add(worked), models.CheckOutRecorded)
+		}
+		require.NoError(t, testDB.Create(&attRec).Error)
+	}
+
+	testCases := []struct {
+		name           string
+		offset         int
+		hours          int
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{name: "Covered by worked hours", offset: 3, hours: 2, expectedStatus: http.StatusCreated},
+		{name: "More than worked", offset: 2, hours: 1, expectedStatus: http.StatusBadRequest, expectedMsg: "Overtime exceeds recorded hours"},
+		{name: "No check-out", offset: 1, hours: 1, expectedStatus: http.StatusBadRequest, expectedMsg: "without a recorded check-out"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := fiber.Map{"date": day(tc.offset).Format("2006-01-02"), "hours": tc.hours}
+			resp, err := makeRequest("POST", "/api/v1/employee/overtime", createJSONBody(payload), empToken)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedMsg != "" {
+				var body map[string]interface{}
+				json.Unmarshal(resp.Body.Bytes(), &body)
+				assert.Contains(t, body["message"], tc.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestMissingCheckOutPolicy(t *testing.T) {
+	adminToken := getAdminToken(t, "closeradmin", "adminpass")
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	emp := models.Employee{Username: "closeremp", Password: "pw", Salary: models.MustParseMoney("4000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	newOpenRecord := func(day int) models.AttendanceRecord {
+		attRec := models.AttendanceRecord{
+			EmployeeID:         emp.ID,
+			AttendancePeriodID: attPeriod.ID,
+			Date:               time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC),
+			CheckInTime:        time.Date(2024, time.March, day, 9, 0, 0, 0, time.UTC),
+			CheckOutStatus:     models.CheckOutOpen,
+		}
+		require.NoError(t, testDB.Create(&attRec).Error)
+		return attRec
+	}
+	flaggedRec := newOpenRecord(4)
+	autoClosedRec := newOpenRecord(5)
+
+	// Flag policy leaves the check-out empty for an admin to resolve
+	closed, err := services.CloseMissingCheckOuts(testDB, services.MissingCheckOutFlag, time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	require.NoError(t, testDB.First(&flaggedRec, "id = ?", flaggedRec.ID).Error)
+	assert.Equal(t, models.CheckOutFlagged, flaggedRec.CheckOutStatus)
+	assert.Nil(t, flaggedRec.CheckOutTime)
+
+	// Auto-close ends the day after the scheduled hours
+	closed, err = services.CloseMissingCheckOuts(testDB, services.MissingCheckOutAutoClose, time.Date(2024, time.March, 6, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	require.NoError(t, testDB.First(&autoClosedRec, "id = ?", autoClosedRec.ID).Error)
+	assert.Equal(t, models.CheckOutAutoClosed, autoClosedRec.CheckOutStatus)
+	require.NotNil(t, autoClosedRec.WorkedMinutes)
+	assert.Equal(t, 8*60, *autoClosedRec.WorkedMinutes)
+
+	// Admin resolves the flagged record
+	resp, err := makeRequest("GET", "/api/v1/admin/attendance", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var listBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &listBody)
+	assert.Len(t, listBody["data"], 1)
+
+	payload := fiber.Map{"check_out_time": "2024-03-04T18:30:00Z"}
+	resp, err = makeRequest("POST", "/api/v1/admin/attendance/"+flaggedRec.ID.String()+"/check-out", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, testDB.First(&flaggedRec, "id = ?", flaggedRec.ID).Error)
+	assert.Equal(t, models.CheckOutRecorded, flaggedRec.CheckOutStatus)
+	require.NotNil(t, flaggedRec.WorkedMinutes)
+	assert.Equal(t, 9*60+30, *flaggedRec.WorkedMinutes)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/attendance/"+autoClosedRec.ID.String()+"/check-out", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttendanceCorrection_ApprovalCreatesRecord(t *testing.T) {
+	adminToken := getAdminToken(t, "correctionadmin", "adminpass")
+	empToken := getEmployeeToken(t, "correctionemp", "emppass", "5000")
+	var admin models.Admin
+	require.NoError(t, testDB.First(&admin, "username = ?", "correctionadmin").Error)
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "correctionemp").Error)
+
+	// The most recent weekday before today, inside an open period
+	day := time.Now().AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	attPeriod := models.AttendancePeriod{StartDate: day.AddDate(0, 0, -7), EndDate: day.AddDate(0, 0, 7)}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+
+	submit := func(payload fiber.Map) (int, map[string]interface{}) {
+		resp, err := makeRequest("POST", "/api/v1/employee/attendance-corrections", createJSONBody(payload), empToken)
+		require.NoError(t, err)
+		var body map[string]interface{}
+		json.Unmarshal(resp.Body.Bytes(), &body)
+		return resp.Code, body
+	}
+
+	code, _ := submit(fiber.Map{"date": day.Format("2006-01-02"), "check_in_time": "09:00"})
+	assert.Equal(t, http.StatusBadRequest, code, "A reason is required")
+
+	code, _ = submit(fiber.Map{"date": time.Now().Format("2006-01-02"), "check_in_time": "09:00", "reason": "Forgot"})
+	assert.Equal(t, http.StatusBadRequest, code, "Today is not a correction")
+
+	code, _ = submit(fiber.Map{"date": day.Format("2006-01-02"), "check_in_time": "17:00", "check_out_time": "09:00", "reason": "Forgot"})
+	assert.Equal(t, http.StatusBadRequest, code, "Check-out must follow check-in")
+
+	code, body := submit(fiber.Map{"date": day.Format("2006-01-02"), "check_in_time": "09:00", "check_out_time": "17:30", "reason": "Badge reader was down"})
+	require.Equal(t, http.StatusCreated, code)
+	correctionID := body["data"].(map[string]interface{})["id"].(string)
+
+	code, _ = submit(fiber.Map{"date": day.Format("2006-01-02"), "check_in_time": "09:15", "reason": "Again"})
+	assert.Equal(t, http.StatusConflict, code, "Only one pending correction per day")
+
+	resp, err := makeRequest("POST", "/api/v1/admin/attendance-corrections/"+correctionID+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var record models.AttendanceRecord
+	require.NoError(t, testDB.First(&record, "employee_id = ? AND date = ?", emp.ID, day).Error)
+	require.NotNil(t, record.CreatedBy)
+	assert.Equal(t, admin.ID, *record.CreatedBy)
+	assert.Equal(t, models.CheckOutRecorded, record.CheckOutStatus)
+	require.NotNil(t, record.WorkedMinutes)
+	assert.Equal(t, 510, *record.WorkedMinutes)
+
+	var correction models.AttendanceCorrection
+	require.NoError(t, testDB.First(&correction, "id = ?", correctionID).Error)
+	assert.Equal(t, models.CorrectionApproved, correction.Status)
+	require.NotNil(t, correction.AttendanceRecordID)
+	assert.Equal(t, record.ID, *correction.AttendanceRecordID)
+
+	// The day now has attendance, so a further correction is refused
+	code, _ = submit(fiber.Map{"date": day.Format("2006-01-02"), "check_in_time": "09:00", "reason": "Duplicate"})
+	assert.Equal(t, http.StatusConflict, code)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action IN ?", []string{"submit_attendance_correction", "approve_attendance_correction", "create_attendance_from_correction"}).Count(&auditCount)
+	assert.Equal(t, int64(3), auditCount)
+
+	// The new record belongs to the employee, not the admin who approved it
+	var recordAudit models.AuditLog
+	require.NoError(t, testDB.First(&recordAudit, "action = ?", "create_attendance_from_correction").Error)
+	assert.Equal(t, emp.ID, recordAudit.UserID)
+	assert.Equal(t, "employee", recordAudit.UserType)
+}
+
+func TestRejectAttendanceCorrection_RequiresReason(t *testing.T) {
+	adminToken := getAdminToken(t, "correctionadmin2", "adminpass")
+	emp := models.Employee{Username: "correctionemp2", Password: "pw", Salary: models.MustParseMoney("3000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	day := time.Now().AddDate(0, 0, -3)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	attPeriod := models.AttendancePeriod{StartDate: day, EndDate: day}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	correction := models.AttendanceCorrection{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: day, CheckInTime: day.Add(9 * time.Hour), Reason: "Sick badge", Status: models.CorrectionPending}
+	require.NoError(t, testDB.Create(&correction).Error)
+
+	resp, err := makeRequest("POST", "/api/v1/admin/attendance-corrections/"+correction.ID.String()+"/reject", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/attendance-corrections/"+correction.ID.String()+"/reject", createJSONBody(fiber.Map{"reason": "No evidence"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, testDB.First(&correction, "id = ?", correction.ID).Error)
+	assert.Equal(t, models.CorrectionRejected, correction.Status)
+
+	var records int64
+	testDB.Model(&models.AttendanceRecord{}).Where("employee_id = ?", emp.ID).Count(&records)
+	assert.Equal(t, int64(0), records, "Rejection does not create attendance")
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type attlogReport struct {
+	Created      int `json:"created"`
+	CheckedOut   int `json:"checked_out"`
+	Duplicates   int `json:"duplicates"`
+	Skipped      int `json:"skipped"`
+	UnmappedPINs []struct {
+		PIN     string `json:"pin"`
+		Punches int    `json:"punches"`
+	} `json:"unmapped_pins"`
+}
+
+func TestImportAttlog(t *testing.T) {
+	adminToken := getAdminToken(t, "attlogadmin", "adminpass")
+	emp := models.Employee{Username: "attlogemp", Password: "pw", Salary: models.MustParseMoney("5000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+
+	monday := time.Date(time.Now().Year()+1, time.March, 1, 0, 0, 0, 0, time.Local)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	day := func(offset int) string { return monday.AddDate(0, 0, offset).Format("2006-01-02") }
+	attPeriod := models.AttendancePeriod{StartDate: monday, EndDate: monday.AddDate(0, 0, 6)}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+
+	resp, err := makeRequest("POST", "/api/v1/admin/device-pins", createJSONBody(fiber.Map{"pin": "101", "employee_id": emp.ID.String()}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	resp, err = makeRequest("POST", "/api/v1/admin/device-pins", createJSONBody(fiber.Map{"pin": "101", "employee_id": emp.ID.String()}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code, "A PIN maps to one employee")
+
+	importFile := func(content string) attlogReport {
+		resp, err := makeUploadRequest("/api/v1/admin/attendance/import", "file", "attlog.dat", []byte(content), adminToken)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var body struct {
+			Data attlogReport `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Data
+	}
+
+	first := fmt.Sprintf("101\t%[1]s 08:58:00\t1\n101\t%[1]s 17:30:00\t1\n101\t%[2]s 09:00:00\t1\n999\t%[1]s 08:00:00\t1\n101\t%[3]s 10:00:00\t1\n",
+		day(0), day(1), day(5))
+	report := importFile(first)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Skipped, "Saturday is not a working day")
+	require.Len(t, report.UnmappedPINs, 1)
+	assert.Equal(t, "999", report.UnmappedPINs[0].PIN)
+
+	var monRecord models.AttendanceRecord
+	require.NoError(t, testDB.First(&monRecord, "employee_id = ? AND date = ?", emp.ID, day(0)).Error)
+	assert.Equal(t, models.CheckOutRecorded, monRecord.CheckOutStatus)
+	require.NotNil(t, monRecord.WorkedMinutes)
+	assert.Equal(t, 512, *monRecord.WorkedMinutes)
+
+	// Importing again with Tuesday's check-out: Monday is a duplicate, Tuesday's open record is checked out
+	second := first + fmt.Sprintf("101\t%s 17:00:00\t1\n", day(1))
+	report = importFile(second)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 1, report.CheckedOut)
+	assert.Equal(t, 1, report.Duplicates)
+
+	var count int64
+	testDB.Model(&models.AttendanceRecord{}).Where("employee_id = ?", emp.ID).Count(&count)
+	assert.Equal(t, int64(2), count)
+
+	report = importFile(second)
+	assert.Equal(t, 0, report.Created+report.CheckedOut)
+	assert.Equal(t, 2, report.Duplicates)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "import_attlog").Count(&auditCount)
+	assert.Equal(t, int64(3), auditCount)
+}
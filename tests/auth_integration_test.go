@@ -81,7 +81,7 @@ func TestEmployeeLogin_Success(t *testing.T) {
 	employeeUser := models.Employee{
 		Username: "testemployee",
 		Password: hashedPassword,
-		Salary: 50000,
+		Salary: models.MustParseMoney("50000"),
 	}
 	err := testDB.Create(&employeeUser).Error
 	require.NoError(t, err, "Failed to seed employee user for login test")
@@ -129,7 +129,7 @@ func TestProtectedAdminRoute_AccessControl(t *testing.T) {
 
 	admin := models.Admin{Username: "prot_admin", Password: adminHashedPass}
 	testDB.Create(&admin)
-	employee := models.Employee{Username: "prot_emp", Password: empHashedPass, Salary: 1000}
+	employee := models.Employee{Username: "prot_emp", Password: empHashedPass, Salary: models.MustParseMoney("1000")}
 	testDB.Create(&employee)
 
 	// 1. No token
@@ -14,11 +14,11 @@ import (
 )
 
 // Helper to get a valid employee token
-func getEmployeeToken(t *testing.T, username, password string, salary float64) string {
+func getEmployeeToken(t *testing.T, username, password, salary string) string {
 	// No need to clearTestData here as it's usually called by the test case itself
 	// or a higher-level setup for a group of tests.
 	hashedPassword, _ := utils.HashPassword(password)
-	employee := models.Employee{Username: username, Password: hashedPassword, Salary: salary}
+	employee := models.Employee{Username: username, Password: hashedPassword, Salary: models.MustParseMoney(salary)}
 	err := testDB.Create(&employee).Error
 	require.NoError(t, err, "Failed to create employee for token generation")
 
@@ -37,7 +37,7 @@ func getEmployeeToken(t *testing.T, username, password string, salary float64) s
 
 func TestSubmitAttendance_Success(t *testing.T) {
 	clearTestData()
-	empToken := getEmployeeToken(t, "attemp", "attpass", 50000)
+	empToken := getEmployeeToken(t, "attemp", "attpass", "50000")
 
 	// Get admin token to create an attendance period
 	// In a real scenario, admin might have a separate test setup or use fixed IDs
@@ -84,7 +84,7 @@ func TestSubmitAttendance_Success(t *testing.T) {
 
 func TestSubmitAttendance_AlreadySubmitted(t *testing.T) {
 	clearTestData()
-	empToken := getEmployeeToken(t, "attemp2", "attpass2", 50000)
+	empToken := getEmployeeToken(t, "attemp2", "attpass2", "50000")
 	adminToken := getAdminToken(t, "att_admin2", "adminpass2")
 
 	today := time.Now()
@@ -116,7 +116,7 @@ func TestSubmitAttendance_AlreadySubmitted(t *testing.T) {
 
 func TestSubmitAttendance_NoActivePeriod(t *testing.T) {
 	clearTestData()
-	empToken := getEmployeeToken(t, "attemp3", "attpass3", 50000)
+	empToken := getEmployeeToken(t, "attemp3", "attpass3", "50000")
 	// No attendance period created for today
 
 	today := time.Now()
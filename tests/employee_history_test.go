@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmployeeHistory(t *testing.T) {
+	empToken := getEmployeeToken(t, "historyemp", "emppass", "4000")
+	otherToken := getEmployeeToken(t, "historyother", "emppass", "4000")
+	var emp, other models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "historyemp").Error)
+	require.NoError(t, testDB.First(&other, "username = ?", "historyother").Error)
+
+	march := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+	}
+	april := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.April, 5, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&march).Error)
+	require.NoError(t, testDB.Create(&april).Error)
+	require.NoError(t, testDB.Create(&models.Holiday{Date: time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC), Name: "Founders Day"}).Error)
+	for _, day := range []int{4, 5} {
+		date := time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, testDB.Create(&models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: march.ID, Date: date, CheckInTime: date.Add(9 * time.Hour)}).Error)
+	}
+	for _, p := range []models.AttendancePeriod{march, april} {
+		require.NoError(t, testDB.Create(&models.Payslip{EmployeeID: emp.ID, AttendancePeriodID: p.ID, BaseSalary: models.MustParseMoney("4000"), ProratedSalary: models.MustParseMoney("4000"), TakeHomePay: models.MustParseMoney("4000")}).Error)
+	}
+	voidedAt := time.Now()
+	require.NoError(t, testDB.Create(&models.Payslip{EmployeeID: emp.ID, AttendancePeriodID: march.ID, BaseSalary: models.MustParseMoney("4000"), ProratedSalary: models.MustParseMoney("3000"), TakeHomePay: models.MustParseMoney("3000"), VoidedAt: &voidedAt}).Error)
+	require.NoError(t, testDB.Create(&models.Payslip{EmployeeID: other.ID, AttendancePeriodID: march.ID, BaseSalary: models.MustParseMoney("4000"), ProratedSalary: models.MustParseMoney("4000"), TakeHomePay: models.MustParseMoney("4000")}).Error)
+
+	type listBody struct {
+		Data       []map[string]interface{} `json:"data"`
+		Pagination struct {
+			TotalItems int64 `json:"total_items"`
+		} `json:"pagination"`
+	}
+	list := func(url, token string) listBody {
+		resp := getWithToken(t, url, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode, url)
+		var body listBody
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body
+	}
+
+	// Payslips: own, not voided unless asked, most recent period first
+	payslips := list("/api/v1/employee/payslips", empToken)
+	require.Len(t, payslips.Data, 2)
+	assert.Equal(t, april.ID.String(), payslips.Data[0]["period_id"])
+	assert.Len(t, list("/api/v1/employee/payslips?include_voided=true", empToken).Data, 3)
+	assert.Len(t, list("/api/v1/employee/payslips?from=2024-03-11", empToken).Data, 1)
+	assert.Len(t, list("/api/v1/employee/payslips?year=2023", empToken).Data, 0)
+	paged := list("/api/v1/employee/payslips?page_size=1", empToken)
+	assert.Len(t, paged.Data, 1)
+	assert.Equal(t, int64(2), paged.Pagination.TotalItems)
+	assert.Equal(t, http.StatusBadRequest, getWithToken(t, "/api/v1/employee/payslips?year=abc", empToken).StatusCode)
+
+	// Attendance calendar of the March period
+	calendars := list("/api/v1/employee/attendance?period_id="+march.ID.String(), empToken)
+	require.Len(t, calendars.Data, 1)
+	days := calendars.Data[0]["days"].([]interface{})
+	require.Len(t, days, 7)
+	statuses := make([]string, 0, len(days))
+	for _, d := range days {
+		statuses = append(statuses, d.(map[string]interface{})["status"].(string))
+	}
+	assert.Equal(t, []string{"present", "present", "absent", "absent", "holiday", "rest_day", "rest_day"}, statuses)
+
+	// Overtime submissions
+	require.NoError(t, testDB.Create(&models.OvertimeRecord{EmployeeID: emp.ID, Date: march.StartDate, Minutes: 60, RateMultiplier: 2, Status: models.OvertimeApproved}).Error)
+	require.NoError(t, testDB.Create(&models.OvertimeRecord{EmployeeID: emp.ID, Date: april.StartDate, Minutes: 90, RateMultiplier: 2, Status: models.OvertimePending}).Error)
+	require.NoError(t, testDB.Create(&models.OvertimeRecord{EmployeeID: other.ID, Date: april.StartDate, Minutes: 30, RateMultiplier: 2, Status: models.OvertimePending}).Error)
+	assert.Len(t, list("/api/v1/employee/overtime", empToken).Data, 2)
+	assert.Len(t, list("/api/v1/employee/overtime?status=pending", empToken).Data, 1)
+	assert.Len(t, list("/api/v1/employee/overtime?period_id="+march.ID.String(), empToken).Data, 1)
+
+	// Reimbursements: listed with their status and cancellable while pending, by their owner only
+	pending := models.ReimbursementRequest{EmployeeID: emp.ID, Description: "Taxi", Amount: models.MustParseMoney("50"), Status: models.ReimbursementPending}
+	approved := models.ReimbursementRequest{EmployeeID: emp.ID, Description: "Hotel", Amount: models.MustParseMoney("300"), Status: models.ReimbursementApproved}
+	othersPending := models.ReimbursementRequest{EmployeeID: other.ID, Description: "Lunch", Amount: models.MustParseMoney("20"), Status: models.ReimbursementPending}
+	for _, rr := range []*models.ReimbursementRequest{&pending, &approved, &othersPending} {
+		require.NoError(t, testDB.Create(rr).Error)
+	}
+	assert.Len(t, list("/api/v1/employee/reimbursements", empToken).Data, 2)
+
+	resp := postJSON(t, "/api/v1/employee/reimbursements/"+othersPending.ID.String()+"/cancel", nil, empToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Another employee's request")
+	resp = postJSON(t, "/api/v1/employee/reimbursements/"+approved.ID.String()+"/cancel", nil, empToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp = postJSON(t, "/api/v1/employee/reimbursements/"+pending.ID.String()+"/cancel", nil, empToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = postJSON(t, "/api/v1/employee/reimbursements/"+pending.ID.String()+"/cancel", nil, empToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "Already cancelled")
+
+	cancelled := list("/api/v1/employee/reimbursements?status=cancelled", empToken)
+	require.Len(t, cancelled.Data, 1)
+	assert.Equal(t, pending.ID.String(), cancelled.Data[0]["id"])
+	assert.Len(t, list("/api/v1/employee/reimbursements?status=pending", otherToken).Data, 1)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "cancel_reimbursement").Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
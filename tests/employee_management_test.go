@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/utils"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmployeeCRUD(t *testing.T) {
+	adminToken := getAdminToken(t, "hradmin", "hrpass")
+
+	// Create
+	payload := fiber.Map{"username": "new.hire", "password": "welcome123", "salary": 7500000}
+	resp, err := makeRequest("POST", "/api/v1/admin/employees", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var body map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	data := body["data"].(map[string]interface{})
+	employeeID := data["id"].(string)
+	assert.Equal(t, true, data["is_active"])
+	assert.Equal(t, "7500000.00", data["salary"], "Money is returned as a string with two decimals")
+	assert.NotContains(t, data, "Password")
+
+	var created models.Employee
+	require.NoError(t, testDB.First(&created, "id = ?", employeeID).Error)
+	assert.True(t, utils.CheckPasswordHash("welcome123", created.Password), "Password should be stored hashed")
+
+	// Duplicate username
+	resp, err = makeRequest("POST", "/api/v1/admin/employees", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	// Invalid salary
+	resp, err = makeRequest("PUT", "/api/v1/admin/employees/"+employeeID, createJSONBody(fiber.Map{"salary": -1}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// Salary change is audited
+	resp, err = makeRequest("PUT", "/api/v1/admin/employees/"+employeeID, createJSONBody(fiber.Map{"salary": "8000000.50"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ? AND target_resource_id = ?", "update_employee_salary", employeeID).Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+	require.NoError(t, testDB.First(&created, "id = ?", employeeID).Error)
+	assert.Equal(t, "8000000.50", created.Salary.String())
+
+	// Deactivate blocks login and hides from the default list
+	resp, err = makeRequest("POST", "/api/v1/admin/employees/"+employeeID+"/deactivate", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/employee/login", createJSONBody(fiber.Map{"username": "new.hire", "password": "welcome123"}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp, err = makeRequest("GET", "/api/v1/admin/employees?search=new.hire", nil, adminToken)
+	require.NoError(t, err)
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	assert.Len(t, body["data"], 0)
+
+	resp, err = makeRequest("GET", "/api/v1/admin/employees?search=new.hire&status=inactive", nil, adminToken)
+	require.NoError(t, err)
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	assert.Len(t, body["data"], 1)
+}
+
+func TestImportEmployees(t *testing.T) {
+	adminToken := getAdminToken(t, "importadmin", "importpass")
+	require.NoError(t, testDB.Create(&models.Employee{Username: "existing.user", Password: "pw", Salary: models.MustParseMoney("1000")}).Error)
+
+	invalidCSV := []byte("username,salary,password\nimport.one,5000000,welcome123\nexisting.user,4000000,welcome123\nimport.one,3000000,\n")
+	resp, err := makeUploadRequest("/api/v1/admin/employees/import", "file", "employees.csv", invalidCSV, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var body struct {
+		Data struct {
+			InvalidRows int `json:"invalid_rows"`
+			Rows        []struct {
+				Line   int      `json:"line"`
+				Errors []string `json:"errors"`
+			} `json:"rows"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 2, body.Data.InvalidRows)
+
+	var count int64
+	testDB.Model(&models.Employee{}).Where("username = ?", "import.one").Count(&count)
+	assert.Equal(t, int64(0), count, "nothing is created when any row is invalid")
+
+	validCSV := []byte("username,salary,password\nimport.one,5000000,welcome123\nimport.two,4000000,\n")
+	resp, err = makeUploadRequest("/api/v1/admin/employees/import?dry_run=true", "file", "employees.csv", validCSV, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	testDB.Model(&models.Employee{}).Where("username IN ?", []string{"import.one", "import.two"}).Count(&count)
+	assert.Equal(t, int64(0), count, "dry run does not create employees")
+
+	resp, err = makeUploadRequest("/api/v1/admin/employees/import", "file", "employees.csv", validCSV, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		Data struct {
+			CreatedRows int `json:"created_rows"`
+			Rows        []struct {
+				Username          string `json:"username"`
+				TemporaryPassword string `json:"temporary_password"`
+			} `json:"rows"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.Equal(t, 2, created.Data.CreatedRows)
+	require.Len(t, created.Data.Rows, 2)
+	assert.Empty(t, created.Data.Rows[0].TemporaryPassword)
+	require.NotEmpty(t, created.Data.Rows[1].TemporaryPassword)
+
+	var invited models.Employee
+	require.NoError(t, testDB.First(&invited, "username = ?", "import.two").Error)
+	assert.True(t, utils.CheckPasswordHash(created.Data.Rows[1].TemporaryPassword, invited.Password))
+	assert.NotNil(t, invited.CreatedBy)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "import_employees").Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolidays_ReduceWorkingDays(t *testing.T) {
+	adminToken := getAdminToken(t, "holidayadmin", "holidaypass")
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC), // Friday
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	emp := models.Employee{Username: "holidayemp", Password: "pw", Salary: models.MustParseMoney("4000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	for _, day := range []int{4, 5, 6, 7} {
+		attRec := models.AttendanceRecord{
+			EmployeeID:         emp.ID,
+			AttendancePeriodID: attPeriod.ID,
+			Date:               time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC),
+			CheckInTime:        time.Date(2024, time.March, day, 9, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, testDB.Create(&attRec).Error)
+	}
+
+	// Friday is a holiday, so attending Monday to Thursday is full attendance
+	resp, err := makeRequest("POST", "/api/v1/admin/holidays", createJSONBody(fiber.Map{"date": "2024-03-08", "name": "Founders Day"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/holidays", createJSONBody(fiber.Map{"date": "2024-03-08", "name": "Duplicate"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/payroll/preview", createJSONBody(fiber.Map{"attendance_period_id": attPeriod.ID.String()}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var body map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	data := body["data"].(map[string]interface{})
+	assert.EqualValues(t, 4, data["total_working_days"])
+	entry := data["employees"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "4000.00", entry["prorated_salary"])
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "create_holiday").Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestImportHolidays_IsIdempotent(t *testing.T) {
+	adminToken := getAdminToken(t, "holidayimport", "holidaypass")
+
+	csvData := []byte("date,name\n2024-01-01,New Year's Day\n2024-08-17,Independence Day\n")
+	for i := 0; i < 2; i++ {
+		resp, err := makeUploadRequest("/api/v1/admin/holidays/import", "file", "holidays.csv", csvData, adminToken)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	var count int64
+	testDB.Model(&models.Holiday{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+
+	resp, err := makeUploadRequest("/api/v1/admin/holidays/import", "file", "holidays.xlsx", csvData, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
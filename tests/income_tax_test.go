@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// putJSON sends an authorised PUT request with a JSON body to the test app
+func putJSON(t *testing.T, url string, body interface{}, token string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest("PUT", url, createJSONBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	return resp
+}
+
+// attendFullMonth creates a period for a month with the employee checked in on every weekday
+func attendFullMonth(t *testing.T, employee models.Employee, year int, month time.Month) models.AttendancePeriod {
+	t.Helper()
+	period := models.AttendancePeriod{
+		StartDate: time.Date(year, month, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&period).Error)
+	for day := period.StartDate; !day.After(period.EndDate); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		record := models.AttendanceRecord{EmployeeID: employee.ID, AttendancePeriodID: period.ID, Date: day, CheckInTime: day.Add(9 * time.Hour)}
+		require.NoError(t, testDB.Create(&record).Error)
+	}
+	return period
+}
+
+type taxedPayslip struct {
+	Data struct {
+		TaxableIncome  string `json:"taxable_income"`
+		IncomeTax      string `json:"income_tax"`
+		TakeHomePay    string `json:"take_home_pay"`
+		TaxCalculation struct {
+			TableVersion   string `json:"table_version"`
+			PTKPStatus     string `json:"ptkp_status"`
+			YearEnd        bool   `json:"year_end"`
+			PeriodsPerYear string `json:"periods_per_year"`
+			WithheldBefore string `json:"withheld_before"`
+		} `json:"tax_calculation"`
+	} `json:"data"`
+}
+
+func TestIncomeTax_MonthlyWithholdingAndYearEndTrueUp(t *testing.T) {
+	adminToken := getAdminToken(t, "incometaxadmin", "adminpass")
+	empToken := getEmployeeToken(t, "incometaxemp", "emppass", "10000000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "incometaxemp").Error)
+
+	profileURL := "/api/v1/admin/employees/" + emp.ID.String() + "/tax-profile"
+	resp := putJSON(t, profileURL, fiber.Map{"tax_id": "09.254.294.3-407.00", "marital_status": "single"}, adminToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "An NPWP has 15 digits")
+	resp = putJSON(t, profileURL, fiber.Map{"tax_id": "09.254.294.3-407.000", "marital_status": "widowed"}, adminToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = putJSON(t, profileURL, fiber.Map{"tax_id": "09.254.294.3-407.000", "marital_status": "single"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = getWithToken(t, "/api/v1/employee/tax-profile", empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var profile struct {
+		Data struct {
+			TaxID      string `json:"tax_id"`
+			PTKPStatus string `json:"ptkp_status"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&profile))
+	assert.Equal(t, "092542943407000", profile.Data.TaxID)
+	assert.Equal(t, "TK/0", profile.Data.PTKPStatus)
+
+	// November: a twelfth of the tax on the annualised salary and taxable employer BPJS, less the deductible JHT and JP
+	november := attendFullMonth(t, emp, 2024, time.November)
+	run := runPayrollAndWait(t, adminToken, november.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+	resp = getWithToken(t, "/api/v1/employee/payslip?period_id="+november.ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var payslip taxedPayslip
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.Equal(t, "10454000.00", payslip.Data.TaxableIncome)
+	assert.Equal(t, "273100.00", payslip.Data.IncomeTax)
+	assert.Equal(t, "9326900.00", payslip.Data.TakeHomePay)
+	assert.Equal(t, "2022-01", payslip.Data.TaxCalculation.TableVersion)
+	assert.False(t, payslip.Data.TaxCalculation.YearEnd)
+
+	// December settles the year: 20,908,000 earned since November is below PTKP, so November's tax is refunded
+	december := attendFullMonth(t, emp, 2024, time.December)
+	run = runPayrollAndWait(t, adminToken, december.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+	resp = getWithToken(t, "/api/v1/employee/payslip?period_id="+december.ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	payslip = taxedPayslip{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.Equal(t, "-273100.00", payslip.Data.IncomeTax)
+	assert.Equal(t, "9873100.00", payslip.Data.TakeHomePay)
+	assert.True(t, payslip.Data.TaxCalculation.YearEnd)
+	assert.Equal(t, "273100.00", payslip.Data.TaxCalculation.WithheldBefore)
+
+	var taxLines []models.PayslipLineItem
+	require.NoError(t, testDB.Where("type = ?", "income_tax").Order("created_at").Find(&taxLines).Error)
+	require.Len(t, taxLines, 2)
+	assert.Equal(t, "Income tax (PPh 21, TK/0), year-end true-up for 2024", taxLines[1].Description)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "update_tax_profile").Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestIncomeTax_WithheldByRunResumedAtStartup(t *testing.T) {
+	adminToken := getAdminToken(t, "resumetaxadmin", "adminpass")
+	getEmployeeToken(t, "resumetaxemp", "emppass", "10000000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "resumetaxemp").Error)
+	resp := putJSON(t, "/api/v1/admin/employees/"+emp.ID.String()+"/tax-profile", fiber.Map{"tax_id": "09.254.294.3-407.000", "marital_status": "single"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	november := attendFullMonth(t, emp, 2024, time.November)
+
+	// A run left queued by a previous process is picked up when the workers start, after the tax tables were loaded
+	run := models.PayrollRun{AttendancePeriodID: november.ID, Status: models.PayrollRunQueued}
+	require.NoError(t, testDB.Create(&run).Error)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	services.NewPayrollRunner(testDB, 10).Start(ctx, 1)
+	require.Eventually(t, func() bool {
+		if err := testDB.First(&run, "id = ?", run.ID).Error; err != nil {
+			return false
+		}
+		return run.Status == models.PayrollRunCompleted || run.Status == models.PayrollRunFailed
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Equal(t, models.PayrollRunCompleted, run.Status)
+
+	var payslip models.Payslip
+	require.NoError(t, testDB.First(&payslip, "employee_id = ? AND attendance_period_id = ?", emp.ID, november.ID).Error)
+	assert.Equal(t, "273100.00", payslip.IncomeTax.String())
+	assert.Equal(t, "400000.00", payslip.SocialSecurityDeduction.String(), "BPJS rates were loaded before the workers started too")
+	require.NotNil(t, payslip.TaxCalculation)
+}
+
+func TestIncomeTax_WeeklyPeriodsTrueUpOnLastWeekOfYear(t *testing.T) {
+	adminToken := getAdminToken(t, "weeklytaxadmin", "adminpass")
+	empToken := getEmployeeToken(t, "weeklytaxemp", "emppass", "2500000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "weeklytaxemp").Error)
+
+	weeks := []models.AttendancePeriod{
+		{StartDate: time.Date(2024, time.December, 16, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, time.December, 22, 0, 0, 0, 0, time.UTC)},
+		{StartDate: time.Date(2024, time.December, 23, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, time.December, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for i := range weeks {
+		require.NoError(t, testDB.Create(&weeks[i]).Error)
+		for day := weeks[i].StartDate; day.Weekday() != time.Saturday; day = day.AddDate(0, 0, 1) {
+			record := models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: weeks[i].ID, Date: day, CheckInTime: day.Add(9 * time.Hour)}
+			require.NoError(t, testDB.Create(&record).Error)
+		}
+	}
+
+	// A week in December is annualised over the year's 262 weekdays rather than settling the year
+	run := runPayrollAndWait(t, adminToken, weeks[0].ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+	resp := getWithToken(t, "/api/v1/employee/payslip?period_id="+weeks[0].ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var payslip taxedPayslip
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.False(t, payslip.Data.TaxCalculation.YearEnd)
+	assert.Equal(t, "52.4", payslip.Data.TaxCalculation.PeriodsPerYear)
+
+	// The last week starting in 2024 settles the year
+	run = runPayrollAndWait(t, adminToken, weeks[1].ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+	resp = getWithToken(t, "/api/v1/employee/payslip?period_id="+weeks[1].ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	payslip = taxedPayslip{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.True(t, payslip.Data.TaxCalculation.YearEnd)
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeave_RequestApprovalAndPayroll(t *testing.T) {
+	adminToken := getAdminToken(t, "leaveadmin", "adminpass")
+	empToken := getEmployeeToken(t, "leaveemp", "emppass", "5000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "leaveemp").Error)
+
+	// A Monday to Friday period next year, so the whole yearly entitlement has accrued for a new employee
+	monday := time.Date(time.Now().Year()+1, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	day := func(offset int) time.Time { return monday.AddDate(0, 0, offset) }
+	attPeriod := models.AttendancePeriod{StartDate: day(0), EndDate: day(4)}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	attRec := models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: day(0), CheckInTime: day(0).Add(9 * time.Hour)}
+	require.NoError(t, testDB.Create(&attRec).Error)
+
+	createLeaveType := func(payload fiber.Map) string {
+		resp, err := makeRequest("POST", "/api/v1/admin/leave-types", createJSONBody(payload), adminToken)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.Code)
+		var body map[string]interface{}
+		json.Unmarshal(resp.Body.Bytes(), &body)
+		return body["data"].(map[string]interface{})["ID"].(string)
+	}
+	annualID := createLeaveType(fiber.Map{"name": "Annual", "paid": true, "yearly_entitlement": 2})
+	unpaidID := createLeaveType(fiber.Map{"name": "Unpaid", "paid": false})
+
+	submit := func(leaveTypeID string, from, to int) (int, map[string]interface{}) {
+		payload := fiber.Map{"leave_type_id": leaveTypeID, "start_date": day(from).Format("2006-01-02"), "end_date": day(to).Format("2006-01-02"), "reason": "Family"}
+		resp, err := makeRequest("POST", "/api/v1/employee/leave-requests", createJSONBody(payload), empToken)
+		require.NoError(t, err)
+		var body map[string]interface{}
+		json.Unmarshal(resp.Body.Bytes(), &body)
+		return resp.Code, body
+	}
+
+	// Three days of annual leave exceed the entitlement of two
+	code, _ := submit(annualID, 1, 3)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, body := submit(annualID, 1, 2)
+	require.Equal(t, http.StatusCreated, code)
+	annualRequestID := body["data"].(map[string]interface{})["id"].(string)
+	assert.EqualValues(t, 2, body["data"].(map[string]interface{})["days"])
+
+	code, _ = submit(unpaidID, 2, 3)
+	assert.Equal(t, http.StatusConflict, code, "Overlapping requests are refused")
+
+	code, body = submit(unpaidID, 3, 3)
+	require.Equal(t, http.StatusCreated, code)
+	unpaidRequestID := body["data"].(map[string]interface{})["id"].(string)
+
+	resp, err := makeRequest("POST", "/api/v1/admin/leave-requests/"+annualRequestID+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp, err = makeRequest("POST", "/api/v1/admin/leave-requests/"+unpaidRequestID+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Balance: the two approved annual days are used
+	resp, err = makeRequest("GET", "/api/v1/employee/leave-balances?year="+day(0).Format("2006"), nil, empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var balanceBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &balanceBody)
+	balances := balanceBody["data"].([]interface{})
+	require.Len(t, balances, 2)
+	annual := balances[0].(map[string]interface{})
+	assert.Equal(t, "Annual", annual["leave_type"])
+	assert.EqualValues(t, 2, annual["used"])
+	assert.EqualValues(t, 0, annual["available"])
+
+	// Payroll: 1 attended + 2 paid leave days are paid, 1 unpaid leave day is deducted, Friday is an absence
+	resp, err = makeRequest("POST", "/api/v1/admin/payroll/preview", createJSONBody(fiber.Map{"attendance_period_id": attPeriod.ID.String()}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var previewBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &previewBody)
+	entry := previewBody["data"].(map[string]interface{})["employees"].([]interface{})[0].(map[string]interface{})
+	assert.EqualValues(t, 3, entry["attendance_count"])
+	assert.EqualValues(t, 2, entry["paid_leave_days"])
+	assert.EqualValues(t, 1, entry["unpaid_leave_days"])
+	assert.Equal(t, "1000.00", entry["unpaid_leave_deduction"])
+	assert.Equal(t, "3000.00", entry["prorated_salary"])
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action IN ?", []string{"submit_leave_request", "approve_leave_request"}).Count(&auditCount)
+	assert.Equal(t, int64(4), auditCount)
+}
+
+func TestRejectLeaveRequest_RequiresReason(t *testing.T) {
+	adminToken := getAdminToken(t, "leaveadmin2", "adminpass")
+	emp := models.Employee{Username: "leaveemp2", Password: "pw", Salary: models.MustParseMoney("3000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	leaveType := models.LeaveType{Name: "Sick", Paid: true, YearlyEntitlement: 10, Accrual: models.LeaveAccrualUpfront}
+	require.NoError(t, testDB.Create(&leaveType).Error)
+	start := time.Date(time.Now().Year()+1, time.April, 6, 0, 0, 0, 0, time.UTC)
+	leaveRequest := models.LeaveRequest{EmployeeID: emp.ID, LeaveTypeID: leaveType.ID, StartDate: start, EndDate: start, Days: 1, Status: models.LeavePending}
+	require.NoError(t, testDB.Create(&leaveRequest).Error)
+
+	resp, err := makeRequest("POST", "/api/v1/admin/leave-requests/"+leaveRequest.ID.String()+"/reject", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/leave-requests/"+leaveRequest.ID.String()+"/reject", createJSONBody(fiber.Map{"reason": "Busy week"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, testDB.First(&leaveRequest, "id = ?", leaveRequest.ID).Error)
+	assert.Equal(t, models.LeaveRejected, leaveRequest.Status)
+
+	resp, err = makeRequest("DELETE", "/api/v1/admin/leave-types/"+leaveType.ID.String(), nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code, "Leave types with requests cannot be deleted")
+}
+
+func TestLeaveRequest_CannotSpanYears(t *testing.T) {
+	adminToken := getAdminToken(t, "leaveadmin3", "adminpass")
+	empToken := getEmployeeToken(t, "leaveemp3", "emppass", "3000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "leaveemp3").Error)
+	leaveType := models.LeaveType{Name: "Annual", Paid: true, YearlyEntitlement: 12, Accrual: models.LeaveAccrualUpfront}
+	require.NoError(t, testDB.Create(&leaveType).Error)
+	newYearsEve := time.Date(time.Now().Year()+1, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	payload := fiber.Map{"leave_type_id": leaveType.ID.String(), "start_date": newYearsEve.Format("2006-01-02"), "end_date": newYearsEve.AddDate(0, 0, 2).Format("2006-01-02")}
+	resp, err := makeRequest("POST", "/api/v1/employee/leave-requests", createJSONBody(payload), empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// A request already spanning the boundary is not approved either, so its days are never charged to one year
+	leaveRequest := models.LeaveRequest{EmployeeID: emp.ID, LeaveTypeID: leaveType.ID, StartDate: newYearsEve, EndDate: newYearsEve.AddDate(0, 0, 2), Days: 2, Status: models.LeavePending}
+	require.NoError(t, testDB.Create(&leaveRequest).Error)
+	resp, err = makeRequest("POST", "/api/v1/admin/leave-requests/"+leaveRequest.ID.String()+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	require.NoError(t, testDB.First(&leaveRequest, "id = ?", leaveRequest.ID).Error)
+	assert.Equal(t, models.LeavePending, leaveRequest.Status)
+}
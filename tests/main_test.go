@@ -2,15 +2,20 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"log"
+	"mime/multipart"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"payslip-generator/pkg/config"
 	"payslip-generator/pkg/database"
 	"payslip-generator/pkg/middleware"
 	"payslip-generator/pkg/routes"
+	"payslip-generator/pkg/services"
+	"payslip-generator/pkg/storage"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -34,6 +39,24 @@ func TestMain(m *testing.M) {
 	// Initialize test database
 	testDB = database.InitTestDB()
 
+	// Keep receipts in a throwaway local store
+	receiptDir, err := os.MkdirTemp("", "receipts")
+	if err != nil {
+		log.Fatalf("Failed to create receipt directory: %v", err)
+	}
+	storage.Receipts, err = storage.NewLocalStore(receiptDir)
+	if err != nil {
+		log.Fatalf("Failed to set up receipt store: %v", err)
+	}
+
+	// Withhold income tax and deduct BPJS contributions by the tables shipped with the repository
+	if err := services.LoadIncomeTaxTables("../config/income_tax_tables.json"); err != nil {
+		log.Fatalf("Failed to load income tax tables: %v", err)
+	}
+	if err := services.LoadSocialSecurityTables("../config/social_security_rates.json"); err != nil {
+		log.Fatalf("Failed to load social security rates: %v", err)
+	}
+
 	// Setup test Fiber app
 	testApp = setupTestApp()
 
@@ -45,18 +68,24 @@ func TestMain(m *testing.M) {
 	// You might close DB connection here if InitTestDB opened a unique one for the suite.
 	// For now, InitTestDB uses config, so it's like the main app's DB but for test DB.
 
+	os.RemoveAll(receiptDir)
+
 	os.Exit(exitCode)
 }
 
 // setupTestApp initializes a Fiber app instance for testing.
 func setupTestApp() *fiber.App {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{StreamRequestBody: true, DisablePreParseMultipartForm: true})
 
 	// Use the global testDB instance initialized in TestMain
 	database.DB = testDB // Crucial: Point the app's DB to the testDB instance
 
+	// Payroll runs are processed in the background, as in main.go
+	services.StartPayrollWorkers(context.Background(), testDB, 1)
+
 	// Register middleware similar to main.go
 	app.Use(middleware.RequestID)
+	app.Use(middleware.LimitBody(fiber.DefaultBodyLimit, routes.AcceptsReceiptUpload))
 	app.Use(middleware.Logger) // You might want to disable verbose logging for tests or use a test-specific logger config
 	app.Use(middleware.DeserializeUser)
 
@@ -88,6 +117,56 @@ func makeRequest(method, url string, body io.Reader, token ...string) (*httptest
 	return respRec, nil
 }
 
+// makeUploadRequest sends a multipart/form-data request with a single file field
+func makeUploadRequest(url, field, filename string, content []byte, token string) (*http.Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		return nil, err
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return testApp.Test(req, -1)
+}
+
+// uploadFile is a file part of a multipart request
+type uploadFile struct {
+	Field    string
+	FileName string
+	Content  []byte
+}
+
+// makeMultipartRequest sends a multipart/form-data POST with form fields and any number of files
+func makeMultipartRequest(url string, fields map[string]string, files []uploadFile, token string) (*http.Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Field, file.FileName)
+		if err != nil {
+			return nil, err
+		}
+		part.Write(file.Content)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return testApp.Test(req, -1)
+}
+
 // Helper to create JSON body for requests
 func createJSONBody(data interface{}) *bytes.Buffer {
 	bodyBytes, _ := json.Marshal(data)
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOvertimeApproval_OnlyApprovedIsPaid(t *testing.T) {
+	adminToken := getAdminToken(t, "overtimeadmin", "adminpass")
+	emp := models.Employee{Username: "overtimeemp", Password: "pw", Salary: models.MustParseMoney("4000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	for d := 0; d < 5; d++ {
+		date := attPeriod.StartDate.AddDate(0, 0, d)
+		require.NoError(t, testDB.Create(&models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: date, CheckInTime: date.Add(9 * time.Hour)}).Error)
+	}
+	toApprove := models.OvertimeRecord{EmployeeID: emp.ID, Date: attPeriod.StartDate, Minutes: 120, RateMultiplier: 2.0, Status: models.OvertimePending}
+	toReject := models.OvertimeRecord{EmployeeID: emp.ID, Date: attPeriod.StartDate.AddDate(0, 0, 1), Minutes: 60, RateMultiplier: 2.0, Status: models.OvertimePending}
+	leftPending := models.OvertimeRecord{EmployeeID: emp.ID, Date: attPeriod.StartDate.AddDate(0, 0, 2), Minutes: 180, RateMultiplier: 2.0, Status: models.OvertimePending}
+	for _, ot := range []*models.OvertimeRecord{&toApprove, &toReject, &leftPending} {
+		require.NoError(t, testDB.Create(ot).Error)
+	}
+
+	resp, err := makeRequest("GET", "/api/v1/admin/overtime?period_id="+attPeriod.ID.String(), nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var listBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &listBody)
+	assert.Len(t, listBody["data"], 3)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/overtime/"+toApprove.ID.String()+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp, err = makeRequest("POST", "/api/v1/admin/overtime/"+toApprove.ID.String()+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code, "Already approved")
+
+	resp, err = makeRequest("POST", "/api/v1/admin/overtime/"+toReject.ID.String()+"/reject", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code, "Rejection needs a reason")
+	resp, err = makeRequest("POST", "/api/v1/admin/overtime/"+toReject.ID.String()+"/reject", createJSONBody(fiber.Map{"reason": "Not pre-authorised"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// 4000 over 5 days is 800/day, 100/hour; only the approved 2 hours at x2 are paid
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+	warnings, ok := run["Warnings"].([]interface{})
+	require.True(t, ok, "The run lists its warnings")
+	require.Len(t, warnings, 1)
+	warning := warnings[0].(map[string]interface{})
+	assert.Equal(t, "unapproved_overtime", warning["type"])
+	assert.Equal(t, leftPending.ID.String(), warning["source_id"])
+
+	var payslip models.Payslip
+	require.NoError(t, testDB.First(&payslip, "employee_id = ? AND attendance_period_id = ?", emp.ID, attPeriod.ID).Error)
+	assert.InDelta(t, 2.0, payslip.OvertimeHours, 0.001)
+	assert.Equal(t, "400.00", payslip.OvertimePay.String())
+
+	// Once payroll has run, the remaining overtime can no longer be approved into it
+	resp, err = makeRequest("POST", "/api/v1/admin/overtime/"+leftPending.ID.String()+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action IN ?", []string{"approve_overtime", "reject_overtime"}).Count(&auditCount)
+	assert.Equal(t, int64(2), auditCount)
+}
+
+func TestPayrollRun_ResumedRunKeepsSkippedEmployeesWarnings(t *testing.T) {
+	adminToken := getAdminToken(t, "resumeadmin", "adminpass")
+	emp := models.Employee{Username: "resumeemp", Password: "pw", Salary: models.MustParseMoney("4000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	pending := models.OvertimeRecord{EmployeeID: emp.ID, Date: attPeriod.StartDate, Minutes: 60, RateMultiplier: 2.0, Status: models.OvertimePending}
+	require.NoError(t, testDB.Create(&pending).Error)
+
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	// A run interrupted after the payslip was written resumes with the employee skipped
+	require.NoError(t, testDB.Model(&attPeriod).Update("payroll_run_at", nil).Error)
+	resumed := models.PayrollRun{AttendancePeriodID: attPeriod.ID, Status: models.PayrollRunRunning}
+	require.NoError(t, testDB.Create(&resumed).Error)
+	require.NoError(t, services.PayrollQueue.Process(resumed.ID))
+
+	require.NoError(t, testDB.First(&resumed, "id = ?", resumed.ID).Error)
+	assert.Equal(t, models.PayrollRunCompleted, resumed.Status)
+	assert.Equal(t, 1, resumed.SkippedEmployees)
+	var warnings []services.PayrollWarning
+	require.NoError(t, json.Unmarshal(resumed.Warnings, &warnings))
+	require.Len(t, warnings, 1, "The skipped payslip's warnings are still reported")
+	assert.Equal(t, services.WarningUnapprovedOvertime, warnings[0].Type)
+	assert.Equal(t, pending.ID, warnings[0].SourceID)
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOvertimePolicy_TieredPricingAndCaps(t *testing.T) {
+	adminToken := getAdminToken(t, "otpolicyadmin", "adminpass")
+	empToken := getEmployeeToken(t, "otpolicyemp", "emppass", "4000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "otpolicyemp").Error)
+
+	monday := time.Now().AddDate(0, 0, -14)
+	monday = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, time.Local)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, -1)
+	}
+	saturday := monday.AddDate(0, 0, 5)
+	attPeriod := models.AttendancePeriod{StartDate: monday, EndDate: monday.AddDate(0, 0, 6)}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	checkIn := monday.Add(9 * time.Hour)
+	attRec := models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: monday, CheckInTime: checkIn}
+	services.ApplyCheckOut(&attRec, checkIn.Add(13*time.Hour), models.CheckOutRecorded)
+	require.NoError(t, testDB.Create(&attRec).Error)
+
+	policyPayload := fiber.Map{
+		"name":                     "Company overtime 2024",
+		"effective_from":           monday.Format("2006-01-02"),
+		"workday_tiers":            []fiber.Map{{"hours": 1, "multiplier": 1.5}, {"hours": 0, "multiplier": 2}},
+		"rest_day_tiers":           []fiber.Map{{"hours": 0, "multiplier": 2}},
+		"daily_cap_hours":          3,
+		"rest_day_daily_cap_hours": 4,
+		"weekly_cap_hours":         5,
+		"rounding_minutes":         15,
+	}
+	invalid := fiber.Map{}
+	for k, v := range policyPayload {
+		invalid[k] = v
+	}
+	invalid["workday_tiers"] = []fiber.Map{{"hours": 1, "multiplier": 1.5}}
+	resp, err := makeRequest("POST", "/api/v1/admin/overtime-policies", createJSONBody(invalid), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code, "Tiers must cover the daily cap")
+
+	resp, err = makeRequest("POST", "/api/v1/admin/overtime-policies", createJSONBody(policyPayload), adminToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.Code)
+	var created struct {
+		Data models.OvertimePolicy `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+	assert.Equal(t, created.Data.HolidayTiers, created.Data.RestDayTiers, "Holidays default to the rest day tiers")
+
+	resp, err = makeRequest("GET", "/api/v1/admin/overtime-policies/effective?date="+saturday.Format("2006-01-02"), nil, adminToken)
+	require.NoError(t, err)
+	var effective struct {
+		Data models.OvertimePolicy `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &effective))
+	assert.Equal(t, created.Data.Version, effective.Data.Version)
+
+	submit := func(date time.Time, duration fiber.Map) int {
+		payload := fiber.Map{"date": date.Format("2006-01-02")}
+		for k, v := range duration {
+			payload[k] = v
+		}
+		resp, err := makeRequest("POST", "/api/v1/employee/overtime", createJSONBody(payload), empToken)
+		require.NoError(t, err)
+		return resp.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, submit(monday, fiber.Map{"hours": 4}), "Over the workday cap")
+	assert.Equal(t, http.StatusBadRequest, submit(monday, fiber.Map{"hours": 1, "minutes": 30}), "One way of giving the duration")
+	assert.Equal(t, http.StatusBadRequest, submit(monday, fiber.Map{"start_time": "21:00", "end_time": "22:30"}), "Ends after the check-out")
+	// 17:00 to 19:52 is 172 minutes, rounded to the nearest 15
+	assert.Equal(t, http.StatusCreated, submit(monday, fiber.Map{"start_time": "17:00", "end_time": "19:52"}))
+	assert.Equal(t, http.StatusConflict, submit(monday, fiber.Map{"minutes": 30}), "One overtime record per day")
+
+	var workday models.OvertimeRecord
+	require.NoError(t, testDB.First(&workday, "employee_id = ? AND date = ?", emp.ID, monday).Error)
+	assert.Equal(t, models.OvertimeDayWorkday, workday.DayType)
+	require.NotNil(t, workday.PolicyVersion)
+	assert.Equal(t, created.Data.Version, *workday.PolicyVersion)
+	assert.Equal(t, 165, workday.Minutes)
+	require.NotNil(t, workday.StartTime)
+	assert.Equal(t, []models.OvertimeTierMinutes{{Minutes: 60, Multiplier: 1.5}, {Minutes: 105, Multiplier: 2}}, workday.Breakdown)
+
+	// Rest day overtime needs no attendance but counts towards the weekly cap of 5 hours
+	assert.Equal(t, http.StatusBadRequest, submit(saturday, fiber.Map{"hours": 3}), "Over the weekly cap")
+	assert.Equal(t, http.StatusBadRequest, submit(saturday, fiber.Map{"minutes": 5}), "Rounds to nothing")
+	assert.Equal(t, http.StatusCreated, submit(saturday, fiber.Map{"minutes": 120}))
+	var restDay models.OvertimeRecord
+	require.NoError(t, testDB.First(&restDay, "employee_id = ? AND date = ?", emp.ID, saturday).Error)
+	assert.Equal(t, models.OvertimeDayRestDay, restDay.DayType)
+	assert.Equal(t, []models.OvertimeTierMinutes{{Minutes: 120, Multiplier: 2}}, restDay.Breakdown)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "create_overtime_policy").Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
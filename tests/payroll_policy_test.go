@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayrollPolicy_ProrationAndRounding(t *testing.T) {
+	adminToken := getAdminToken(t, "payrollpolicyadmin", "adminpass")
+	empToken := getEmployeeToken(t, "payrollpolicyemp", "emppass", "3100")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "payrollpolicyemp").Error)
+
+	// March 2024 has 21 working days; the employee misses one of them
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	for day := attPeriod.StartDate; !day.After(attPeriod.EndDate); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || day.Day() == 29 {
+			continue
+		}
+		record := models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: day, CheckInTime: day.Add(9 * time.Hour)}
+		require.NoError(t, testDB.Create(&record).Error)
+	}
+
+	resp := getWithToken(t, "/api/v1/admin/payroll-policies/effective?date=2024-03-01", adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var effective struct {
+		Data models.PayrollPolicy `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&effective))
+	assert.Equal(t, 0, effective.Data.Version, "The built-in policy applies without any version")
+	assert.Equal(t, models.ProrationWorkingDays, effective.Data.ProrationMethod)
+
+	resp = postJSON(t, "/api/v1/admin/payroll-policies", fiber.Map{"name": "Fixed divisor", "effective_from": "2024-03-01", "proration_method": "divisor_22", "rounding_precision": 3}, adminToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Rounding below the cent")
+	resp = postJSON(t, "/api/v1/admin/payroll-policies", fiber.Map{"name": "Fixed divisor", "effective_from": "2024-03-01", "proration_method": "divisor_22", "rounding_precision": 0}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		Data models.PayrollPolicy `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.Equal(t, 1, created.Data.Version)
+	assert.Equal(t, models.PayrollRoundHalfUp, created.Data.RoundingMode, "Rounding mode defaults to half up")
+
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	// 3100 / 22 * 21 is 2959.09, rounded to a whole amount, less 30 + 59 + 30 BPJS on it
+	resp = getWithToken(t, "/api/v1/employee/payslip?period_id="+attPeriod.ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var payslip struct {
+		Data struct {
+			ProratedSalary       string                     `json:"prorated_salary"`
+			TakeHomePay          string                     `json:"take_home_pay"`
+			PayrollPolicyVersion int                        `json:"payroll_policy_version"`
+			PayrollPolicy        *models.PayrollPolicyRules `json:"payroll_policy"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.Equal(t, "2959.00", payslip.Data.ProratedSalary)
+	assert.Equal(t, "2840.00", payslip.Data.TakeHomePay)
+	assert.Equal(t, 1, payslip.Data.PayrollPolicyVersion)
+	require.NotNil(t, payslip.Data.PayrollPolicy)
+	assert.Equal(t, models.PayrollPolicyRules{ProrationMethod: models.ProrationDivisor22, RoundingMode: models.PayrollRoundHalfUp, RoundingPrecision: 0}, *payslip.Data.PayrollPolicy)
+
+	// A later version does not change payslips already calculated
+	resp = postJSON(t, "/api/v1/admin/payroll-policies", fiber.Map{"name": "Calendar days", "effective_from": "2024-03-01", "proration_method": "calendar_days"}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var stored models.Payslip
+	require.NoError(t, testDB.First(&stored, "employee_id = ? AND attendance_period_id = ?", emp.ID, attPeriod.ID).Error)
+	assert.Equal(t, 1, stored.PayrollPolicyVersion)
+	assert.Equal(t, "2840.00", stored.TakeHomePay.String())
+
+	resp = getWithToken(t, "/api/v1/admin/payroll-policies", adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var listed struct {
+		Data []models.PayrollPolicy `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+	require.Len(t, listed.Data, 2)
+	assert.Equal(t, 2, listed.Data[0].Version, "Newest first")
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "create_payroll_policy").Count(&auditCount)
+	assert.Equal(t, int64(2), auditCount)
+}
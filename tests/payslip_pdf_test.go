@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayslipPDF(t *testing.T) {
+	adminToken := getAdminToken(t, "pdfadmin", "adminpass")
+	empToken := getEmployeeToken(t, "pdfemp", "emppass", "4000")
+	getEmployeeToken(t, "pdfother", "emppass", "5000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "pdfemp").Error)
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	for d := 0; d < 5; d++ {
+		date := attPeriod.StartDate.AddDate(0, 0, d)
+		require.NoError(t, testDB.Create(&models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: date, CheckInTime: date.Add(9 * time.Hour)}).Error)
+	}
+	overtime := models.OvertimeRecord{EmployeeID: emp.ID, Date: attPeriod.StartDate, Minutes: 90, RateMultiplier: 1.5,
+		Breakdown: []models.OvertimeTierMinutes{{Minutes: 60, Multiplier: 1.5}, {Minutes: 30, Multiplier: 2}}, Status: models.OvertimeApproved}
+	require.NoError(t, testDB.Create(&overtime).Error)
+	require.NoError(t, testDB.Create(&models.ReimbursementRequest{EmployeeID: emp.ID, Description: "Client dinner", Amount: models.MustParseMoney("120"), Status: models.ReimbursementApproved}).Error)
+
+	resp := getWithToken(t, "/api/v1/employee/payslip.pdf?period_id="+attPeriod.ID.String(), empToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "No payslip before payroll runs")
+
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	// The run keeps the payslip's lines: salary, one per overtime tier, the reimbursement and the three BPJS deductions
+	var payslip models.Payslip
+	require.NoError(t, testDB.Preload("LineItems").First(&payslip, "employee_id = ? AND attendance_period_id = ?", emp.ID, attPeriod.ID).Error)
+	require.Len(t, payslip.LineItems, 7)
+
+	resp = getWithToken(t, "/api/v1/employee/payslip.pdf", empToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = getWithToken(t, "/api/v1/employee/payslip.pdf?period_id="+attPeriod.ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "payslip-pdfemp-2024-03-04.pdf")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "%PDF-1.4")
+	assert.Contains(t, string(body), "(Overtime on 2024-03-04, workday (x2))")
+	assert.Contains(t, string(body), "(Client dinner)")
+
+	again := getWithToken(t, "/api/v1/employee/payslip.pdf?period_id="+attPeriod.ID.String(), empToken)
+	againBody, err := io.ReadAll(again.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, againBody, "The same payslip renders to the same bytes")
+
+	// The admin variant renders every payslip of the period in one document
+	resp = getWithToken(t, "/api/v1/admin/payslips.pdf?period_id="+attPeriod.ID.String(), adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "/Count 2")
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "payslips-2024-03-04-to-2024-03-08.pdf")
+
+	resp = getWithToken(t, "/api/v1/admin/payslips.pdf?period_id="+attPeriod.ID.String()+"&employee_id="+emp.ID.String(), adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "/Count 1")
+
+	resp = getWithToken(t, "/api/v1/admin/payslips.pdf?period_id="+attPeriod.ID.String(), empToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"payslip-generator/pkg/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postJSON sends a JSON POST and returns the response, which makeRequest does not
+func postJSON(t *testing.T, url string, body interface{}, token string) *http.Response {
+	req := httptest.NewRequest("POST", url, createJSONBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestReimbursementCategoryLimits(t *testing.T) {
+	adminToken := getAdminToken(t, "categoryadmin", "adminpass")
+	empToken := getEmployeeToken(t, "categoryemp", "emppass", "5000")
+
+	resp := postJSON(t, "/api/v1/admin/reimbursement-categories", fiber.Map{"name": "Medical", "max_per_claim": 500, "cap_amount": 800, "cap_period": "period"}, adminToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Cap window must match the cap")
+	resp = postJSON(t, "/api/v1/admin/reimbursement-categories", fiber.Map{"name": "Medical", "max_per_claim": 500, "cap_amount": 800, "cap_period": "year", "receipt_required": true}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		Data models.ReimbursementCategory `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	medicalID := created.Data.ID.String()
+	resp = postJSON(t, "/api/v1/admin/reimbursement-categories", fiber.Map{"name": "Medical"}, adminToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = getWithToken(t, "/api/v1/employee/reimbursement-categories", empToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Employees can see the categories")
+
+	// A category is required
+	resp = postJSON(t, "/api/v1/employee/reimbursements", fiber.Map{"amount": 100, "description": "Clinic"}, empToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	pdf := []byte("%PDF-1.4\n%%EOF\n")
+	submit := func(amount string) *http.Response {
+		resp, err := makeMultipartRequest("/api/v1/employee/reimbursements", map[string]string{"category_id": medicalID, "amount": amount, "description": "Clinic"},
+			[]uploadFile{{Field: "receipts", FileName: "clinic.pdf", Content: pdf}}, empToken)
+		require.NoError(t, err)
+		return resp
+	}
+	require.Equal(t, http.StatusCreated, submit("500").StatusCode)
+
+	// Over the per-claim maximum and the yearly cap, and without the required receipt: every violation is listed
+	resp = postJSON(t, "/api/v1/employee/reimbursements", fiber.Map{"category_id": medicalID, "amount": 600, "description": "Dentist"}, empToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var refused struct {
+		Status string `json:"status"`
+		Data   []struct {
+			Code      string  `json:"code"`
+			Message   string  `json:"message"`
+			Available *string `json:"available"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&refused))
+	assert.Equal(t, "fail", refused.Status)
+	require.Len(t, refused.Data, 3)
+	assert.Equal(t, "exceeds_max_per_claim", refused.Data[0].Code)
+	assert.Equal(t, "exceeds_yearly_cap", refused.Data[1].Code)
+	require.NotNil(t, refused.Data[1].Available)
+	assert.Equal(t, "300.00", *refused.Data[1].Available)
+	assert.Equal(t, "receipt_required", refused.Data[2].Code)
+
+	// What is left of the cap can still be claimed; rejected claims do not count
+	require.Equal(t, http.StatusCreated, submit("300").StatusCode)
+	assert.Equal(t, http.StatusUnprocessableEntity, submit("0.01").StatusCode)
+	require.NoError(t, testDB.Model(&models.ReimbursementRequest{}).Where("amount = ?", 300).Update("status", models.ReimbursementRejected).Error)
+	assert.Equal(t, http.StatusCreated, submit("300").StatusCode)
+
+	var count int64
+	testDB.Model(&models.ReimbursementRequest{}).Where("category_id = ?", medicalID).Count(&count)
+	assert.Equal(t, int64(3), count)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/admin/reimbursement-categories/"+medicalID, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "A category in use cannot be deleted")
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewReimbursements(t *testing.T) {
+	adminToken := getAdminToken(t, "reviewadmin", "reviewpass")
+
+	emp := models.Employee{Username: "reviewemp", Password: "pw", Salary: models.MustParseMoney("5000")}
+	require.NoError(t, testDB.Create(&emp).Error)
+	toApprove := models.ReimbursementRequest{EmployeeID: emp.ID, Description: "Taxi", Amount: models.MustParseMoney("50"), Status: models.ReimbursementPending}
+	toReject := models.ReimbursementRequest{EmployeeID: emp.ID, Description: "Gym", Amount: models.MustParseMoney("80"), Status: models.ReimbursementPending}
+	require.NoError(t, testDB.Omit("AttendancePeriodID").Create(&toApprove).Error)
+	require.NoError(t, testDB.Omit("AttendancePeriodID").Create(&toReject).Error)
+
+	// Both show up in the pending list
+	resp, err := makeRequest("GET", "/api/v1/admin/reimbursements?employee_id="+emp.ID.String(), nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var body map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &body)
+	assert.Len(t, body["data"], 2)
+	assert.EqualValues(t, 2, body["pagination"].(map[string]interface{})["total_items"])
+
+	resp, err = makeRequest("POST", "/api/v1/admin/reimbursements/"+toApprove.ID.String()+"/approve", nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Rejection needs a reason
+	resp, err = makeRequest("POST", "/api/v1/admin/reimbursements/"+toReject.ID.String()+"/reject", createJSONBody(fiber.Map{}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/reimbursements/"+toReject.ID.String()+"/reject", createJSONBody(fiber.Map{"reason": "Not a business expense"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// A decided request cannot be decided again
+	resp, err = makeRequest("POST", "/api/v1/admin/reimbursements/"+toApprove.ID.String()+"/reject", createJSONBody(fiber.Map{"reason": "Changed my mind"}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	var approved, rejected models.ReimbursementRequest
+	require.NoError(t, testDB.First(&approved, "id = ?", toApprove.ID).Error)
+	require.NoError(t, testDB.First(&rejected, "id = ?", toReject.ID).Error)
+	assert.Equal(t, models.ReimbursementApproved, approved.Status)
+	assert.NotNil(t, approved.UpdatedBy)
+	assert.Equal(t, models.ReimbursementRejected, rejected.Status)
+	require.NotNil(t, rejected.ReviewReason)
+	assert.Equal(t, "Not a business expense", *rejected.ReviewReason)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action IN ?", []string{"approve_reimbursement", "reject_reimbursement"}).Count(&auditCount)
+	assert.Equal(t, int64(2), auditCount)
+}
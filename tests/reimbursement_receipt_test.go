@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getWithToken(t *testing.T, url, token string) *http.Response {
+	req := httptest.NewRequest("GET", url, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestReimbursementReceipts(t *testing.T) {
+	adminToken := getAdminToken(t, "receiptadmin", "adminpass")
+	empToken := getEmployeeToken(t, "receiptemp", "emppass", "5000")
+	otherToken := getEmployeeToken(t, "receiptother", "otherpass", "5000")
+
+	category := models.ReimbursementCategory{Name: "Receipt test meals", CapPeriod: models.ReimbursementCapNone}
+	require.NoError(t, testDB.Create(&category).Error)
+	pdf := []byte("%PDF-1.4\n1 0 obj << >> endobj\n%%EOF\n")
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+	// Submit with two receipts in one multipart request
+	resp, err := makeMultipartRequest("/api/v1/employee/reimbursements", map[string]string{"category_id": category.ID.String(), "amount": "75.5", "description": "Client dinner"},
+		[]uploadFile{{Field: "receipts", FileName: "dinner.pdf", Content: pdf}, {Field: "receipts", FileName: "photo.png", Content: png}}, empToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var submitted struct {
+		Data models.ReimbursementRequest `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&submitted))
+	requestID := submitted.Data.ID.String()
+	assert.Equal(t, "75.50", submitted.Data.Amount.String())
+	require.Len(t, submitted.Data.Receipts, 2)
+
+	// A file that is not an image or PDF is rejected and nothing is created
+	resp, err = makeMultipartRequest("/api/v1/employee/reimbursements", map[string]string{"category_id": category.ID.String(), "amount": "10", "description": "Snacks"},
+		[]uploadFile{{Field: "receipts", FileName: "receipt.pdf", Content: []byte("plain text pretending to be a PDF")}}, empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	var count int64
+	testDB.Model(&models.ReimbursementRequest{}).Where("description = ?", "Snacks").Count(&count)
+	assert.Zero(t, count)
+
+	// Another receipt is added later; only the owner may add one. It is larger than
+	// the body limit of other routes but within the size allowed for a receipt
+	scan := append(append([]byte{}, pdf...), bytes.Repeat([]byte("%"), 4*1024*1024+512*1024)...)
+	resp, err = makeUploadRequest("/api/v1/employee/reimbursements/"+requestID+"/receipts", "receipts", "parking.pdf", scan, otherToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, err = makeUploadRequest("/api/v1/employee/reimbursements/"+requestID+"/receipts", "receipts", "parking.pdf", scan, empToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = getWithToken(t, "/api/v1/employee/reimbursements/"+requestID+"/receipts", empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var listed struct {
+		Data []struct {
+			ID          string `json:"id"`
+			FileName    string `json:"file_name"`
+			ContentType string `json:"content_type"`
+			Checksum    string `json:"checksum"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+	require.Len(t, listed.Data, 3)
+	var pngReceiptID string
+	for _, r := range listed.Data {
+		if r.FileName == "photo.png" {
+			pngReceiptID = r.ID
+			assert.Equal(t, "image/png", r.ContentType)
+		}
+	}
+	require.NotEmpty(t, pngReceiptID)
+
+	// The owner and admins can download it; other employees cannot
+	sum := sha256.Sum256(png)
+	for _, download := range []struct{ url, token string }{
+		{"/api/v1/employee/reimbursements/" + requestID + "/receipts/" + pngReceiptID, empToken},
+		{"/api/v1/admin/reimbursements/" + requestID + "/receipts/" + pngReceiptID, adminToken},
+	} {
+		resp = getWithToken(t, download.url, download.token)
+		require.Equal(t, http.StatusOK, resp.StatusCode, download.url)
+		data, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, png, data)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=photo.png`, resp.Header.Get("Content-Disposition"))
+		assert.Equal(t, hex.EncodeToString(sum[:]), resp.Header.Get("X-Checksum-SHA256"))
+	}
+	resp = getWithToken(t, "/api/v1/employee/reimbursements/"+requestID+"/receipts/"+pngReceiptID, otherToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp = getWithToken(t, "/api/v1/admin/reimbursements/"+requestID+"/receipts/"+pngReceiptID, empToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// The admin list shows how many receipts a request has
+	resp = getWithToken(t, "/api/v1/admin/reimbursements?employee_id="+submitted.Data.EmployeeID.String(), adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var adminList struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&adminList))
+	require.Len(t, adminList.Data, 1)
+	assert.EqualValues(t, 3, adminList.Data[0]["receipt_count"])
+
+	// Once decided, a request takes no more receipts
+	require.NoError(t, testDB.Model(&models.ReimbursementRequest{}).Where("id = ?", requestID).Update("status", models.ReimbursementApproved).Error)
+	resp, err = makeUploadRequest("/api/v1/employee/reimbursements/"+requestID+"/receipts", "receipts", "late.pdf", pdf, empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ?", "upload_reimbursement_receipt").Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestRequestBodyLimit(t *testing.T) {
+	// Routes that don't take receipts keep the default body limit
+	body := `{"username":"` + strings.Repeat("a", fiber.DefaultBodyLimit) + `","password":"secret"}`
+	req := httptest.NewRequest("POST", "/api/v1/employee/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	// Receipt uploads are still refused above the size of a full set of receipts
+	empToken := getEmployeeToken(t, "bodylimitemp", "emppass", "5000")
+	big := bytes.Repeat([]byte("%"), services.MaxReceiptUploadSize)
+	resp, err = makeUploadRequest("/api/v1/employee/reimbursements/"+uuid.New().String()+"/receipts", "receipts", "huge.pdf", big, empToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
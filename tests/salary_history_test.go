@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSalaryHistory_MidPeriodRaise(t *testing.T) {
+	adminToken := getAdminToken(t, "salaryhistoryadmin", "adminpass")
+	empToken := getEmployeeToken(t, "salaryhistoryemp", "emppass", "3100")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "salaryhistoryemp").Error)
+
+	var opening []models.SalaryHistory
+	require.NoError(t, testDB.Where("employee_id = ?", emp.ID).Find(&opening).Error)
+	require.Len(t, opening, 1, "Creating an employee opens the salary history")
+	assert.Equal(t, "3100.00", opening[0].Salary.String())
+	// As if the employee had been hired at the start of 2024
+	require.NoError(t, testDB.Model(&opening[0]).Update("effective_from", "2024-01-01").Error)
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	for day := attPeriod.StartDate; !day.After(attPeriod.EndDate); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		record := models.AttendanceRecord{EmployeeID: emp.ID, AttendancePeriodID: attPeriod.ID, Date: day, CheckInTime: day.Add(9 * time.Hour)}
+		require.NoError(t, testDB.Create(&record).Error)
+	}
+
+	historyURL := "/api/v1/admin/employees/" + emp.ID.String() + "/salary-history"
+	resp := postJSON(t, historyURL, fiber.Map{"salary": "4200", "effective_from": "2024-03-15", "reason": "Promotion"}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp = postJSON(t, historyURL, fiber.Map{"salary": "4200", "effective_from": "15/03/2024"}, adminToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// A raise from April, given before March is paid, does not apply to March
+	req := httptest.NewRequest("PUT", "/api/v1/admin/employees/"+emp.ID.String(), createJSONBody(fiber.Map{"salary": 5000, "salary_effective_from": "2024-04-01"}))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var updated map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+	assert.Equal(t, "5000.00", updated["data"].(map[string]interface{})["salary"], "The employee's salary is the one in force today")
+
+	run := runPayrollAndWait(t, adminToken, attPeriod.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	resp = getWithToken(t, "/api/v1/employee/payslip?period_id="+attPeriod.ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var payslip struct {
+		Data struct {
+			BaseSalary     string `json:"base_salary"`
+			ProratedSalary string `json:"prorated_salary"`
+			SalarySegments []struct {
+				Salary      string `json:"salary"`
+				WorkingDays int    `json:"working_days"`
+				Amount      string `json:"amount"`
+			} `json:"salary_segments"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.Equal(t, "4200.00", payslip.Data.BaseSalary)
+	assert.Equal(t, "3676.19", payslip.Data.ProratedSalary, "3100 / 21 * 10 + 4200 / 21 * 11")
+	require.Len(t, payslip.Data.SalarySegments, 2)
+	assert.Equal(t, "3100.00", payslip.Data.SalarySegments[0].Salary)
+	assert.Equal(t, 10, payslip.Data.SalarySegments[0].WorkingDays)
+	assert.Equal(t, "1476.19", payslip.Data.SalarySegments[0].Amount)
+	assert.Equal(t, "4200.00", payslip.Data.SalarySegments[1].Salary)
+
+	var lineItems []models.PayslipLineItem
+	require.NoError(t, testDB.Where("type = ?", "salary").Order("position").Find(&lineItems).Error)
+	assert.Len(t, lineItems, 2, "One salary line per segment")
+
+	// March is paid, so the history cannot change within it
+	resp = postJSON(t, historyURL, fiber.Map{"salary": "4500", "effective_from": "2024-03-20"}, adminToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = getWithToken(t, historyURL, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var history struct {
+		Data []struct {
+			Salary        string  `json:"salary"`
+			EffectiveFrom string  `json:"effective_from"`
+			Reason        *string `json:"reason"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	require.Len(t, history.Data, 3)
+	assert.Equal(t, "2024-04-01", history.Data[0].EffectiveFrom, "Newest first")
+	assert.Equal(t, "5000.00", history.Data[0].Salary)
+	require.NotNil(t, history.Data[1].Reason)
+	assert.Equal(t, "Promotion", *history.Data[1].Reason)
+
+	var auditCount int64
+	testDB.Model(&models.AuditLog{}).Where("action = ? AND target_resource_id = ?", "update_employee_salary", emp.ID).Count(&auditCount)
+	assert.Equal(t, int64(2), auditCount)
+}
+
+func TestSalaryHistory_ScheduledRaiseTakesEffectOnItsDate(t *testing.T) {
+	adminToken := getAdminToken(t, "scheduledraiseadmin", "adminpass")
+	getEmployeeToken(t, "scheduledraiseemp", "emppass", "3100")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "scheduledraiseemp").Error)
+
+	// A raise for next quarter is recorded, but today's salary is unchanged
+	effective := time.Now().AddDate(0, 3, 0)
+	resp := postJSON(t, "/api/v1/admin/employees/"+emp.ID.String()+"/salary-history", fiber.Map{"salary": "4200", "effective_from": effective.Format("2006-01-02")}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.NoError(t, testDB.First(&emp, "id = ?", emp.ID).Error)
+	assert.Equal(t, "3100.00", emp.Salary.String())
+
+	updated, err := services.ApplyDueSalaryChanges(testDB, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+
+	// On its date the raise becomes the employee's salary
+	updated, err = services.ApplyDueSalaryChanges(testDB, effective)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	require.NoError(t, testDB.First(&emp, "id = ?", emp.ID).Error)
+	assert.Equal(t, "4200.00", emp.Salary.String())
+}
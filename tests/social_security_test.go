@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"payslip-generator/pkg/services"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocialSecurity_DeductionsAndContributionReport(t *testing.T) {
+	adminToken := getAdminToken(t, "bpjsadmin", "adminpass")
+	empToken := getEmployeeToken(t, "bpjsemp", "emppass", "10000000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "bpjsemp").Error)
+	resp := putJSON(t, "/api/v1/admin/employees/"+emp.ID.String()+"/tax-profile", fiber.Map{"tax_id": "09.254.294.3-407.000", "marital_status": "single"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	period := attendFullMonth(t, emp, 2024, time.March)
+	resp = getWithToken(t, "/api/v1/admin/contributions-report?period_id="+period.ID.String(), adminToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Payroll has not been run yet")
+
+	run := runPayrollAndWait(t, adminToken, period.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	resp = getWithToken(t, "/api/v1/employee/payslip?period_id="+period.ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var payslip struct {
+		Data struct {
+			SocialSecurityDeduction string `json:"social_security_deduction"`
+			TaxableIncome           string `json:"taxable_income"`
+			IncomeTax               string `json:"income_tax"`
+			TakeHomePay             string `json:"take_home_pay"`
+			Contributions           []struct {
+				Program        string
+				EmployeeAmount string
+				EmployerAmount string
+			} `json:"contributions"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.Equal(t, "400000.00", payslip.Data.SocialSecurityDeduction, "1% health, 2% JHT and 1% JP")
+	assert.Equal(t, "10454000.00", payslip.Data.TaxableIncome, "Employer health, JKK and JKM are taxable benefits")
+	assert.Equal(t, "273100.00", payslip.Data.IncomeTax)
+	assert.Equal(t, "9326900.00", payslip.Data.TakeHomePay)
+	require.Len(t, payslip.Data.Contributions, 5)
+	assert.Equal(t, models.ProgramHealth, payslip.Data.Contributions[0].Program)
+	assert.Equal(t, "400000.00", payslip.Data.Contributions[0].EmployerAmount)
+
+	var lines []models.PayslipLineItem
+	require.NoError(t, testDB.Where("type = ?", services.LineItemSocialSecurity).Order("position").Find(&lines).Error)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "BPJS Kesehatan (1% of 10000000.00)", lines[0].Description)
+	assert.Equal(t, "-100000.00", lines[0].Amount.String())
+
+	// Voiding and re-running the period leaves only the new payslip's contributions in the report
+	resp = postJSON(t, "/api/v1/admin/payroll/void", fiber.Map{"attendance_period_id": period.ID.String(), "reason": "Late attendance correction"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	run = runPayrollAndWait(t, adminToken, period.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+
+	resp = getWithToken(t, "/api/v1/admin/contributions-report?period_id="+period.ID.String(), adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report struct {
+		Data services.ContributionReport `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	require.Len(t, report.Data.Employees, 1)
+	assert.Equal(t, "bpjsemp", report.Data.Employees[0].Username)
+	require.Len(t, report.Data.Programs, 5)
+	assert.Equal(t, 1, report.Data.Programs[0].Employees)
+	assert.Equal(t, "400000.00", report.Data.EmployeeAmount.String())
+	assert.Equal(t, "1024000.00", report.Data.EmployerAmount.String())
+	assert.Equal(t, "1424000.00", report.Data.Total.String())
+
+	resp = getWithToken(t, "/api/v1/admin/contributions-report?format=csv&period_id="+period.ID.String(), adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=bpjs-contributions-2024-03-01-2024-03-31.csv`, resp.Header.Get("Content-Disposition"))
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 11, "Header, five programs for the employee and five totals")
+	assert.Equal(t, []string{"2024-03-01", "2024-03-31", emp.ID.String(), "bpjsemp", "jp", "BPJS Ketenagakerjaan JP", "10000000.00", "0.01", "0.02", "100000.00", "200000.00", "300000.00"}, rows[3])
+
+	resp = getWithToken(t, "/api/v1/admin/contributions-report?format=xlsx&period_id="+period.ID.String(), adminToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSocialSecurity_PeriodBeforeFirstRates(t *testing.T) {
+	adminToken := getAdminToken(t, "bpjshistadmin", "adminpass")
+	empToken := getEmployeeToken(t, "bpjshistemp", "emppass", "4000000")
+	var emp models.Employee
+	require.NoError(t, testDB.First(&emp, "username = ?", "bpjshistemp").Error)
+
+	// The shipped rates start in March 2023; a backdated period is still paid, without contributions
+	period := attendFullMonth(t, emp, 2023, time.January)
+	run := runPayrollAndWait(t, adminToken, period.ID.String())
+	require.Equal(t, models.PayrollRunCompleted, run["Status"])
+	warnings := run["Warnings"].([]interface{})
+	require.Len(t, warnings, 1)
+	assert.Equal(t, services.WarningNoSocialSecurityRates, warnings[0].(map[string]interface{})["type"])
+
+	resp := getWithToken(t, "/api/v1/employee/payslip?period_id="+period.ID.String(), empToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var payslip struct {
+		Data struct {
+			SocialSecurityDeduction string `json:"social_security_deduction"`
+			TakeHomePay             string `json:"take_home_pay"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payslip))
+	assert.Equal(t, "0.00", payslip.Data.SocialSecurityDeduction)
+	assert.Equal(t, "4000000.00", payslip.Data.TakeHomePay, "Below PTKP, so no income tax either")
+}
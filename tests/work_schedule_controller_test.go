@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"payslip-generator/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkSchedules_DrivePayrollProration(t *testing.T) {
+	adminToken := getAdminToken(t, "scheduleadmin", "schedulepass")
+
+	payload := fiber.Map{"name": "Six-day", "working_days": []string{"mon", "tue", "wed", "thu", "fri", "sat"}, "hours_per_day": 6}
+	resp, err := makeRequest("POST", "/api/v1/admin/work-schedules", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var schedule models.WorkSchedule
+	require.NoError(t, testDB.First(&schedule, "name = ?", "Six-day").Error)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/work-schedules", createJSONBody(payload), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	attPeriod := models.AttendancePeriod{
+		StartDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:   time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC), // Saturday
+	}
+	require.NoError(t, testDB.Create(&attPeriod).Error)
+	standardEmp := models.Employee{Username: "standard.emp", Password: "pw", Salary: models.MustParseMoney("5000")}
+	sixDayEmp := models.Employee{Username: "sixday.emp", Password: "pw", Salary: models.MustParseMoney("6000")}
+	require.NoError(t, testDB.Create(&standardEmp).Error)
+	require.NoError(t, testDB.Create(&sixDayEmp).Error)
+
+	resp, err = makeRequest("PUT", "/api/v1/admin/employees/"+sixDayEmp.ID.String(), createJSONBody(fiber.Map{"work_schedule_id": schedule.ID.String()}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	overtime := models.OvertimeRecord{EmployeeID: sixDayEmp.ID, Date: attPeriod.StartDate, Minutes: 60, RateMultiplier: 2.0, Status: models.OvertimeApproved}
+	require.NoError(t, testDB.Create(&overtime).Error)
+
+	resp, err = makeRequest("POST", "/api/v1/admin/payroll/preview", createJSONBody(fiber.Map{"attendance_period_id": attPeriod.ID.String()}), adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var body struct {
+		Data struct {
+			Employees []struct {
+				Username         string `json:"username"`
+				WorkSchedule     string `json:"work_schedule"`
+				TotalWorkingDays int    `json:"total_working_days"`
+				OvertimePay      string `json:"overtime_pay"`
+			} `json:"employees"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	byName := map[string]int{}
+	for i, e := range body.Data.Employees {
+		byName[e.Username] = i
+	}
+	require.Len(t, body.Data.Employees, 2)
+	standard := body.Data.Employees[byName["standard.emp"]]
+	sixDay := body.Data.Employees[byName["sixday.emp"]]
+	assert.Equal(t, "Standard", standard.WorkSchedule)
+	assert.Equal(t, 5, standard.TotalWorkingDays)
+	assert.Equal(t, "Six-day", sixDay.WorkSchedule)
+	assert.Equal(t, 6, sixDay.TotalWorkingDays)
+	assert.Equal(t, "333.33", sixDay.OvertimePay) // 6000 / 6 days / 6 hours * 2
+
+	// An assigned schedule cannot be deleted
+	resp, err = makeRequest("DELETE", "/api/v1/admin/work-schedules/"+schedule.ID.String(), nil, adminToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}